@@ -22,12 +22,16 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Recurrence time zones are loaded from the binary, as the alpine image
+	// has no zoneinfo
+	_ "time/tzdata"
 
 	_ "todo-list-api/docs"
 	"todo-list-api/internal/server"
+	"todo-list-api/internal/service"
 )
 
-func gracefulShutdown(apiServer *http.Server, done chan bool) {
+func gracefulShutdown(apiServer *http.Server, reminders *service.ReminderScheduler, retention *service.DataRetention, done chan bool) {
 	// Create context that listens for the interrupt signal from the OS.
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -46,6 +50,15 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 		log.Printf("Server forced to shutdown with error: %v", err)
 	}
 
+	// Reminders claimed but not delivered yet are left to the other instances
+	if err := reminders.Stop(ctx); err != nil {
+		log.Printf("Reminder scheduler forced to stop with error: %v", err)
+	}
+
+	if err := retention.Stop(ctx); err != nil {
+		log.Printf("Data retention forced to stop with error: %v", err)
+	}
+
 	log.Println("Server exiting")
 
 	// Notify the main goroutine that the shutdown is complete
@@ -54,13 +67,13 @@ func gracefulShutdown(apiServer *http.Server, done chan bool) {
 
 func main() {
 
-	server := server.NewServer()
+	server, reminders, retention := server.NewServer()
 
 	// Create a done channel to signal when the shutdown is complete
 	done := make(chan bool, 1)
 
 	// Run graceful shutdown in a separate goroutine
-	go gracefulShutdown(server, done)
+	go gracefulShutdown(server, reminders, retention, done)
 
 	err := server.ListenAndServe()
 	if err != nil && err != http.ErrServerClosed {
@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Event types
+const (
+	// EventAccountLocked is recorded when failed logins lock an account
+	EventAccountLocked = "account.locked"
+)
+
+// Event is a security-relevant occurrence worth keeping a trace of
+type Event struct {
+	Type   string    `json:"type"`
+	Time   time.Time `json:"time"`
+	UserID uint64    `json:"userId,omitempty"`
+	Email  string    `json:"email,omitempty"`
+	IP     string    `json:"ip,omitempty"`
+	// Details holds event-specific values
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Logger records audit events. Recording must not fail the operation being
+// audited, so implementations handle their own errors.
+type Logger interface {
+	Record(ctx context.Context, event Event)
+}
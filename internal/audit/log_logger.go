@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// LogLogger writes audit events to the application log as JSON, one per line
+type LogLogger struct{}
+
+// NewLogLogger creates a new LogLogger
+func NewLogLogger() *LogLogger {
+	return &LogLogger{}
+}
+
+func (l *LogLogger) Record(ctx context.Context, event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	entry, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode audit event %s: %v", event.Type, err)
+		return
+	}
+	log.Printf("audit: %s", entry)
+}
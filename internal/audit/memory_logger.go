@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryLogger keeps audit events in memory. It is an in-process fake for tests.
+type MemoryLogger struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewMemoryLogger creates a new MemoryLogger
+func NewMemoryLogger() *MemoryLogger {
+	return &MemoryLogger{}
+}
+
+func (l *MemoryLogger) Record(ctx context.Context, event Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+// Events returns every event recorded so far
+func (l *MemoryLogger) Events() []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Event(nil), l.events...)
+}
@@ -0,0 +1,19 @@
+package audit
+
+import "context"
+
+// MultiLogger records every audit event with each of its loggers
+type MultiLogger struct {
+	loggers []Logger
+}
+
+// NewMultiLogger creates a new MultiLogger
+func NewMultiLogger(loggers ...Logger) *MultiLogger {
+	return &MultiLogger{loggers: loggers}
+}
+
+func (l *MultiLogger) Record(ctx context.Context, event Event) {
+	for _, logger := range l.loggers {
+		logger.Record(ctx, event)
+	}
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+// StoreLogger saves audit events with a repository, so that they can be
+// queried later
+type StoreLogger struct {
+	repo repository.AuditEventRepository
+}
+
+// NewStoreLogger creates a new StoreLogger
+func NewStoreLogger(repo repository.AuditEventRepository) *StoreLogger {
+	return &StoreLogger{repo: repo}
+}
+
+func (l *StoreLogger) Record(ctx context.Context, event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	err := l.repo.Create(ctx, &models.AuditEvent{
+		Type:    event.Type,
+		Time:    event.Time,
+		UserID:  event.UserID,
+		Email:   event.Email,
+		IP:      event.IP,
+		Details: event.Details,
+	})
+	if err != nil {
+		log.Printf("Failed to store audit event %s: %v", event.Type, err)
+	}
+}
@@ -0,0 +1,292 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type AdminController struct {
+	adminService service.AdminService
+	validator    *validator.Validate
+}
+
+// NewAdminController creates a new instance of AdminController
+func NewAdminController(adminService service.AdminService) *AdminController {
+	return &AdminController{
+		adminService: adminService,
+		validator:    validator.New(),
+	}
+}
+
+// @Summary List users
+// @Description List and search user accounts. Requires the users:read permission.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param search query string false "Text matched against email, first and last name"
+// @Param role query string false "Filter by role" Enums(user, admin)
+// @Param disabled query bool false "Filter by disabled status"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} models.UserPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/users [get]
+func (c *AdminController) ListUsers(w http.ResponseWriter, r *http.Request) {
+	req, err := parseListUsersRequest(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := c.adminService.ListUsers(r.Context(), req)
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to get users")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, page)
+}
+
+// @Summary Get user
+// @Description Get any user account. Requires the users:read permission.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/users/{id} [get]
+func (c *AdminController) GetUser(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUserID(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	user, err := c.adminService.GetUser(r.Context(), id)
+	if err != nil {
+		writeAdminError(w, err, "Failed to get user")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, user)
+}
+
+// @Summary Disable user
+// @Description Disable an account: the user is logged out everywhere and cannot log in until re-enabled. Requires the users:manage permission.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/users/{id}/disable [post]
+func (c *AdminController) DisableUser(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUserID(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := c.adminService.DisableUser(r.Context(), adminID, id); err != nil {
+		writeAdminError(w, err, "Failed to disable user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Enable user
+// @Description Re-enable a disabled account. Requires the users:manage permission.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/users/{id}/enable [post]
+func (c *AdminController) EnableUser(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUserID(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := c.adminService.EnableUser(r.Context(), id); err != nil {
+		writeAdminError(w, err, "Failed to enable user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Force password reset
+// @Description Log the user out everywhere, block logging in until the password is reset and email them a reset link. Requires the users:manage permission.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 202 "Accepted"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/users/{id}/password-reset [post]
+func (c *AdminController) ForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUserID(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := c.adminService.ForcePasswordReset(r.Context(), id); err != nil {
+		writeAdminError(w, err, "Failed to force password reset")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// @Summary Get user's todos
+// @Description Get a page of any user's todos, for support. Accepts the filters of GET /api/todos. Requires the todos:read_all permission.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page"
+// @Param completed query bool false "Filter by completion status"
+// @Param sort query string false "Sort field" Enums(createdAt, updatedAt, dueDate, priority, title)
+// @Param order query string false "Sort direction" Enums(asc, desc)
+// @Success 200 {object} models.TodoPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/users/{id}/todos [get]
+func (c *AdminController) GetUserTodos(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUserID(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	req, err := parseListTodosRequest(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := c.adminService.GetUserTodos(r.Context(), id, req)
+	if err != nil {
+		if err.Error() == "invalid cursor" {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeAdminError(w, err, "Failed to get todos")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, page)
+}
+
+// writeAdminError maps the errors of AdminService to responses
+func writeAdminError(w http.ResponseWriter, err error, fallback string) {
+	switch err.Error() {
+	case "user not found":
+		httputils.WriteError(w, http.StatusNotFound, "User not found")
+	case "invalid user ID", "cannot disable your own account":
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputils.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func parseUserID(r *http.Request) (uint, error) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil || id == 0 {
+		return 0, errors.New("invalid user ID")
+	}
+	return uint(id), nil
+}
+
+func parseListUsersRequest(r *http.Request) (*models.ListUsersRequest, error) {
+	query := r.URL.Query()
+	req := &models.ListUsersRequest{
+		Search: query.Get("search"),
+		Role:   query.Get("role"),
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid limit parameter")
+		}
+		req.Limit = limit
+	}
+
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid offset parameter")
+		}
+		req.Offset = offset
+	}
+
+	if v := query.Get("disabled"); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid disabled parameter")
+		}
+		req.Disabled = &disabled
+	}
+
+	return req, nil
+}
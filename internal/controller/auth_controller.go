@@ -2,9 +2,16 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"todo-list-api/internal/middleware"
 	"todo-list-api/internal/models"
 	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type AuthController struct {
@@ -23,7 +30,6 @@ func NewAuthController(authService service.AuthService) *AuthController {
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Security ApiKeyAuth
 // @Param user body models.CreateUserRequest true "User registration data"
 // @Success 201 {object} models.User
 // @Failure 400 {object} map[string]string
@@ -51,15 +57,16 @@ func (c *AuthController) Register(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Login user
-// @Description Authenticate user and return JWT tokens
+// @Description Authenticate user and return JWT tokens. For accounts with two-factor authentication only mfaRequired and mfaToken are returned; complete the login at /api/auth/mfa/verify.
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Security ApiKeyAuth
 // @Param credentials body models.LoginUserRequest true "User login credentials"
 // @Success 200 {object} models.Token
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string "Too many failed logins; retry after the Retry-After header's seconds"
 // @Failure 500 {object} map[string]string
 // @Router /api/auth/login [post]
 func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
@@ -69,13 +76,23 @@ func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := c.authService.Login(r.Context(), &req)
+	token, err := c.authService.Login(r.Context(), &req, clientInfo(r))
 	if err != nil {
+		var throttled *service.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			c.writeError(w, http.StatusTooManyRequests, "Too many failed login attempts, try again later")
+			return
+		}
 		// Check if it's an authentication error
 		if err.Error() == "invalid email or password" {
 			c.writeError(w, http.StatusUnauthorized, err.Error())
 			return
 		}
+		if err.Error() == "email not verified" || err.Error() == "account disabled" || err.Error() == "password reset required" {
+			c.writeError(w, http.StatusForbidden, err.Error())
+			return
+		}
 		// Check if it's a validation error
 		if isAuthValidationError(err) {
 			c.writeError(w, http.StatusBadRequest, err.Error())
@@ -93,7 +110,6 @@ func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Security ApiKeyAuth
 // @Param token body models.RefreshTokenRequest true "Refresh token data"
 // @Success 200 {object} models.Token
 // @Failure 400 {object} map[string]string
@@ -107,7 +123,7 @@ func (c *AuthController) Refresh(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := c.authService.RefreshToken(r.Context(), &req)
+	token, err := c.authService.RefreshToken(r.Context(), &req, clientInfo(r))
 	if err != nil {
 		// Check if it's a token validation error
 		if err.Error() == "invalid or expired refresh token" {
@@ -126,6 +142,438 @@ func (c *AuthController) Refresh(w http.ResponseWriter, r *http.Request) {
 	c.writeJSON(w, http.StatusOK, token)
 }
 
+// @Summary Request a password reset
+// @Description Email a single-use password reset link. The response is the same whether or not an account exists for the email.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.ForgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/password/forgot [post]
+func (c *AuthController) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.authService.ForgotPassword(r.Context(), &req); err != nil {
+		if isAuthValidationError(err) {
+			c.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error requesting password reset")
+		return
+	}
+
+	c.writeJSON(w, http.StatusAccepted, map[string]string{
+		"message": "If an account exists for this email, a password reset link has been sent",
+	})
+}
+
+// @Summary Reset password
+// @Description Set a new password using a reset token. All sessions of the user are ended.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.ResetPasswordRequest true "Reset token and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/password/reset [post]
+func (c *AuthController) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req models.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.authService.ResetPassword(r.Context(), &req); err != nil {
+		if err.Error() == "invalid or expired reset token" || isAuthValidationError(err) {
+			c.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error resetting password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Verify email
+// @Description Confirm the user's email address with the token sent on registration, or the new address of an email change
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.VerifyEmailRequest true "Verification token"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/verify [post]
+func (c *AuthController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req models.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.authService.VerifyEmail(r.Context(), &req); err != nil {
+		if err.Error() == "invalid or expired verification token" || isAuthValidationError(err) {
+			c.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err.Error() == "email already in use" {
+			c.writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error verifying email")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Resend verification email
+// @Description Email a new verification link. The response is the same whether or not an unverified account exists for the email.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.ResendVerificationRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/verify/resend [post]
+func (c *AuthController) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req models.ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.authService.ResendVerification(r.Context(), &req); err != nil {
+		if isAuthValidationError(err) {
+			c.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error sending verification email")
+		return
+	}
+
+	c.writeJSON(w, http.StatusAccepted, map[string]string{
+		"message": "If an unverified account exists for this email, a verification link has been sent",
+	})
+}
+
+// @Summary Logout
+// @Description Revoke the session of the given refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param token body models.RefreshTokenRequest true "Refresh token of the session to end"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/logout [post]
+func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.authService.Logout(r.Context(), userID, &req); err != nil {
+		if err.Error() == "invalid or expired refresh token" {
+			c.writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if isAuthValidationError(err) {
+			c.writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error during logout")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Logout everywhere
+// @Description Revoke every refresh token of the authenticated user
+// @Tags auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/logout-all [post]
+func (c *AuthController) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	if err := c.authService.LogoutAll(r.Context(), userID); err != nil {
+		c.writeError(w, http.StatusInternalServerError, "Error during logout")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary List sessions
+// @Description List the active sessions (logged-in devices) of the authenticated user
+// @Tags auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {array} models.Session
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/sessions [get]
+func (c *AuthController) GetSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	currentSessionID, _ := middleware.GetSessionIDFromContext(r.Context())
+
+	sessions, err := c.authService.GetSessions(r.Context(), userID, currentSessionID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, "Error retrieving sessions")
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, sessions)
+}
+
+// @Summary Revoke session
+// @Description End one of the authenticated user's sessions. Its tokens stop working immediately.
+// @Tags auth
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/sessions/{id} [delete]
+func (c *AuthController) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	if err := c.authService.RevokeSession(r.Context(), userID, chi.URLParam(r, "id")); err != nil {
+		if err.Error() == "session not found" {
+			c.writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error revoking session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Complete two-factor login
+// @Description Exchange the MFA token returned by login and a TOTP code (or a recovery code) for a token pair
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body models.VerifyMFARequest true "MFA token and code"
+// @Success 200 {object} models.Token
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/mfa/verify [post]
+func (c *AuthController) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req models.VerifyMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	token, err := c.authService.VerifyMFA(r.Context(), &req, clientInfo(r))
+	if err != nil {
+		switch {
+		case err.Error() == "invalid or expired mfa token" || err.Error() == "invalid mfa code":
+			c.writeError(w, http.StatusUnauthorized, err.Error())
+		case err.Error() == "too many failed attempts":
+			c.writeError(w, http.StatusTooManyRequests, err.Error())
+		case err.Error() == "account disabled" || err.Error() == "password reset required":
+			c.writeError(w, http.StatusForbidden, err.Error())
+		case isAuthValidationError(err):
+			c.writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			c.writeError(w, http.StatusInternalServerError, "Error verifying mfa code")
+		}
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, token)
+}
+
+// @Summary Start two-factor enrollment
+// @Description Generate a TOTP secret for the authenticated user. Add it to an authenticator app (the otpauth URI can be shown as a QR code), then confirm with a first code.
+// @Tags mfa
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.MFAEnrollment
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/mfa/enroll [post]
+func (c *AuthController) EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	enrollment, err := c.authService.EnrollMFA(r.Context(), userID)
+	if err != nil {
+		if err.Error() == "mfa already enabled" {
+			c.writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error enrolling mfa")
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, enrollment)
+}
+
+// @Summary Confirm two-factor enrollment
+// @Description Enable two-factor authentication with a first code from the authenticator app. The returned recovery codes are shown only once.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body models.ConfirmMFARequest true "TOTP code"
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/mfa/confirm [post]
+func (c *AuthController) ConfirmMFA(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.ConfirmMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	codes, err := c.authService.ConfirmMFA(r.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case err.Error() == "mfa already enabled" || err.Error() == "mfa enrollment not started":
+			c.writeError(w, http.StatusConflict, err.Error())
+		case err.Error() == "invalid mfa code" || isAuthValidationError(err):
+			c.writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			c.writeError(w, http.StatusInternalServerError, "Error confirming mfa")
+		}
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, codes)
+}
+
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes. Requires a login with a second factor in the last 15 minutes.
+// @Tags mfa
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/mfa/recovery-codes [post]
+func (c *AuthController) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	codes, err := c.authService.RegenerateRecoveryCodes(r.Context(), userID)
+	if err != nil {
+		if err.Error() == "mfa not enabled" {
+			c.writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error generating recovery codes")
+		return
+	}
+
+	c.writeJSON(w, http.StatusOK, codes)
+}
+
+// @Summary Disable two-factor authentication
+// @Description Remove the TOTP secret and recovery codes. Requires a login with a second factor in the last 15 minutes.
+// @Tags mfa
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/mfa [delete]
+func (c *AuthController) DisableMFA(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		c.writeError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	if err := c.authService.DisableMFA(r.Context(), userID); err != nil {
+		if err.Error() == "mfa not enabled" {
+			c.writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		c.writeError(w, http.StatusInternalServerError, "Error disabling mfa")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Helper methods
 
 func (c *AuthController) writeJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -147,6 +595,14 @@ func (c *AuthController) writeError(w http.ResponseWriter, status int, message s
 	}
 }
 
+// clientInfo describes the client of an authentication request
+func clientInfo(r *http.Request) *models.ClientInfo {
+	return &models.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IPAddress: httputils.ClientIP(r),
+	}
+}
+
 // isAuthValidationError checks if the error is a business logic validation error
 func isAuthValidationError(err error) bool {
 	validationErrors := []string{
@@ -158,6 +614,10 @@ func isAuthValidationError(err error) bool {
 		"password must be less than 128 characters",
 		"email and password are required",
 		"refresh token is required",
+		"reset token is required",
+		"verification token is required",
+		"mfa token is required",
+		"mfa code is required",
 		"invalid user ID",
 	}
 
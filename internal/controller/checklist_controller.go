@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ChecklistController struct {
+	checklistService service.ChecklistService
+	validator        *validator.Validate
+}
+
+// NewChecklistController creates a new instance of ChecklistController
+func NewChecklistController(checklistService service.ChecklistService) *ChecklistController {
+	return &ChecklistController{
+		checklistService: checklistService,
+		validator:        validator.New(),
+	}
+}
+
+// @Summary Get checklist
+// @Description List the checklist items of a todo owned by the authenticated user, in order
+// @Tags checklist
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {array} models.ChecklistItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/checklist [get]
+func (c *ChecklistController) GetChecklist(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	todoID, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	items, err := c.checklistService.GetChecklist(r.Context(), userID, todoID)
+	if err != nil {
+		writeChecklistError(w, err, "Failed to get checklist")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, items)
+}
+
+// @Summary Add checklist item
+// @Description Add an item to the checklist of a todo owned by the authenticated user. Items go to the end of the checklist unless a position is given. A todo has at most 100 items.
+// @Tags checklist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param item body models.CreateChecklistItemRequest true "Item title and optional position"
+// @Success 201 {object} models.ChecklistItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/checklist [post]
+func (c *ChecklistController) AddItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	todoID, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	var req models.CreateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := c.checklistService.AddItem(r.Context(), userID, todoID, &req)
+	if err != nil {
+		writeChecklistError(w, err, "Failed to add checklist item")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusCreated, item)
+}
+
+// @Summary Update checklist item
+// @Description Rename, check, uncheck or move a checklist item. Only the fields that are set change.
+// @Tags checklist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param itemId path int true "Checklist item ID"
+// @Param item body models.UpdateChecklistItemRequest true "Fields to change"
+// @Success 200 {object} models.ChecklistItem
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/checklist/{itemId} [patch]
+func (c *ChecklistController) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	todoID, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	itemID, err := parseUintParam(r, "itemId")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid checklist item ID")
+		return
+	}
+
+	var req models.UpdateChecklistItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	item, err := c.checklistService.UpdateItem(r.Context(), userID, todoID, itemID, &req)
+	if err != nil {
+		writeChecklistError(w, err, "Failed to update checklist item")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, item)
+}
+
+// @Summary Delete checklist item
+// @Description Remove an item from the checklist of a todo owned by the authenticated user
+// @Tags checklist
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param itemId path int true "Checklist item ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/checklist/{itemId} [delete]
+func (c *ChecklistController) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	todoID, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	itemID, err := parseUintParam(r, "itemId")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid checklist item ID")
+		return
+	}
+
+	if err := c.checklistService.DeleteItem(r.Context(), userID, todoID, itemID); err != nil {
+		writeChecklistError(w, err, "Failed to delete checklist item")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeChecklistError maps checklist service errors to responses. message is
+// returned for unexpected errors.
+func writeChecklistError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "todo not found", "invalid todo ID", "checklist item not found":
+		httputils.WriteError(w, http.StatusNotFound, err.Error())
+	case "checklist item title is required":
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+	case "checklist is full":
+		httputils.WriteError(w, http.StatusConflict, err.Error())
+	default:
+		httputils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type ClientKeyController struct {
+	keyService service.ClientKeyService
+	validator  *validator.Validate
+}
+
+// NewClientKeyController creates a new instance of ClientKeyController
+func NewClientKeyController(keyService service.ClientKeyService) *ClientKeyController {
+	return &ClientKeyController{
+		keyService: keyService,
+		validator:  validator.New(),
+	}
+}
+
+// @Summary List client keys
+// @Description List the client keys that were not revoked. Requires the root API key.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.ClientKey
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/client-keys [get]
+func (c *ClientKeyController) ListKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := c.keyService.ListKeys(r.Context())
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Error retrieving client keys")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, keys)
+}
+
+// @Summary Create client key
+// @Description Issue a key for a client application, optionally restricted to browser origins and with an expiry. The key is only returned in this response. Requires the root API key.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key body models.CreateClientKeyRequest true "Key name, owner, allowed origins and optional expiry"
+// @Success 201 {object} models.CreatedClientKey
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/client-keys [post]
+func (c *ClientKeyController) CreateKey(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateClientKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key, err := c.keyService.CreateKey(r.Context(), &req)
+	if err != nil {
+		if err.Error() == "expiration must be in the future" || strings.HasPrefix(err.Error(), "invalid origin") {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Error creating client key")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusCreated, key)
+}
+
+// @Summary Revoke client key
+// @Description Revoke a client key. Requests using it are rejected immediately. Requires the root API key.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Client key ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/client-keys/{id} [delete]
+func (c *ClientKeyController) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid client key ID")
+		return
+	}
+
+	if err := c.keyService.RevokeKey(r.Context(), id); err != nil {
+		if err.Error() == "key not found" {
+			httputils.WriteError(w, http.StatusNotFound, "Client key not found")
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Error revoking client key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type DataExportController struct {
+	exportService service.DataExportService
+}
+
+// NewDataExportController creates a new instance of DataExportController
+func NewDataExportController(exportService service.DataExportService) *DataExportController {
+	return &DataExportController{
+		exportService: exportService,
+	}
+}
+
+// @Summary Request data export
+// @Description Start building an archive of everything stored about the authenticated user: profile, todos including deleted ones, sessions and audit events, as JSON and CSV. Poll the export until it is ready to get its download link.
+// @Tags profile
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 202 {object} models.DataExport
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me/export [post]
+func (c *DataExportController) RequestExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	export, err := c.exportService.RequestExport(r.Context(), userID)
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Error requesting export")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusAccepted, export)
+}
+
+// @Summary Get data export
+// @Description Get the status of a data export. Once it is ready, downloadUrl is a link to the archive that works without authentication for 15 minutes.
+// @Tags profile
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path string true "Export ID"
+// @Success 200 {object} models.DataExport
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me/export/{id} [get]
+func (c *DataExportController) GetExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	export, err := c.exportService.GetExport(r.Context(), userID, chi.URLParam(r, "id"))
+	if err != nil {
+		if err.Error() == "export not found" {
+			httputils.WriteError(w, http.StatusNotFound, "Export not found")
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Error getting export")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, export)
+}
+
+// @Summary Download data export
+// @Description Download the zip archive of a data export through the signed link returned with the export
+// @Tags profile
+// @Produce application/zip
+// @Param id path string true "Export ID"
+// @Param expires query int true "Link expiry, in seconds since the epoch"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/exports/{id} [get]
+func (c *DataExportController) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil {
+		httputils.WriteError(w, http.StatusForbidden, "Invalid or expired link")
+		return
+	}
+
+	archive, err := c.exportService.GetArchive(r.Context(), chi.URLParam(r, "id"), expires, r.URL.Query().Get("signature"))
+	if err != nil {
+		switch err.Error() {
+		case "invalid or expired link":
+			httputils.WriteError(w, http.StatusForbidden, "Invalid or expired link")
+		case "export not found":
+			httputils.WriteError(w, http.StatusNotFound, "Export not found")
+		default:
+			httputils.WriteError(w, http.StatusInternalServerError, "Error downloading export")
+		}
+		return
+	}
+
+	filename := fmt.Sprintf("todo-list-export-%s.zip", time.Now().UTC().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive)
+}
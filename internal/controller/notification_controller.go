@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"net/http"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+)
+
+type NotificationController struct {
+	notificationService service.NotificationService
+}
+
+// NewNotificationController creates a new instance of NotificationController
+func NewNotificationController(notificationService service.NotificationService) *NotificationController {
+	return &NotificationController{
+		notificationService: notificationService,
+	}
+}
+
+// @Summary List notifications
+// @Description List the 100 newest in-app notifications of the authenticated user, such as fired reminders
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only list unread notifications"
+// @Success 200 {array} models.Notification
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/notifications [get]
+func (c *NotificationController) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	unreadOnly, err := parseBoolQuery(r, "unread")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notifications, err := c.notificationService.ListNotifications(r.Context(), userID, unreadOnly)
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to list notifications")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, notifications)
+}
+
+// @Summary Mark notification as read
+// @Description Mark an in-app notification of the authenticated user as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} models.Notification
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/notifications/{id}/read [post]
+func (c *NotificationController) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	notification, err := c.notificationService.MarkRead(r.Context(), userID, id)
+	if err != nil {
+		if err.Error() == "notification not found" {
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to update notification")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, notification)
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+)
+
+type PersonalAccessTokenController struct {
+	tokenService service.PersonalAccessTokenService
+	validator    *validator.Validate
+}
+
+// NewPersonalAccessTokenController creates a new instance of PersonalAccessTokenController
+func NewPersonalAccessTokenController(tokenService service.PersonalAccessTokenService) *PersonalAccessTokenController {
+	return &PersonalAccessTokenController{
+		tokenService: tokenService,
+		validator:    validator.New(),
+	}
+}
+
+// @Summary List personal access tokens
+// @Description List the active personal access tokens of the authenticated user. Token values are never returned again after creation.
+// @Tags tokens
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {array} models.PersonalAccessToken
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/tokens [get]
+func (c *PersonalAccessTokenController) ListTokens(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	tokens, err := c.tokenService.ListTokens(r.Context(), userID)
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Error retrieving tokens")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, tokens)
+}
+
+// @Summary Create personal access token
+// @Description Create a named, long-lived token with the given scopes (todos:read, todos:write, account:admin) for scripts and CI. The token value is only returned in this response.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param token body models.CreatePersonalAccessTokenRequest true "Token name, scopes and optional expiry"
+// @Success 201 {object} models.CreatedPersonalAccessToken
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/tokens [post]
+func (c *PersonalAccessTokenController) CreateToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.CreatePersonalAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := c.tokenService.CreateToken(r.Context(), userID, &req)
+	if err != nil {
+		if err.Error() == "expiration must be in the future" {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Error creating token")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusCreated, token)
+}
+
+// @Summary Revoke personal access token
+// @Description Revoke one of the authenticated user's personal access tokens. It stops working immediately.
+// @Tags tokens
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param id path int true "Token ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/auth/tokens/{id} [delete]
+func (c *PersonalAccessTokenController) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id == 0 {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid token ID")
+		return
+	}
+
+	if err := c.tokenService.RevokeToken(r.Context(), userID, id); err != nil {
+		if err.Error() == "token not found" {
+			httputils.WriteError(w, http.StatusNotFound, "Token not found")
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Error revoking token")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ProfileController struct {
+	authService service.AuthService
+	validator   *validator.Validate
+}
+
+// NewProfileController creates a new instance of ProfileController
+func NewProfileController(authService service.AuthService) *ProfileController {
+	return &ProfileController{
+		authService: authService,
+		validator:   validator.New(),
+	}
+}
+
+// @Summary Get profile
+// @Description Get the account of the authenticated user
+// @Tags profile
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Success 200 {object} models.User
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me [get]
+func (c *ProfileController) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	user, err := c.authService.GetUserByID(r.Context(), userID)
+	if err != nil {
+		writeProfileError(w, err, "Error getting profile")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, user)
+}
+
+// @Summary Update profile
+// @Description Change the names and preferences of the authenticated user. Omitted fields are left unchanged.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param profile body models.UpdateProfileRequest true "Fields to change"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me [patch]
+func (c *ProfileController) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := c.authService.UpdateProfile(r.Context(), userID, &req)
+	if err != nil {
+		writeProfileError(w, err, "Error updating profile")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, user)
+}
+
+// @Summary Change password
+// @Description Change the password of the authenticated user. Every other session is ended; the current one stays logged in.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body models.ChangePasswordRequest true "Current and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me/password [post]
+func (c *ProfileController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	sessionID, _ := middleware.GetSessionIDFromContext(r.Context())
+	if err := c.authService.ChangePassword(r.Context(), userID, sessionID, &req); err != nil {
+		writeProfileError(w, err, "Error changing password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Change email
+// @Description Send a verification link to a new email address. The account keeps its current email until the link is used.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body models.ChangeEmailRequest true "New email and current password"
+// @Success 202 "Accepted"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me/email [post]
+func (c *ProfileController) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.ChangeEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.authService.ChangeEmail(r.Context(), userID, &req); err != nil {
+		writeProfileError(w, err, "Error changing email")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// @Summary Delete account
+// @Description Delete the authenticated user's account and todos. Every session is ended.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Param request body models.DeleteAccountRequest true "Current password"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/me [delete]
+func (c *ProfileController) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.authService.DeleteAccount(r.Context(), userID, &req); err != nil {
+		writeProfileError(w, err, "Error deleting account")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeProfileError maps the errors of the account self-service to responses
+func writeProfileError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case err.Error() == "user not found":
+		httputils.WriteError(w, http.StatusNotFound, "User not found")
+	case err.Error() == "incorrect password":
+		httputils.WriteError(w, http.StatusForbidden, err.Error())
+	case err.Error() == "email already in use":
+		httputils.WriteError(w, http.StatusConflict, err.Error())
+	case err.Error() == "email unchanged" || err.Error() == "current password is required" ||
+		err.Error() == "invalid timezone" || err.Error() == "invalid week start" || isAuthValidationError(err):
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputils.WriteError(w, http.StatusInternalServerError, fallback)
+	}
+}
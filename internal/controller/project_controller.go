@@ -0,0 +1,290 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ProjectController struct {
+	projectService service.ProjectService
+	todoService    service.TodoService
+	validator      *validator.Validate
+}
+
+// NewProjectController creates a new instance of ProjectController
+func NewProjectController(projectService service.ProjectService, todoService service.TodoService) *ProjectController {
+	return &ProjectController{
+		projectService: projectService,
+		todoService:    todoService,
+		validator:      validator.New(),
+	}
+}
+
+// @Summary List projects
+// @Description List the projects of the authenticated user in order. Archived projects are only listed with archived=true.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param archived query bool false "Include archived projects"
+// @Success 200 {array} models.Project
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects [get]
+func (c *ProjectController) ListProjects(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	includeArchived, err := parseBoolQuery(r, "archived")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	projects, err := c.projectService.ListProjects(r.Context(), userID, includeArchived)
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to list projects")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, projects)
+}
+
+// @Summary Create project
+// @Description Create a project for the authenticated user. Projects go to the end of the list unless a position is given.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param project body models.CreateProjectRequest true "Project data"
+// @Success 201 {object} models.Project
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects [post]
+func (c *ProjectController) CreateProject(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	project, err := c.projectService.CreateProject(r.Context(), userID, &req)
+	if err != nil {
+		writeProjectError(w, err, "Failed to create project")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusCreated, project)
+}
+
+// @Summary Get project
+// @Description Get a project of the authenticated user
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/{id} [get]
+func (c *ProjectController) GetProject(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	project, err := c.projectService.GetProject(r.Context(), userID, id)
+	if err != nil {
+		writeProjectError(w, err, "Failed to get project")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, project)
+}
+
+// @Summary Update project
+// @Description Rename, restyle, archive, unarchive or move a project. Only the fields that are set change. The todos of an archived project are hidden from the default todo listing.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param project body models.UpdateProjectRequest true "Fields to change"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/{id} [patch]
+func (c *ProjectController) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	var req models.UpdateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	project, err := c.projectService.UpdateProject(r.Context(), userID, id, &req)
+	if err != nil {
+		writeProjectError(w, err, "Failed to update project")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, project)
+}
+
+// @Summary Delete project
+// @Description Delete a project of the authenticated user. Its todos are kept and move to the Inbox.
+// @Tags projects
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/{id} [delete]
+func (c *ProjectController) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	if err := c.projectService.DeleteProject(r.Context(), userID, id); err != nil {
+		writeProjectError(w, err, "Failed to delete project")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Get project todos
+// @Description Get a page of the todos of a project, archived or not. Accepts the filters, sorting and pagination of GET /api/todos.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page"
+// @Param completed query bool false "Filter by completion status"
+// @Param sort query string false "Sort field" Enums(createdAt, updatedAt, dueDate, priority, title)
+// @Param order query string false "Sort direction" Enums(asc, desc)
+// @Success 200 {object} models.TodoPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/{id}/todos [get]
+func (c *ProjectController) GetProjectTodos(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid project ID")
+		return
+	}
+
+	project, err := c.projectService.GetProject(r.Context(), userID, id)
+	if err != nil {
+		writeProjectError(w, err, "Failed to get project")
+		return
+	}
+
+	listTodos(w, r, c.todoService, c.validator, userID, &project.ID)
+}
+
+// @Summary Get Inbox todos
+// @Description Get a page of the todos that are not in a project. Accepts the filters, sorting and pagination of GET /api/todos.
+// @Tags projects
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page"
+// @Param completed query bool false "Filter by completion status"
+// @Param sort query string false "Sort field" Enums(createdAt, updatedAt, dueDate, priority, title)
+// @Param order query string false "Sort direction" Enums(asc, desc)
+// @Success 200 {object} models.TodoPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/inbox/todos [get]
+func (c *ProjectController) GetInboxTodos(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	inbox := uint(0)
+	listTodos(w, r, c.todoService, c.validator, userID, &inbox)
+}
+
+// writeProjectError maps project service errors to responses. message is
+// returned for unexpected errors.
+func writeProjectError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "project not found":
+		httputils.WriteError(w, http.StatusNotFound, err.Error())
+	case "project name is required":
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
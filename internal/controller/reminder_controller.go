@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type ReminderController struct {
+	reminderService service.ReminderService
+	validator       *validator.Validate
+}
+
+// NewReminderController creates a new instance of ReminderController
+func NewReminderController(reminderService service.ReminderService) *ReminderController {
+	return &ReminderController{
+		reminderService: reminderService,
+		validator:       validator.New(),
+	}
+}
+
+// @Summary Get reminders
+// @Description List the reminders of a todo owned by the authenticated user, with their delivery status
+// @Tags reminders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {array} models.Reminder
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/reminders [get]
+func (c *ReminderController) GetReminders(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	todoID, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	reminders, err := c.reminderService.GetReminders(r.Context(), userID, todoID)
+	if err != nil {
+		writeReminderError(w, err, "Failed to get reminders")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, reminders)
+}
+
+// @Summary Create reminder
+// @Description Add a reminder to a todo owned by the authenticated user, at remindAt or offsetMinutes before the due date. It is delivered by email, to a webhook or as an in-app notification. A todo has at most 10 reminders.
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param reminder body models.CreateReminderRequest true "Reminder time and channel"
+// @Success 201 {object} models.Reminder
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/reminders [post]
+func (c *ReminderController) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	todoID, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	var req models.CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reminder, err := c.reminderService.CreateReminder(r.Context(), userID, todoID, &req)
+	if err != nil {
+		writeReminderError(w, err, "Failed to create reminder")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusCreated, reminder)
+}
+
+// @Summary Delete reminder
+// @Description Remove a reminder from a todo owned by the authenticated user
+// @Tags reminders
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param reminderId path int true "Reminder ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/reminders/{reminderId} [delete]
+func (c *ReminderController) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	todoID, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	reminderID, err := parseUintParam(r, "reminderId")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid reminder ID")
+		return
+	}
+
+	if err := c.reminderService.DeleteReminder(r.Context(), userID, todoID, reminderID); err != nil {
+		writeReminderError(w, err, "Failed to delete reminder")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeReminderError maps reminder service errors to responses. message is
+// returned for unexpected errors.
+func writeReminderError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "todo not found", "invalid todo ID", "reminder not found":
+		httputils.WriteError(w, http.StatusNotFound, err.Error())
+	case "either remindAt or offsetMinutes is required":
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+	case "too many reminders":
+		httputils.WriteError(w, http.StatusConflict, err.Error())
+	default:
+		httputils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
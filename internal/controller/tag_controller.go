@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type TagController struct {
+	tagService service.TagService
+	validator  *validator.Validate
+}
+
+// NewTagController creates a new instance of TagController
+func NewTagController(tagService service.TagService) *TagController {
+	return &TagController{
+		tagService: tagService,
+		validator:  validator.New(),
+	}
+}
+
+// @Summary List tags
+// @Description List the tags of the authenticated user, by name
+// @Tags tags
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Tag
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tags [get]
+func (c *TagController) ListTags(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	tags, err := c.tagService.ListTags(r.Context(), userID)
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to list tags")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, tags)
+}
+
+// @Summary Create tag
+// @Description Create a tag for the authenticated user. Tag names are unique per user, ignoring case. Tags are also created when a todo is saved with a new tag name.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tag body models.CreateTagRequest true "Tag name and optional color such as #ff8800"
+// @Success 201 {object} models.Tag
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tags [post]
+func (c *TagController) CreateTag(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	var req models.CreateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tag, err := c.tagService.CreateTag(r.Context(), userID, &req)
+	if err != nil {
+		writeTagError(w, err, "Failed to create tag")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusCreated, tag)
+}
+
+// @Summary Update tag
+// @Description Rename or recolor a tag. Only the fields that are set change. To combine two tags, merge them instead of renaming one to the other's name.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Param tag body models.UpdateTagRequest true "Fields to change"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tags/{id} [patch]
+func (c *TagController) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	var req models.UpdateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tag, err := c.tagService.UpdateTag(r.Context(), userID, id, &req)
+	if err != nil {
+		writeTagError(w, err, "Failed to update tag")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, tag)
+}
+
+// @Summary Delete tag
+// @Description Delete a tag of the authenticated user. Its todos are kept and lose the tag.
+// @Tags tags
+// @Security BearerAuth
+// @Param id path int true "Tag ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tags/{id} [delete]
+func (c *TagController) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	if err := c.tagService.DeleteTag(r.Context(), userID, id); err != nil {
+		writeTagError(w, err, "Failed to delete tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Merge tag
+// @Description Move the todos of a tag to the target tag and delete the merged tag. Returns the target tag.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID of the tag to merge"
+// @Param merge body models.MergeTagRequest true "Target tag"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/tags/{id}/merge [post]
+func (c *TagController) MergeTag(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	var req models.MergeTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tag, err := c.tagService.MergeTag(r.Context(), userID, id, &req)
+	if err != nil {
+		writeTagError(w, err, "Failed to merge tag")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, tag)
+}
+
+// writeTagError maps tag service errors to responses. message is returned for
+// unexpected errors.
+func writeTagError(w http.ResponseWriter, err error, message string) {
+	switch err.Error() {
+	case "tag not found":
+		httputils.WriteError(w, http.StatusNotFound, err.Error())
+	case "tag name is required", "cannot merge a tag into itself":
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+	case "tag already exists":
+		httputils.WriteError(w, http.StatusConflict, err.Error())
+	default:
+		httputils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
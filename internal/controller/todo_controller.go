@@ -2,8 +2,14 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"todo-list-api/internal/middleware"
 	"todo-list-api/internal/models"
 	"todo-list-api/internal/service"
 	httputils "todo-list-api/internal/utils/http"
@@ -26,23 +32,120 @@ func NewTodoController(todoService service.TodoService) *TodoController {
 }
 
 // @Summary Get all todos
-// @Description Get all todos for the authenticated user
+// @Description Get a page of todos for the authenticated user, optionally filtered and sorted
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Todo
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page"
+// @Param completed query bool false "Filter by completion status"
+// @Param priority query string false "Filter by priority" Enums(low, medium, high)
+// @Param status query string false "Filter by status key"
+// @Param projectId query string false "Only todos of this project ID, or of the Inbox with \"inbox\". Without it todos of archived projects are left out."
+// @Param tags query string false "Comma-separated tag names"
+// @Param tagIds query string false "Comma-separated tag IDs"
+// @Param tagMatch query string false "Match todos with any (default) or all of the tags" Enums(any, all)
+// @Param dueBefore query string false "Only todos due before this RFC3339 date"
+// @Param dueAfter query string false "Only todos due after this RFC3339 date"
+// @Param overdue query bool false "Only overdue (true) or not overdue (false) todos"
+// @Param includeDeferred query bool false "Include todos that start later or are snoozed"
+// @Param sort query string false "Sort field" Enums(createdAt, updatedAt, dueDate, priority, title)
+// @Param order query string false "Sort direction" Enums(asc, desc)
+// @Success 200 {object} models.TodoPage
+// @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/todos [get]
 func (c *TodoController) GetTodos(w http.ResponseWriter, r *http.Request) {
-	todos, err := c.todoService.GetTodos(r.Context())
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	listTodos(w, r, c.todoService, c.validator, userID, nil)
+}
+
+// listTodos writes a page of the user's todos for the list query parameters of
+// the request. A non-nil projectID replaces the projectId parameter.
+func listTodos(w http.ResponseWriter, r *http.Request, todoService service.TodoService, validate *validator.Validate, userID uint, projectID *uint) {
+	req, err := parseListTodosRequest(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if projectID != nil {
+		req.ProjectID = projectID
+	}
+
+	if err := validate.Struct(req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := todoService.GetTodos(r.Context(), userID, req)
 	if err != nil {
+		if err.Error() == "invalid cursor" || isInvalidTodoError(err) {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		httputils.WriteError(w, http.StatusInternalServerError, "Failed to get todos")
 		return
 	}
 
-	httputils.WriteJson(w, http.StatusOK, todos)
+	httputils.WriteJson(w, http.StatusOK, page)
+}
+
+// @Summary Search todos
+// @Description Full-text search over the authenticated user's todo titles and descriptions, ranked by relevance
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text; every word is matched as a prefix"
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of results to skip"
+// @Param completed query bool false "Filter by completion status"
+// @Param priority query string false "Filter by priority" Enums(low, medium, high)
+// @Param projectId query string false "Only todos of this project ID, or of the Inbox with \"inbox\". Without it todos of archived projects are left out."
+// @Param tags query string false "Comma-separated tag names"
+// @Param tagIds query string false "Comma-separated tag IDs"
+// @Param tagMatch query string false "Match todos with any (default) or all of the tags" Enums(any, all)
+// @Success 200 {object} models.TodoSearchPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/search [get]
+func (c *TodoController) SearchTodos(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	req, err := parseSearchTodosRequest(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := c.todoService.SearchTodos(r.Context(), userID, req)
+	if err != nil {
+		if err.Error() == "search query is required" || isInvalidTodoError(err) {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to search todos")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, page)
 }
 
 // @Summary Create a new todo
@@ -58,6 +161,12 @@ func (c *TodoController) GetTodos(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} map[string]string
 // @Router /api/todos [post]
 func (c *TodoController) CreateTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
 	var req models.CreateTodoRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -70,8 +179,12 @@ func (c *TodoController) CreateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	todo, err := c.todoService.CreateTodo(r.Context(), &req)
+	todo, err := c.todoService.CreateTodo(r.Context(), userID, &req)
 	if err != nil {
+		if isInvalidTodoError(err) {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		httputils.WriteError(w, http.StatusInternalServerError, "Failed to create todo")
 		return
 	}
@@ -80,7 +193,7 @@ func (c *TodoController) CreateTodo(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Get todo by ID
-// @Description Get a specific todo by its ID
+// @Description Get a specific todo owned by the authenticated user
 // @Tags todos
 // @Accept json
 // @Produce json
@@ -93,13 +206,19 @@ func (c *TodoController) CreateTodo(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} map[string]string
 // @Router /api/todos/{id} [get]
 func (c *TodoController) GetTodoByID(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
 	id, err := c.parseIDFromURL(r)
 	if err != nil {
 		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
 		return
 	}
 
-	todo, err := c.todoService.GetTodoByID(r.Context(), id)
+	todo, err := c.todoService.GetTodoByID(r.Context(), userID, id)
 	if err != nil {
 		if err.Error() == "invalid todo ID" {
 			httputils.WriteError(w, http.StatusBadRequest, err.Error())
@@ -117,21 +236,29 @@ func (c *TodoController) GetTodoByID(w http.ResponseWriter, r *http.Request) {
 	httputils.WriteJson(w, http.StatusOK, todo)
 }
 
-// @Summary Update todo
-// @Description Update an existing todo item
+// @Summary Replace todo
+// @Description Replace all editable fields of a todo owned by the authenticated user. Omitted fields are cleared.
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Todo ID"
 // @Param todo body models.UpdateTodoRequest true "Updated todo data"
+// @Param completeSubtasks query bool false "Also complete every subtask when the todo ends up completed"
 // @Success 200 {object} models.Todo
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/todos/{id} [put]
 func (c *TodoController) UpdateTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
 	id, err := c.parseIDFromURL(r)
 	if err != nil {
 		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
@@ -149,13 +276,27 @@ func (c *TodoController) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	todo, err := c.todoService.UpdateTodo(r.Context(), id, &req)
+	completeSubtasks, err := parseBoolQuery(r, "completeSubtasks")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	todo, err := c.todoService.UpdateTodo(r.Context(), userID, id, &req, completeSubtasks)
 	if err != nil {
 		// Check if it's a not found error
 		if err.Error() == "todo not found" || err.Error() == "invalid todo ID" {
 			httputils.WriteError(w, http.StatusNotFound, err.Error())
 			return
 		}
+		if isInvalidTodoError(err) {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err.Error() == "todo status changed" {
+			httputils.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
 		httputils.WriteError(w, http.StatusInternalServerError, "Failed to update todo")
 		return
 	}
@@ -163,13 +304,85 @@ func (c *TodoController) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 	httputils.WriteJson(w, http.StatusOK, todo)
 }
 
+// @Summary Partially update todo
+// @Description Apply a JSON Merge Patch (RFC 7396, application/merge-patch+json) or a JSON Patch (RFC 6902, application/json-patch+json) to a todo owned by the authenticated user. Absent fields are left untouched and null clears a field. The patched todo must pass the same validation as PUT.
+// @Tags todos
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param patch body object true "Patch document"
+// @Param completeSubtasks query bool false "Also complete every subtask when the todo ends up completed"
+// @Success 200 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id} [patch]
+func (c *TodoController) PatchTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	patchType, ok := patchTypeFromContentType(r.Header.Get("Content-Type"))
+	if !ok {
+		httputils.WriteError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/merge-patch+json or application/json-patch+json")
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid patch body")
+		return
+	}
+
+	completeSubtasks, err := parseBoolQuery(r, "completeSubtasks")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	todo, err := c.todoService.PatchTodo(r.Context(), userID, id, patchType, patch, completeSubtasks)
+	if err != nil {
+		var validationErrors validator.ValidationErrors
+		switch {
+		case err.Error() == "todo not found" || err.Error() == "invalid todo ID":
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+		case err.Error() == "patch test failed" || err.Error() == "todo status changed":
+			httputils.WriteError(w, http.StatusConflict, err.Error())
+		case strings.HasPrefix(err.Error(), "invalid patch document") || isInvalidTodoError(err):
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		case errors.As(err, &validationErrors):
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			httputils.WriteError(w, http.StatusInternalServerError, "Failed to update todo")
+		}
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, todo)
+}
+
 // @Summary Delete todo
-// @Description Delete a todo item by ID
+// @Description Move a todo owned by the authenticated user to the trash, or delete it for good with permanent=true. Todos already in the trash can only be deleted permanently. Subtasks are deleted with the todo, or move up to its parent with subtasks=promote.
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Todo ID"
+// @Param permanent query bool false "Delete the todo for good instead of moving it to the trash"
+// @Param subtasks query string false "What happens to the subtasks (default delete)" Enums(delete, promote)
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
@@ -177,18 +390,39 @@ func (c *TodoController) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 // @Failure 500 {object} map[string]string
 // @Router /api/todos/{id} [delete]
 func (c *TodoController) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
 	id, err := c.parseIDFromURL(r)
 	if err != nil {
 		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
 		return
 	}
 
-	err = c.todoService.DeleteTodo(r.Context(), id)
+	permanent, err := parseBoolQuery(r, "permanent")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	subtasks := r.URL.Query().Get("subtasks")
+	if permanent {
+		err = c.todoService.DeleteTodoPermanently(r.Context(), userID, id, subtasks)
+	} else {
+		err = c.todoService.DeleteTodo(r.Context(), userID, id, subtasks)
+	}
 	if err != nil {
 		if err.Error() == "todo not found" || err.Error() == "invalid todo ID" {
 			httputils.WriteError(w, http.StatusNotFound, err.Error())
 			return
 		}
+		if err.Error() == "invalid subtasks option" {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		httputils.WriteError(w, http.StatusInternalServerError, "Failed to delete todo")
 		return
 	}
@@ -196,13 +430,656 @@ func (c *TodoController) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// @Summary List trash
+// @Description List the authenticated user's deleted todos, most recently deleted first. Todos are kept in the trash until they are restored, deleted permanently or purged after the retention period.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param offset query int false "Number of todos to skip"
+// @Success 200 {object} models.TrashPage
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/trash [get]
+func (c *TodoController) GetTrash(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	req, err := parseListTrashRequest(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.validator.Struct(req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := c.todoService.GetTrash(r.Context(), userID, req)
+	if err != nil {
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to list trash")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, page)
+}
+
+// @Summary Restore todo
+// @Description Move a todo owned by the authenticated user out of the trash
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/restore [post]
+func (c *TodoController) RestoreTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	todo, err := c.todoService.RestoreTodo(r.Context(), userID, id)
+	if err != nil {
+		if err.Error() == "todo not found" || err.Error() == "invalid todo ID" {
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to restore todo")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, todo)
+}
+
+// @Summary Snooze todo
+// @Description Hide a todo owned by the authenticated user from the todo list until a time, given as a time, a duration such as "3h" or "2d", or a preset resolved in the user's time zone
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param snooze body models.SnoozeTodoRequest true "Snooze end"
+// @Success 200 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/snooze [post]
+func (c *TodoController) SnoozeTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	var req models.SnoozeTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	todo, err := c.todoService.SnoozeTodo(r.Context(), userID, id, &req)
+	if err != nil {
+		switch err.Error() {
+		case "todo not found", "invalid todo ID":
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+		case "either until, duration or preset is required", "invalid snooze time", "invalid snooze duration",
+			"invalid snooze preset", "snooze must end in the future", "snooze must end within a year":
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		default:
+			httputils.WriteError(w, http.StatusInternalServerError, "Failed to snooze todo")
+		}
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, todo)
+}
+
+// @Summary Unsnooze todo
+// @Description Show a snoozed todo owned by the authenticated user in the todo list again
+// @Tags todos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/snooze [delete]
+func (c *TodoController) UnsnoozeTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	todo, err := c.todoService.UnsnoozeTodo(r.Context(), userID, id)
+	if err != nil {
+		if err.Error() == "todo not found" || err.Error() == "invalid todo ID" {
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to unsnooze todo")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, todo)
+}
+
+// @Summary Transition todo
+// @Description Move a todo owned by the authenticated user to another status of its workflow. The move must be one of the transitions of its current status. completed is derived from the new status.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Param transition body models.TransitionTodoRequest true "Target status"
+// @Param completeSubtasks query bool false "Also complete every subtask when the todo ends up completed"
+// @Success 200 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/transition [post]
+func (c *TodoController) TransitionTodo(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	var req models.TransitionTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	completeSubtasks, err := parseBoolQuery(r, "completeSubtasks")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	todo, err := c.todoService.TransitionTodo(r.Context(), userID, id, &req, completeSubtasks)
+	if err != nil {
+		switch {
+		case err.Error() == "todo not found" || err.Error() == "invalid todo ID":
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+		case err.Error() == "unknown status" || isInvalidTodoError(err):
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		case err.Error() == "transition not allowed" || err.Error() == "todo status changed":
+			httputils.WriteError(w, http.StatusConflict, err.Error())
+		default:
+			httputils.WriteError(w, http.StatusInternalServerError, "Failed to transition todo")
+		}
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, todo)
+}
+
+// @Summary List subtasks
+// @Description List the direct subtasks of a todo owned by the authenticated user, oldest first
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Todo ID"
+// @Success 200 {array} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/subtasks [get]
+func (c *TodoController) GetSubtasks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	subtasks, err := c.todoService.GetSubtasks(r.Context(), userID, id)
+	if err != nil {
+		if err.Error() == "todo not found" || err.Error() == "invalid todo ID" {
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to list subtasks")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, subtasks)
+}
+
+// @Summary Create subtask
+// @Description Create a new todo as a subtask of a todo owned by the authenticated user
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Parent todo ID"
+// @Param todo body models.CreateTodoRequest true "Todo data"
+// @Success 201 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/subtasks [post]
+func (c *TodoController) CreateSubtask(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	var req models.CreateTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	todo, err := c.todoService.CreateSubtask(r.Context(), userID, id, &req)
+	if err != nil {
+		if err.Error() == "todo not found" || err.Error() == "invalid todo ID" {
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if isInvalidTodoError(err) {
+			httputils.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to create subtask")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusCreated, todo)
+}
+
+// @Summary Attach subtask
+// @Description Move an existing todo, with its own subtasks, under another todo. Both must be owned by the authenticated user. A todo cannot be moved under itself or one of its subtasks.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Parent todo ID"
+// @Param subtaskId path int true "ID of the todo to move"
+// @Success 200 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/subtasks/{subtaskId} [put]
+func (c *TodoController) AttachSubtask(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	subtaskID, err := parseUintParam(r, "subtaskId")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid subtask ID")
+		return
+	}
+
+	todo, err := c.todoService.AttachSubtask(r.Context(), userID, id, subtaskID)
+	if err != nil {
+		switch err.Error() {
+		case "todo not found", "invalid todo ID":
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+		case "subtask would create a cycle":
+			httputils.WriteError(w, http.StatusConflict, err.Error())
+		default:
+			httputils.WriteError(w, http.StatusInternalServerError, "Failed to attach subtask")
+		}
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, todo)
+}
+
+// @Summary Detach subtask
+// @Description Make a subtask a top-level todo again. The todo itself is not deleted.
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Parent todo ID"
+// @Param subtaskId path int true "Subtask ID"
+// @Success 200 {object} models.Todo
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/todos/{id}/subtasks/{subtaskId} [delete]
+func (c *TodoController) DetachSubtask(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return
+	}
+
+	id, err := c.parseIDFromURL(r)
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid todo ID")
+		return
+	}
+
+	subtaskID, err := parseUintParam(r, "subtaskId")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid subtask ID")
+		return
+	}
+
+	todo, err := c.todoService.DetachSubtask(r.Context(), userID, id, subtaskID)
+	if err != nil {
+		if err.Error() == "todo not found" || err.Error() == "invalid todo ID" {
+			httputils.WriteError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		httputils.WriteError(w, http.StatusInternalServerError, "Failed to detach subtask")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, todo)
+}
+
 // Helper methods
 
+// maxPatchSize bounds the size of PATCH request bodies
+const maxPatchSize = 1 << 20
+
+// patchTypeFromContentType maps a PATCH Content-Type to a models.PatchType*.
+// Plain application/json is treated as a merge patch.
+func patchTypeFromContentType(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "application/merge-patch+json", "application/json":
+		return models.PatchTypeMerge, true
+	case "application/json-patch+json":
+		return models.PatchTypeJSON, true
+	default:
+		return "", false
+	}
+}
+
 func (c *TodoController) parseIDFromURL(r *http.Request) (uint, error) {
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	return parseUintParam(r, "id")
+}
+
+// parseUintParam parses a numeric ID from the URL path
+func parseUintParam(r *http.Request, name string) (uint, error) {
+	id, err := strconv.ParseUint(chi.URLParam(r, name), 10, 32)
 	if err != nil {
 		return 0, err
 	}
 	return uint(id), nil
 }
+
+// parseBoolQuery parses an optional boolean query parameter, false when absent
+func parseBoolQuery(r *http.Request, name string) (bool, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, errors.New("invalid " + name + " parameter")
+	}
+	return b, nil
+}
+
+// isInvalidTodoError reports whether err rejects the tags, project or
+// recurrence given for a todo or a filter
+func isInvalidTodoError(err error) bool {
+	switch err.Error() {
+	case "tag not found", "too many tags", "tag name is required", "project not found",
+		"invalid recurrence timezone":
+		return true
+	}
+	return strings.HasPrefix(err.Error(), "invalid recurrence rule")
+}
+
+// parseTagsQuery collects a list query parameter, which may be repeated and
+// hold comma-separated values
+func parseTagsQuery(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// parseProjectQuery reads the projectId query parameter, which is a project
+// ID or "inbox" (0)
+func parseProjectQuery(query url.Values) (*uint, error) {
+	v := query.Get("projectId")
+	if v == "" {
+		return nil, nil
+	}
+	if v == "inbox" {
+		inbox := uint(0)
+		return &inbox, nil
+	}
+	id, err := strconv.ParseUint(v, 10, 32)
+	if err != nil || id == 0 {
+		return nil, errors.New("invalid projectId parameter")
+	}
+	projectID := uint(id)
+	return &projectID, nil
+}
+
+// parseTagFilter reads the tags, tagIds and tagMatch query parameters
+func parseTagFilter(query url.Values) (tags []string, tagIDs []uint, tagMatch string, err error) {
+	tags = parseTagsQuery(query["tags"])
+	for _, v := range parseTagsQuery(query["tagIds"]) {
+		id, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, nil, "", errors.New("invalid tagIds parameter")
+		}
+		tagIDs = append(tagIDs, uint(id))
+	}
+	return tags, tagIDs, query.Get("tagMatch"), nil
+}
+
+func parseListTodosRequest(r *http.Request) (*models.ListTodosRequest, error) {
+	query := r.URL.Query()
+	req := &models.ListTodosRequest{
+		Cursor:   query.Get("cursor"),
+		Priority: query.Get("priority"),
+		Status:   query.Get("status"),
+		Sort:     query.Get("sort"),
+		Order:    query.Get("order"),
+	}
+
+	var err error
+	if req.ProjectID, err = parseProjectQuery(query); err != nil {
+		return nil, err
+	}
+	if req.Tags, req.TagIDs, req.TagMatch, err = parseTagFilter(query); err != nil {
+		return nil, err
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid limit parameter")
+		}
+		req.Limit = limit
+	}
+
+	if v := query.Get("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid completed parameter")
+		}
+		req.Completed = &completed
+	}
+
+	if v := query.Get("overdue"); v != "" {
+		overdue, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid overdue parameter")
+		}
+		req.Overdue = &overdue
+	}
+
+	if v := query.Get("dueBefore"); v != "" {
+		req.DueBefore = &v
+	}
+
+	if v := query.Get("dueAfter"); v != "" {
+		req.DueAfter = &v
+	}
+
+	if req.IncludeDeferred, err = parseBoolQuery(r, "includeDeferred"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func parseSearchTodosRequest(r *http.Request) (*models.SearchTodosRequest, error) {
+	query := r.URL.Query()
+	req := &models.SearchTodosRequest{
+		Query:    query.Get("q"),
+		Priority: query.Get("priority"),
+	}
+
+	var err error
+	if req.ProjectID, err = parseProjectQuery(query); err != nil {
+		return nil, err
+	}
+	if req.Tags, req.TagIDs, req.TagMatch, err = parseTagFilter(query); err != nil {
+		return nil, err
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid limit parameter")
+		}
+		req.Limit = limit
+	}
+
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid offset parameter")
+		}
+		req.Offset = offset
+	}
+
+	if v := query.Get("completed"); v != "" {
+		completed, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.New("invalid completed parameter")
+		}
+		req.Completed = &completed
+	}
+
+	return req, nil
+}
+
+func parseListTrashRequest(r *http.Request) (*models.ListTrashRequest, error) {
+	query := r.URL.Query()
+	req := &models.ListTrashRequest{}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid limit parameter")
+		}
+		req.Limit = limit
+	}
+
+	if v := query.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid offset parameter")
+		}
+		req.Offset = offset
+	}
+
+	return req, nil
+}
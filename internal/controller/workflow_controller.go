@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service"
+	httputils "todo-list-api/internal/utils/http"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type WorkflowController struct {
+	workflowService service.WorkflowService
+	validator       *validator.Validate
+}
+
+// NewWorkflowController creates a new instance of WorkflowController
+func NewWorkflowController(workflowService service.WorkflowService) *WorkflowController {
+	return &WorkflowController{
+		workflowService: workflowService,
+		validator:       validator.New(),
+	}
+}
+
+// @Summary Get workflow
+// @Description Get the statuses the todos of the authenticated user follow: their own workflow, or the default one
+// @Tags workflows
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.Workflow
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/workflow [get]
+func (c *WorkflowController) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	c.getWorkflow(w, r, false)
+}
+
+// @Summary Set workflow
+// @Description Replace the workflow of the authenticated user. The first status is given to new todos; a status in the done category is required.
+// @Tags workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param workflow body models.SetWorkflowRequest true "Statuses"
+// @Success 200 {object} models.Workflow
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/workflow [put]
+func (c *WorkflowController) SetWorkflow(w http.ResponseWriter, r *http.Request) {
+	c.setWorkflow(w, r, false)
+}
+
+// @Summary Reset workflow
+// @Description Delete the workflow of the authenticated user, who goes back to the default workflow
+// @Tags workflows
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/workflow [delete]
+func (c *WorkflowController) DeleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	c.deleteWorkflow(w, r, false)
+}
+
+// @Summary Get project workflow
+// @Description Get the statuses the todos of a project follow: its own workflow, else the user's, else the default one
+// @Tags workflows
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} models.Workflow
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/{id}/workflow [get]
+func (c *WorkflowController) GetProjectWorkflow(w http.ResponseWriter, r *http.Request) {
+	c.getWorkflow(w, r, true)
+}
+
+// @Summary Set project workflow
+// @Description Replace the workflow of a project, which its todos follow instead of the user's
+// @Tags workflows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Param workflow body models.SetWorkflowRequest true "Statuses"
+// @Success 200 {object} models.Workflow
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/{id}/workflow [put]
+func (c *WorkflowController) SetProjectWorkflow(w http.ResponseWriter, r *http.Request) {
+	c.setWorkflow(w, r, true)
+}
+
+// @Summary Reset project workflow
+// @Description Delete the workflow of a project, whose todos go back to the user's workflow
+// @Tags workflows
+// @Security BearerAuth
+// @Param id path int true "Project ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/projects/{id}/workflow [delete]
+func (c *WorkflowController) DeleteProjectWorkflow(w http.ResponseWriter, r *http.Request) {
+	c.deleteWorkflow(w, r, true)
+}
+
+func (c *WorkflowController) getWorkflow(w http.ResponseWriter, r *http.Request, ofProject bool) {
+	userID, projectID, ok := workflowOwner(w, r, ofProject)
+	if !ok {
+		return
+	}
+
+	workflow, err := c.workflowService.GetWorkflow(r.Context(), userID, projectID)
+	if err != nil {
+		writeWorkflowError(w, err, "Failed to get workflow")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, workflow)
+}
+
+func (c *WorkflowController) setWorkflow(w http.ResponseWriter, r *http.Request, ofProject bool) {
+	userID, projectID, ok := workflowOwner(w, r, ofProject)
+	if !ok {
+		return
+	}
+
+	var req models.SetWorkflowRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid JSON format")
+		return
+	}
+
+	if err := c.validator.Struct(&req); err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	workflow, err := c.workflowService.SetWorkflow(r.Context(), userID, projectID, &req)
+	if err != nil {
+		writeWorkflowError(w, err, "Failed to set workflow")
+		return
+	}
+
+	httputils.WriteJson(w, http.StatusOK, workflow)
+}
+
+func (c *WorkflowController) deleteWorkflow(w http.ResponseWriter, r *http.Request, ofProject bool) {
+	userID, projectID, ok := workflowOwner(w, r, ofProject)
+	if !ok {
+		return
+	}
+
+	if err := c.workflowService.DeleteWorkflow(r.Context(), userID, projectID); err != nil {
+		writeWorkflowError(w, err, "Failed to delete workflow")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// workflowOwner returns the user, and the project when ofProject is set, whose
+// workflow the request is about. It writes the error response when ok is false.
+func workflowOwner(w http.ResponseWriter, r *http.Request, ofProject bool) (userID uint, projectID *uint, ok bool) {
+	userID, ok = middleware.GetUserIDFromContextAsUint(r.Context())
+	if !ok {
+		httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+		return 0, nil, false
+	}
+
+	if !ofProject {
+		return userID, nil, true
+	}
+
+	id, err := parseUintParam(r, "id")
+	if err != nil {
+		httputils.WriteError(w, http.StatusBadRequest, "Invalid project ID")
+		return 0, nil, false
+	}
+	return userID, &id, true
+}
+
+// writeWorkflowError maps workflow service errors to responses. message is
+// returned for unexpected errors.
+func writeWorkflowError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case err.Error() == "project not found" || err.Error() == "workflow not found":
+		httputils.WriteError(w, http.StatusNotFound, err.Error())
+	case strings.HasPrefix(err.Error(), "invalid workflow"):
+		httputils.WriteError(w, http.StatusBadRequest, err.Error())
+	default:
+		httputils.WriteError(w, http.StatusInternalServerError, message)
+	}
+}
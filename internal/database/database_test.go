@@ -5,6 +5,7 @@ import (
 	"log"
 	"testing"
 	"time"
+	"todo-list-api/internal/models"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -98,3 +99,22 @@ func TestClose(t *testing.T) {
 		t.Fatalf("expected Close() to return nil")
 	}
 }
+
+func TestUserEmailReusableAfterDelete(t *testing.T) {
+	db := New().GetDB()
+
+	user := &models.User{Email: "reused@example.com"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("expected the user to be created, got %v", err)
+	}
+	if err := db.Create(&models.User{Email: "reused@example.com"}).Error; err == nil {
+		t.Fatalf("expected a second account with the email to be rejected")
+	}
+
+	if err := db.Delete(user).Error; err != nil {
+		t.Fatalf("expected the user to be deleted, got %v", err)
+	}
+	if err := db.Create(&models.User{Email: "reused@example.com"}).Error; err != nil {
+		t.Fatalf("expected the email of a deleted user to be reusable, got %v", err)
+	}
+}
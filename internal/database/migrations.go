@@ -11,15 +11,138 @@ import (
 func AutoMigrate(db *gorm.DB) error {
 	log.Println("Running database migrations...")
 
+	// Users must exist before todos so the todos.user_id foreign key can be created.
+	// Todos created before ownership was introduced keep a NULL user_id and are
+	// not visible to any user.
+	// Accounts created before email verification existed are treated as verified
+	backfillVerified := db.Migrator().HasTable(&models.User{}) &&
+		!db.Migrator().HasColumn(&models.User{}, "EmailVerified")
+	// Todos completed before statuses existed get the done status
+	backfillStatus := db.Migrator().HasTable(&models.Todo{}) &&
+		!db.Migrator().HasColumn(&models.Todo{}, "Status")
+
+	// Emails are unique among accounts that are not deleted (idx_users_email);
+	// AutoMigrate drops the unique constraint of earlier versions on its own.
 	err := db.AutoMigrate(
-		&models.Todo{},
 		&models.User{},
+		&models.Project{},
+		&models.Workflow{},
+		&models.Todo{},
+		&models.Tag{},
+		&models.TodoTag{},
+		&models.ChecklistItem{},
+		&models.Reminder{},
+		&models.Notification{},
+		&models.RefreshToken{},
+		&models.Session{},
+		&models.PasswordResetToken{},
+		&models.EmailVerificationToken{},
+		&models.MFACredential{},
+		&models.RecoveryCode{},
+		&models.PersonalAccessToken{},
+		&models.ClientKey{},
+		&models.LoginAttempt{},
+		&models.RateLimitBucket{},
+		&models.AuditEvent{},
+		&models.DataExport{},
 	)
 	if err != nil {
 		log.Printf("Failed to run migrations: %v", err)
 		return err
 	}
 
+	if backfillVerified {
+		if err := db.Exec(`UPDATE users SET email_verified = true, verified_at = created_at`).Error; err != nil {
+			log.Printf("Failed to mark existing users as verified: %v", err)
+			return err
+		}
+	}
+
+	if backfillStatus {
+		err := db.Exec(`UPDATE todos SET status = ?, completed_at = updated_at WHERE completed`, models.StatusDone).Error
+		if err != nil {
+			log.Printf("Failed to set the status of completed todos: %v", err)
+			return err
+		}
+	}
+
+	// Users and each of their projects have at most one workflow
+	err = db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_workflows_user_id_project_id ON workflows (user_id, COALESCE(project_id, 0))`).Error
+	if err != nil {
+		log.Printf("Failed to create the workflow index: %v", err)
+		return err
+	}
+
+	if err := migrateTodoSearch(db); err != nil {
+		log.Printf("Failed to run todo search migration: %v", err)
+		return err
+	}
+
+	if err := migrateTags(db); err != nil {
+		log.Printf("Failed to run tag migration: %v", err)
+		return err
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
+
+// migrateTodoSearch adds the full-text search column and its GIN index to todos.
+// The column is generated by PostgreSQL, so it is not part of models.Todo.
+func migrateTodoSearch(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE todos ADD COLUMN IF NOT EXISTS search_vector tsvector
+			GENERATED ALWAYS AS (
+				setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+				setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+			) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_todos_search_vector ON todos USING GIN (search_vector)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateTags makes tag names unique per user, ignoring case, and turns the
+// categories of todos created before tags existed into tags
+func migrateTags(db *gorm.DB) error {
+	err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_id_name ON tags (user_id, lower(name))`).Error
+	if err != nil {
+		return err
+	}
+
+	if !db.Migrator().HasColumn(&models.Todo{}, "category") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		statements := []string{
+			`INSERT INTO tags (user_id, name, created_at, updated_at)
+				SELECT DISTINCT ON (user_id, lower(left(trim(category), 50)))
+					user_id, left(trim(category), 50), now(), now()
+				FROM todos
+				WHERE user_id IS NOT NULL AND trim(category) <> ''
+				ORDER BY user_id, lower(left(trim(category), 50)), id
+				ON CONFLICT DO NOTHING`,
+			`INSERT INTO todo_tags (todo_id, tag_id)
+				SELECT todos.id, tags.id
+				FROM todos
+				JOIN tags ON tags.user_id = todos.user_id
+					AND lower(tags.name) = lower(left(trim(todos.category), 50))
+				WHERE trim(todos.category) <> ''
+				ON CONFLICT DO NOTHING`,
+			`ALTER TABLE todos DROP COLUMN category`,
+		}
+
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
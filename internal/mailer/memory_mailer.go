@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryMailer keeps sent emails in memory. It is an in-process fake for tests.
+type MemoryMailer struct {
+	mu       sync.Mutex
+	messages []Message
+	sent     chan Message
+}
+
+// NewMemoryMailer creates a new MemoryMailer
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{sent: make(chan Message, 64)}
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	m.messages = append(m.messages, msg)
+	m.mu.Unlock()
+
+	select {
+	case m.sent <- msg:
+	default:
+	}
+	return nil
+}
+
+// Messages returns every email sent so far
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// Wait returns the next sent email, or false if none is sent within timeout.
+// Emails may be delivered in the background, so tests wait instead of polling.
+func (m *MemoryMailer) Wait(timeout time.Duration) (Message, bool) {
+	select {
+	case msg := <-m.sent:
+		return msg, true
+	case <-time.After(timeout):
+		return Message{}, false
+	}
+}
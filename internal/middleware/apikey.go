@@ -1,12 +1,24 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
-	"strings"
+	"todo-list-api/internal/models"
+	httputils "todo-list-api/internal/utils/http"
 )
 
-// ApiKeyMiddleware validates the X-API-Key header
-func ApiKeyMiddleware(validApiKey string) func(http.Handler) http.Handler {
+// ClientKeyKey is the context key of the client key of the request
+const ClientKeyKey contextKey = "client_key"
+
+// ClientKeyValidator resolves the key sent in the X-API-Key header
+type ClientKeyValidator interface {
+	ValidateKey(ctx context.Context, key string, origin string) (*models.ClientKey, error)
+}
+
+// ApiKeyMiddleware validates the X-API-Key header and adds the client key to
+// the context. It applies to every route of the group it is used in; routes
+// that must work without a key are registered outside such groups.
+func ApiKeyMiddleware(keys ClientKeyValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Always allow OPTIONS requests (CORS preflight)
@@ -15,35 +27,46 @@ func ApiKeyMiddleware(validApiKey string) func(http.Handler) http.Handler {
 				return
 			}
 
-			// Skip API key validation for public endpoints
-			publicPaths := []string{
-				"/health",
-				"/",
-				"/docs",
-				"/api/auth/login",
-				"/api/auth/register",
-				"/api/auth/refresh",
-			}
-
-			for _, path := range publicPaths {
-				if r.URL.Path == path || strings.HasPrefix(r.URL.Path, path) {
-					next.ServeHTTP(w, r)
-					return
-				}
-			}
-
 			apiKey := r.Header.Get("X-API-Key")
 			if apiKey == "" {
-				w.WriteHeader(http.StatusForbidden)
+				httputils.WriteError(w, http.StatusForbidden, "API key required")
 				return
 			}
 
-			if apiKey != validApiKey {
-				w.WriteHeader(http.StatusForbidden)
+			key, err := keys.ValidateKey(r.Context(), apiKey, r.Header.Get("Origin"))
+			if err != nil {
+				switch err.Error() {
+				case "invalid client key":
+					httputils.WriteError(w, http.StatusForbidden, "Invalid API key")
+				case "origin not allowed":
+					httputils.WriteError(w, http.StatusForbidden, "API key not allowed from this origin")
+				default:
+					httputils.WriteError(w, http.StatusInternalServerError, "Failed to validate API key")
+				}
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			ctx := context.WithValue(r.Context(), ClientKeyKey, key)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// RequireRootApiKey only lets through requests made with the root key
+// configured with API_KEY. It must run after ApiKeyMiddleware.
+func RequireRootApiKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, ok := GetClientKeyFromContext(r.Context())
+		if !ok || !key.Root {
+			httputils.WriteError(w, http.StatusForbidden, "Root API key required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetClientKeyFromContext extracts the client key of the request from context
+func GetClientKeyFromContext(ctx context.Context) (*models.ClientKey, bool) {
+	key, ok := ctx.Value(ClientKeyKey).(*models.ClientKey)
+	return key, ok
+}
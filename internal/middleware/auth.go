@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"todo-list-api/internal/models"
 	"todo-list-api/internal/utils"
 	httputils "todo-list-api/internal/utils/http"
 )
@@ -14,10 +15,32 @@ const (
 	UserIDKey    contextKey = "user_id"
 	UserEmailKey contextKey = "user_email"
 	ClaimsKey    contextKey = "claims"
+	ScopesKey    contextKey = "scopes"
 )
 
-// AuthMiddleware validates JWT tokens and adds user information to context
-func AuthMiddleware(jwtUtil *utils.JWT) func(http.Handler) http.Handler {
+// SessionValidator reports whether the session an access token belongs to was revoked
+type SessionValidator interface {
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// TokenAuthenticator resolves personal access tokens into the claims of their
+// user and the scopes they grant
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*utils.Claims, []string, error)
+}
+
+// authError is an authentication failure and the response it produces
+type authError struct {
+	status  int
+	message string
+}
+
+// AuthMiddleware authenticates the request with either a JWT access token or
+// a personal access token and adds the user information and scopes to the
+// context. JWTs grant every scope. Tokens of revoked sessions are rejected;
+// sessions may be nil to skip that check, and tokens may be nil to only
+// accept JWTs.
+func AuthMiddleware(jwtUtil *utils.JWT, sessions SessionValidator, tokens TokenAuthenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Extract token from Authorization header
@@ -40,18 +63,12 @@ func AuthMiddleware(jwtUtil *utils.JWT) func(http.Handler) http.Handler {
 				return
 			}
 
-			// Validate the access token
-			claims, err := jwtUtil.ValidateAccessToken(tokenString)
-			if err != nil {
-				httputils.WriteError(w, http.StatusUnauthorized, "Invalid or expired token")
+			ctx, authErr := authenticate(r.Context(), tokenString, jwtUtil, sessions, tokens)
+			if authErr != nil {
+				httputils.WriteError(w, authErr.status, authErr.message)
 				return
 			}
 
-			// Add user information to request context
-			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-			ctx = context.WithValue(ctx, UserEmailKey, claims.UserEmail)
-			ctx = context.WithValue(ctx, ClaimsKey, claims)
-
 			// Call the next handler with the updated context
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -61,7 +78,7 @@ func AuthMiddleware(jwtUtil *utils.JWT) func(http.Handler) http.Handler {
 // OptionalAuthMiddleware is similar to AuthMiddleware but doesn't require authentication
 // If a valid token is provided, user info is added to context
 // If no token or invalid token, request continues without user info
-func OptionalAuthMiddleware(jwtUtil *utils.JWT) func(http.Handler) http.Handler {
+func OptionalAuthMiddleware(jwtUtil *utils.JWT, sessions SessionValidator, tokens TokenAuthenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Extract token from Authorization header
@@ -70,11 +87,7 @@ func OptionalAuthMiddleware(jwtUtil *utils.JWT) func(http.Handler) http.Handler
 				parts := strings.SplitN(authHeader, " ", 2)
 				if len(parts) == 2 && parts[0] == "Bearer" && parts[1] != "" {
 					// Try to validate the token
-					if claims, err := jwtUtil.ValidateAccessToken(parts[1]); err == nil {
-						// Add user information to request context
-						ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
-						ctx = context.WithValue(ctx, UserEmailKey, claims.UserEmail)
-						ctx = context.WithValue(ctx, ClaimsKey, claims)
+					if ctx, authErr := authenticate(r.Context(), parts[1], jwtUtil, sessions, tokens); authErr == nil {
 						r = r.WithContext(ctx)
 					}
 				}
@@ -86,6 +99,50 @@ func OptionalAuthMiddleware(jwtUtil *utils.JWT) func(http.Handler) http.Handler
 	}
 }
 
+// authenticate validates a bearer token and returns the context carrying its
+// user information and scopes
+func authenticate(ctx context.Context, tokenString string, jwtUtil *utils.JWT, sessions SessionValidator, tokens TokenAuthenticator) (context.Context, *authError) {
+	var claims *utils.Claims
+	var scopes []string
+
+	if tokens != nil && strings.HasPrefix(tokenString, models.PersonalAccessTokenPrefix) {
+		var err error
+		claims, scopes, err = tokens.AuthenticateToken(ctx, tokenString)
+		if err != nil {
+			if err.Error() == "invalid or expired token" {
+				return nil, &authError{http.StatusUnauthorized, "Invalid or expired token"}
+			}
+			return nil, &authError{http.StatusInternalServerError, "Failed to validate token"}
+		}
+	} else {
+		// Validate the access token
+		var err error
+		claims, err = jwtUtil.ValidateAccessToken(tokenString)
+		if err != nil {
+			return nil, &authError{http.StatusUnauthorized, "Invalid or expired token"}
+		}
+
+		// Reject tokens whose session was revoked
+		if sessions != nil && claims.SessionID != "" {
+			revoked, err := sessions.IsSessionRevoked(ctx, claims.SessionID)
+			if err != nil {
+				return nil, &authError{http.StatusInternalServerError, "Failed to validate session"}
+			}
+			if revoked {
+				return nil, &authError{http.StatusUnauthorized, "Session has been revoked"}
+			}
+		}
+		scopes = models.AllScopes
+	}
+
+	// Add user information to request context
+	ctx = context.WithValue(ctx, UserIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, UserEmailKey, claims.UserEmail)
+	ctx = context.WithValue(ctx, ClaimsKey, claims)
+	ctx = context.WithValue(ctx, ScopesKey, scopes)
+	return ctx, nil
+}
+
 // GetUserIDFromContext extracts user ID from request context
 func GetUserIDFromContext(ctx context.Context) (uint64, bool) {
 	userID, ok := ctx.Value(UserIDKey).(uint64)
@@ -113,6 +170,62 @@ func GetClaimsFromContext(ctx context.Context) (*utils.Claims, bool) {
 	return claims, ok
 }
 
+// GetSessionIDFromContext extracts the session ID of the access token from request context
+func GetSessionIDFromContext(ctx context.Context) (string, bool) {
+	claims, ok := GetClaimsFromContext(ctx)
+	if !ok || claims.SessionID == "" {
+		return "", false
+	}
+	return claims.SessionID, true
+}
+
+// GetScopesFromContext extracts the scopes of the request's token from context
+func GetScopesFromContext(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(ScopesKey).([]string)
+	return scopes, ok
+}
+
+// HasScope reports whether the request's token grants scope
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, _ := GetScopesFromContext(ctx)
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireRole rejects requests whose user has none of roles. It must run
+// after AuthMiddleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetClaimsFromContext(r.Context())
+			if !ok || !claims.HasRole(roles...) {
+				httputils.WriteError(w, http.StatusForbidden, "Insufficient permissions")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequirePermission rejects requests whose user lacks permission. It must run
+// after AuthMiddleware.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetClaimsFromContext(r.Context())
+			if !ok || !claims.HasPermission(permission) {
+				httputils.WriteError(w, http.StatusForbidden, "Insufficient permissions")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequireUserID is a helper middleware that ensures a user ID is present in context
 func RequireUserID(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
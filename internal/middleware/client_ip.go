@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"net/http"
+	httputils "todo-list-api/internal/utils/http"
+)
+
+// ClientIPMiddleware resolves the client IP address of each request, which
+// httputils.ClientIP returns from then on. X-Forwarded-For is only believed
+// when it was sent by one of the trusted proxies.
+func ClientIPMiddleware(proxies httputils.TrustedProxies) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, httputils.WithClientIP(r, proxies.ClientIP(r)))
+		})
+	}
+}
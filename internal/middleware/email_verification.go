@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	httputils "todo-list-api/internal/utils/http"
+)
+
+// ReadOnlyUntilVerified only lets users with an unverified email read. It must
+// run after AuthMiddleware.
+func ReadOnlyUntilVerified(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, ok := GetClaimsFromContext(r.Context())
+		if !ok {
+			httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+			return
+		}
+		if !claims.EmailVerified {
+			httputils.WriteError(w, http.StatusForbidden, "Email verification required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
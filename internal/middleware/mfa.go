@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+	"todo-list-api/internal/utils"
+	httputils "todo-list-api/internal/utils/http"
+)
+
+// RequireRecentMFA only lets through users whose session was started with a
+// second factor less than maxAge ago. It must run after AuthMiddleware.
+func RequireRecentMFA(maxAge time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := GetClaimsFromContext(r.Context())
+			if !ok {
+				httputils.WriteError(w, http.StatusUnauthorized, "User authentication required")
+				return
+			}
+
+			if !claims.HasAuthMethod(utils.AuthMethodOTP) || claims.AuthTime == nil ||
+				time.Since(claims.AuthTime.Time) > maxAge {
+				httputils.WriteError(w, http.StatusForbidden, "Recent two-factor authentication required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
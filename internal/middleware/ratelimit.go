@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/utils"
+	httputils "todo-list-api/internal/utils/http"
+)
+
+// RateLimitStore takes requests from the token buckets of clients
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit models.RateLimit, now time.Time) (*models.RateLimitResult, error)
+}
+
+// RateLimitMiddleware limits each client of the routes it is used on to
+// limit. Clients are told apart by authenticated user when AuthMiddleware ran
+// before, otherwise by client key when ApiKeyMiddleware did, otherwise by IP.
+// name separates the buckets of route groups with different limits. Requests
+// are let through when the store fails, so an outage of it does not take the
+// API down, unless failClosed is set: then they get 503 Service Unavailable,
+// as routes guarding against password guessing must not go unlimited.
+func RateLimitMiddleware(store RateLimitStore, name string, limit models.RateLimit, failClosed bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Always allow OPTIONS requests (CORS preflight)
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client := rateLimitClient(r)
+			// Clients are hashed so that keys have a fixed length
+			key := name + ":" + utils.HashToken(client)
+			result, err := store.Take(r.Context(), key, limit, time.Now().UTC())
+			if err != nil {
+				log.Printf("Rate limiting failed for %s %s: %v", name, client, err)
+				if failClosed {
+					httputils.WriteError(w, http.StatusServiceUnavailable, "Rate limiting is unavailable, try again later")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+			if !result.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				httputils.WriteError(w, http.StatusTooManyRequests, "Rate limit exceeded, try again later")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitClient identifies the client of the request for rate limiting
+func rateLimitClient(r *http.Request) string {
+	if userID, ok := GetUserIDFromContext(r.Context()); ok {
+		return fmt.Sprintf("user:%d", userID)
+	}
+	if key, ok := GetClientKeyFromContext(r.Context()); ok {
+		if key.Root {
+			return "key:root"
+		}
+		return fmt.Sprintf("key:%d", key.ID)
+	}
+	return "ip:" + httputils.ClientIP(r)
+}
+
+// ceilSeconds rounds d up to whole seconds, as used by the rate limit headers
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, models.RateLimit, time.Time) (*models.RateLimitResult, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limit := models.RateLimit{Requests: 2, Period: time.Minute}
+	handler := RateLimitMiddleware(repository.NewMemoryRateLimitRepository(), "test", limit, false)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := request("192.0.2.1:1234")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", first.Header().Get("RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, request("192.0.2.1:1234").Code)
+
+	denied := request("192.0.2.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, denied.Code)
+	assert.Equal(t, "0", denied.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", denied.Header().Get("Retry-After"))
+
+	// Other clients have buckets of their own
+	assert.Equal(t, http.StatusOK, request("192.0.2.2:1234").Code)
+}
+
+func TestRateLimitMiddleware_StoreFailure(t *testing.T) {
+	limit := models.RateLimit{Requests: 2, Period: time.Minute}
+	request := func(failClosed bool) int {
+		handler := RateLimitMiddleware(failingRateLimitStore{}, "test", limit, failClosed)(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}),
+		)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request(false))
+	assert.Equal(t, http.StatusServiceUnavailable, request(true))
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	httputils "todo-list-api/internal/utils/http"
+)
+
+// RequireScope rejects requests whose token does not grant scope. It must run
+// after AuthMiddleware.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !HasScope(r.Context(), scope) {
+				httputils.WriteError(w, http.StatusForbidden, "Token lacks the required scope: "+scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireReadWriteScope requires readScope for safe methods (GET, HEAD,
+// OPTIONS) and writeScope for every other method
+func RequireReadWriteScope(readScope, writeScope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope := writeScope
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				scope = readScope
+			}
+
+			if !HasScope(r.Context(), scope) {
+				httputils.WriteError(w, http.StatusForbidden, "Token lacks the required scope: "+scope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
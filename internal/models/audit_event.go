@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AuditEvent is an audit event as stored in PostgreSQL, so that users can
+// export the events about their account
+type AuditEvent struct {
+	ID     uint64    `json:"-" gorm:"primaryKey"`
+	Type   string    `json:"type" gorm:"type:varchar(64);not null;index"`
+	Time   time.Time `json:"time" gorm:"column:occurred_at;not null"`
+	UserID uint64    `json:"-" gorm:"index"`
+	Email  string    `json:"email,omitempty" gorm:"type:varchar(320)"`
+	IP     string    `json:"ip,omitempty" gorm:"type:varchar(64)"`
+	// Details holds event-specific values
+	Details map[string]string `json:"details,omitempty" gorm:"serializer:json;type:text"`
+}
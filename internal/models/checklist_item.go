@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// MaxChecklistItems bounds the number of checklist items of a todo
+const MaxChecklistItems = 100
+
+// ChecklistItem is a lightweight step of a todo. Unlike a subtask it only
+// has a title and a checked state.
+type ChecklistItem struct {
+	ID      uint   `json:"id" gorm:"primaryKey"`
+	TodoID  uint   `json:"todoId" gorm:"not null;index"`
+	Todo    *Todo  `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Title   string `json:"title" gorm:"type:varchar(200);not null"`
+	Checked bool   `json:"checked" gorm:"not null;default:false"`
+	// Position orders the checklist, ascending. Items sharing a position are
+	// ordered by creation.
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateChecklistItemRequest struct {
+	Title string `json:"title" validate:"required,min=1,max=200"`
+	// Position defaults to the end of the checklist
+	Position *int `json:"position" validate:"omitempty,min=0"`
+}
+
+// UpdateChecklistItemRequest changes the fields that are set
+type UpdateChecklistItemRequest struct {
+	Title    *string `json:"title" validate:"omitempty,min=1,max=200"`
+	Checked  *bool   `json:"checked"`
+	Position *int    `json:"position" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// ClientKeyPrefix starts every client key
+const ClientKeyPrefix = "tdl_ck_"
+
+// ClientKey identifies a client application calling the API through the
+// X-API-Key header. Only a SHA-256 hash of the key is stored; the key itself
+// is shown once, at creation.
+type ClientKey struct {
+	ID    uint64 `json:"id" gorm:"primaryKey"`
+	Name  string `json:"name" gorm:"type:varchar(100);not null"`
+	Owner string `json:"owner" gorm:"type:varchar(255);not null"`
+	// KeyPrefix is the start of the key, which is enough to recognize it
+	KeyPrefix string `json:"keyPrefix" gorm:"type:varchar(16);not null"`
+	KeyHash   string `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	// AllowedOrigins restricts the key to requests from these browser origins.
+	// Keys without origins are accepted from anywhere.
+	AllowedOrigins []string   `json:"allowedOrigins" gorm:"serializer:json;type:text;not null"`
+	ExpiresAt      *time.Time `json:"expiresAt"`
+	LastUsedAt     *time.Time `json:"lastUsedAt"`
+	RevokedAt      *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+
+	// Root is set on the key configured with API_KEY, which is not stored
+	Root bool `json:"-" gorm:"-"`
+}
+
+// AllowsOrigin reports whether the key may be used from origin
+func (k *ClientKey) AllowsOrigin(origin string) bool {
+	if len(k.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, allowed := range k.AllowedOrigins {
+		if allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateClientKeyRequest struct {
+	Name           string   `json:"name" validate:"required,min=1,max=100"`
+	Owner          string   `json:"owner" validate:"required,min=1,max=255"`
+	AllowedOrigins []string `json:"allowedOrigins" validate:"dive,required"`
+	// ExpiresAt is optional; keys without it never expire
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// CreatedClientKey is returned once, when the key is created
+type CreatedClientKey struct {
+	ClientKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Statuses of a data export
+const (
+	DataExportPending = "pending"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+)
+
+// DataExport is an archive of everything stored about a user, built in the
+// background. The archive is downloaded through a signed link.
+type DataExport struct {
+	ID          string     `json:"id" gorm:"primaryKey;type:varchar(64)"`
+	UserID      uint64     `json:"-" gorm:"not null;index"`
+	User        *User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Status      string     `json:"status" gorm:"type:varchar(20);not null"`
+	Archive     []byte     `json:"-"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// ExpiresAt is when the export is deleted
+	ExpiresAt time.Time `json:"expiresAt" gorm:"not null;index"`
+	// DownloadURL is a signed link to the archive, set once it is ready. It
+	// expires long before the export does; get the export again for a new one.
+	DownloadURL string `json:"downloadUrl,omitempty" gorm:"-"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// EmailVerificationToken is a single-use token proving that a user controls
+// their email address. Only a SHA-256 hash of the token is stored.
+type EmailVerificationToken struct {
+	ID     uint64 `json:"id" gorm:"primaryKey"`
+	UserID uint64 `json:"-" gorm:"not null;index"`
+	User   *User  `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	// Email is the new address of an email change. It is empty when the
+	// token verifies the address the account already has.
+	Email     string     `json:"-" gorm:"type:varchar(255)"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// LoginAttempt counts the recent failed logins of an email or client IP
+type LoginAttempt struct {
+	// Key is the throttled subject: "email:" or "ip:" followed by the SHA-256 hash
+	// of the normalized email or client IP
+	Key           string    `gorm:"primaryKey;type:varchar(320)"`
+	Failures      int       `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"not null;index"`
+	LockedUntil   *time.Time
+}
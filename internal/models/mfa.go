@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// MFACredential is the TOTP secret of a user. Two-factor authentication is
+// enabled once the enrollment is confirmed with a first code.
+type MFACredential struct {
+	UserID uint64 `json:"-" gorm:"primaryKey;autoIncrement:false"`
+	User   *User  `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Secret string `json:"-" gorm:"type:varchar(64);not null"`
+	// LastUsedStep is the TOTP time step of the last accepted code; codes of
+	// that step or earlier are rejected so a code cannot be replayed
+	LastUsedStep   int64      `json:"-" gorm:"not null;default:0"`
+	FailedAttempts int        `json:"-" gorm:"not null;default:0"`
+	LockedUntil    *time.Time `json:"-"`
+	ConfirmedAt    *time.Time `json:"confirmedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the user
+// has lost their device. Only a SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	UserID    uint64     `json:"-" gorm:"not null;index"`
+	User      *User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CodeHash  string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// MFAEnrollment is returned when a user starts enrolling an authenticator app
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauthUri"`
+}
+
+// RecoveryCodes are shown to the user once, when they are generated
+type RecoveryCodes struct {
+	Codes []string `json:"recoveryCodes"`
+}
+
+type ConfirmMFARequest struct {
+	Code string `json:"code"`
+}
+
+// VerifyMFARequest completes a login with either a TOTP code or a recovery code
+type VerifyMFARequest struct {
+	MFAToken     string `json:"mfaToken"`
+	Code         string `json:"code,omitempty"`
+	RecoveryCode string `json:"recoveryCode,omitempty"`
+}
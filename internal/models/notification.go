@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// MaxNotificationsListed bounds the notifications returned at once, newest first
+const MaxNotificationsListed = 100
+
+// Notification is an in-app message for a user, such as a fired reminder
+type Notification struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint64     `json:"userId" gorm:"not null;index"`
+	User      *User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TodoID    *uint      `json:"todoId" gorm:"index"`
+	Todo      *Todo      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Title     string     `json:"title" gorm:"type:varchar(250);not null"`
+	Body      string     `json:"body" gorm:"type:varchar(1000)"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
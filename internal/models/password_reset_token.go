@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// PasswordResetToken is a single-use token that lets a user choose a new
+// password. Only a SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	UserID    uint64     `json:"-" gorm:"not null;index"`
+	User      *User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Scopes limit what a personal access token may do. Logins with a password
+// (JWT access tokens) have every scope.
+const (
+	ScopeTodosRead    = "todos:read"
+	ScopeTodosWrite   = "todos:write"
+	ScopeAccountAdmin = "account:admin"
+)
+
+// AllScopes lists every scope
+var AllScopes = []string{ScopeTodosRead, ScopeTodosWrite, ScopeAccountAdmin}
+
+// PersonalAccessTokenPrefix starts every personal access token, which tells
+// them apart from JWTs and makes leaked tokens easy to detect
+const PersonalAccessTokenPrefix = "tdl_pat_"
+
+// PersonalAccessToken is a long-lived token for scripts and CI. Only a SHA-256
+// hash of the token is stored; the token itself is shown once, at creation.
+type PersonalAccessToken struct {
+	ID         uint64     `json:"id" gorm:"primaryKey"`
+	UserID     uint64     `json:"-" gorm:"not null;index"`
+	User       *User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name       string     `json:"name" gorm:"type:varchar(100);not null"`
+	TokenHash  string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	Scopes     []string   `json:"scopes" gorm:"serializer:json;type:text;not null"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"-"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// HasScope reports whether the token was granted scope
+func (t *PersonalAccessToken) HasScope(scope string) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=todos:read todos:write account:admin"`
+	// ExpiresAt is optional; tokens without it never expire
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// CreatedPersonalAccessToken is returned once, when the token is created
+type CreatedPersonalAccessToken struct {
+	PersonalAccessToken
+	Token string `json:"token"`
+}
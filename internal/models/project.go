@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Project groups todos of a user. Todos without a project are in the Inbox.
+// The todos of an archived project are hidden from the default todo listing.
+type Project struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	UserID   uint64 `json:"-" gorm:"not null;index"`
+	User     *User  `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name     string `json:"name" gorm:"type:varchar(100);not null"`
+	Color    string `json:"color" gorm:"type:varchar(9)"`
+	Icon     string `json:"icon" gorm:"type:varchar(50)"`
+	Archived bool   `json:"archived" gorm:"not null;default:false"`
+	// Position orders the projects, ascending. Projects sharing a position
+	// are ordered by creation.
+	Position  int       `json:"position" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type CreateProjectRequest struct {
+	Name  string `json:"name" validate:"required,min=1,max=100"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+	Icon  string `json:"icon" validate:"max=50"`
+	// Position defaults to the end of the project list
+	Position *int `json:"position" validate:"omitempty,min=0"`
+}
+
+// UpdateProjectRequest changes the fields that are set
+type UpdateProjectRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Color    *string `json:"color" validate:"omitempty,hexcolor"`
+	Icon     *string `json:"icon" validate:"omitempty,max=50"`
+	Archived *bool   `json:"archived"`
+	Position *int    `json:"position" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// RateLimit allows Requests requests per Period. Unused allowance builds up
+// to Requests, so clients may burst after being idle.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RefillRate returns how many requests are regained per second
+func (l RateLimit) RefillRate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// RateLimitBucket is the token bucket of one client, as stored in PostgreSQL
+type RateLimitBucket struct {
+	Key       string    `gorm:"primaryKey;type:varchar(255)"`
+	Tokens    float64   `gorm:"not null"`
+	Allowed   bool      `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;index"`
+}
+
+// RateLimitResult is the outcome of taking a request from a bucket
+type RateLimitResult struct {
+	Allowed bool
+	// Remaining is how many more requests are allowed right now
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, when this one was not
+	RetryAfter time.Duration
+}
+
+// NewRateLimitResult describes a bucket left with tokens by a request
+func NewRateLimitResult(limit RateLimit, tokens float64, allowed bool) *RateLimitResult {
+	rate := limit.RefillRate()
+	result := &RateLimitResult{
+		Allowed:   allowed,
+		Remaining: int(tokens),
+		Reset:     time.Duration((float64(limit.Requests) - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return result
+}
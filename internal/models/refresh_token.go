@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// RefreshToken is the server-side record of an issued refresh token. Only a
+// SHA-256 hash of the token is stored. Every refresh rotates the token, and
+// all tokens descending from the same login share a FamilyID, which is also
+// the ID of the Session they belong to.
+type RefreshToken struct {
+	ID        uint64     `json:"id" gorm:"primaryKey"`
+	UserID    uint64     `json:"-" gorm:"not null;index"`
+	User      *User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	FamilyID  string     `json:"-" gorm:"type:varchar(64);not null;index"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	RotatedAt *time.Time `json:"-"`
+	RevokedAt *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// MaxRemindersPerTodo bounds the number of reminders of a todo
+const MaxRemindersPerTodo = 10
+
+// Channels reminders are delivered through
+const (
+	ReminderChannelEmail   = "email"
+	ReminderChannelWebhook = "webhook"
+	ReminderChannelInApp   = "in_app"
+)
+
+// Delivery states of a reminder
+const (
+	ReminderPending = "pending" // waiting to fire, or to be retried
+	ReminderSent    = "sent"
+	ReminderFailed  = "failed" // every delivery attempt failed
+)
+
+// Reminder notifies the owner of a todo at RemindAt, or OffsetMinutes before
+// the todo's due date. Reminders of completed, cancelled or deleted todos do
+// not fire.
+type Reminder struct {
+	ID     uint  `json:"id" gorm:"primaryKey"`
+	TodoID uint  `json:"todoId" gorm:"not null;index"`
+	Todo   *Todo `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	// Exactly one of RemindAt and OffsetMinutes is set. A relative reminder
+	// follows changes of the due date and does not fire while there is none.
+	RemindAt      *time.Time `json:"remindAt"`
+	OffsetMinutes *int       `json:"offsetMinutes"`
+	Channel       string     `json:"channel" gorm:"type:varchar(20);not null"`
+	WebhookURL    string     `json:"webhookUrl,omitempty" gorm:"type:varchar(2000)"`
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	LastError     string     `json:"lastError,omitempty" gorm:"type:varchar(500)"`
+	// NextAttemptAt holds back a reminder that is being delivered or waits
+	// for a retry. It is nil for reminders that have not been claimed yet.
+	NextAttemptAt *time.Time `json:"-" gorm:"index"`
+	SentAt        *time.Time `json:"sentAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// CreateReminderRequest sets either an absolute time or an offset before the due date
+type CreateReminderRequest struct {
+	RemindAt *string `json:"remindAt" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// OffsetMinutes is how many minutes before the due date the reminder
+	// fires, at most a year
+	OffsetMinutes *int   `json:"offsetMinutes" validate:"omitempty,min=0,max=525600"`
+	Channel       string `json:"channel" validate:"required,oneof=email webhook in_app"`
+	// WebhookURL receives the reminder when Channel is "webhook"
+	WebhookURL string `json:"webhookUrl" validate:"required_if=Channel webhook,omitempty,url,startswith=https://,max=2000"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// Roles of a user. Every user has exactly one.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permissions granted by roles, carried in access tokens
+const (
+	// PermissionUsersRead allows listing and viewing any account
+	PermissionUsersRead = "users:read"
+	// PermissionUsersManage allows disabling accounts and forcing password resets
+	PermissionUsersManage = "users:manage"
+	// PermissionTodosReadAll allows viewing the todos of any user, for support
+	PermissionTodosReadAll = "todos:read_all"
+)
+
+// rolePermissions lists the permissions of each role
+var rolePermissions = map[string][]string{
+	RoleUser:  {},
+	RoleAdmin: {PermissionUsersRead, PermissionUsersManage, PermissionTodosReadAll},
+}
+
+// PermissionsForRole returns the permissions granted by role, or none for an unknown role
+func PermissionsForRole(role string) []string {
+	return rolePermissions[role]
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Session is a login on one device. It starts at login and lives as long as
+// its refresh tokens keep being rotated; the refresh token family of a session
+// shares its ID (RefreshToken.FamilyID).
+type Session struct {
+	ID         string     `json:"id" gorm:"primaryKey;type:varchar(64)"`
+	UserID     uint64     `json:"-" gorm:"not null;index"`
+	User       *User      `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	UserAgent  string     `json:"userAgent" gorm:"type:varchar(512)"`
+	IPAddress  string     `json:"ipAddress" gorm:"type:varchar(64)"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt time.Time  `json:"lastUsedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	RevokedAt  *time.Time `json:"-" gorm:"index"`
+	// AuthMethods is the comma-separated list of methods the user logged in
+	// with (e.g. "pwd,otp") and AuthenticatedAt is when the login completed
+	AuthMethods     string    `json:"authMethods" gorm:"type:varchar(64)"`
+	AuthenticatedAt time.Time `json:"authenticatedAt"`
+	Current         bool      `json:"current" gorm:"-"`
+}
+
+// AuthMethodList returns AuthMethods as a list
+func (s *Session) AuthMethodList() []string {
+	if s.AuthMethods == "" {
+		return nil
+	}
+	return strings.Split(s.AuthMethods, ",")
+}
+
+// ClientInfo describes the client making an authentication request
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
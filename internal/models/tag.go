@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Tag limits
+const (
+	MaxTagNameLength = 50
+	MaxTagsPerTodo   = 20
+)
+
+// Tag labels todos of a user. Names are unique per user, ignoring case.
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint64    `json:"-" gorm:"not null;index"`
+	User      *User     `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name      string    `json:"name" gorm:"type:varchar(50);not null"`
+	Color     string    `json:"color" gorm:"type:varchar(9)"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TodoTag links a todo to one of its tags
+type TodoTag struct {
+	TodoID uint  `gorm:"primaryKey"`
+	Todo   *Todo `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	TagID  uint  `gorm:"primaryKey;index"`
+	Tag    *Tag  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type CreateTagRequest struct {
+	Name  string `json:"name" validate:"required,min=1,max=50"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+// UpdateTagRequest renames or recolors a tag. Only the fields that are set change.
+type UpdateTagRequest struct {
+	Name  *string `json:"name" validate:"omitempty,min=1,max=50"`
+	Color *string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+// MergeTagRequest names the tag that takes over the todos of the merged tag
+type MergeTagRequest struct {
+	TargetID uint `json:"targetId" validate:"required"`
+}
+
+// Tag matching modes of the todo list filter
+const (
+	TagMatchAny = "any" // todos with at least one of the tags (default)
+	TagMatchAll = "all" // todos with every one of the tags
+)
@@ -8,15 +8,59 @@ import (
 
 type Todo struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
+	UserID      uint64         `json:"userId" gorm:"index"`
+	User        *User          `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ParentID    *uint          `json:"parentId" gorm:"index"`
+	Parent      *Todo          `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ProjectID   *uint          `json:"projectId" gorm:"index"`
+	Project     *Project       `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 	Title       string         `json:"title" gorm:"varchar(200);not null"`
 	Description string         `json:"description" gorm:"type:varchar(1000)"`
 	Priority    string         `json:"priority" gorm:"type:varchar(10);default:'low'"`
 	DueDate     *time.Time     `json:"dueDate"`
-	Category    string         `json:"category" gorm:"type:varchar(100)"`
 	Completed   bool           `json:"completed" gorm:"default:false"`
 	CreatedAt   time.Time      `json:"createdAt"`
 	UpdatedAt   time.Time      `json:"updatedAt"`
 	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	// StartDate and SnoozedUntil defer a todo: it is left out of the todo
+	// listings until both have passed. SnoozedUntil is set by snoozing.
+	StartDate    *time.Time `json:"startDate"`
+	SnoozedUntil *time.Time `json:"snoozedUntil"`
+	// Status is a status key of the todo's workflow. Completed is derived
+	// from it: a todo is completed while its status is in the done category.
+	// The timestamps record when the todo was first started, and when it was
+	// completed or cancelled, as long as it still is.
+	Status      string     `json:"status" gorm:"type:varchar(50);not null;default:'todo';index"`
+	StartedAt   *time.Time `json:"startedAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+	CancelledAt *time.Time `json:"cancelledAt"`
+	// Recurrence is an RFC 5545 RRULE such as "FREQ=WEEKLY;BYDAY=MO",
+	// evaluated in RecurrenceTimezone (UTC when empty). Completing the todo
+	// creates the next occurrence, which takes over the rule.
+	Recurrence         string `json:"recurrence" gorm:"type:varchar(500)"`
+	RecurrenceTimezone string `json:"recurrenceTimezone" gorm:"type:varchar(64)"`
+	RepeatFrom         string `json:"repeatFrom" gorm:"type:varchar(20)"`
+	// RecurrenceStart is the start of the series the due dates follow
+	RecurrenceStart *time.Time `json:"-"`
+	// Occurrence numbers the todos of a series, from 1
+	Occurrence int `json:"occurrence" gorm:"not null;default:0"`
+	// NextOccurrence is the todo created by completing a recurring todo
+	NextOccurrence *Todo `json:"nextOccurrence,omitempty" gorm:"-"`
+	// Tags, Checklist and Progress are loaded by the repository
+	Tags      []Tag           `json:"tags" gorm:"-"`
+	Checklist []ChecklistItem `json:"checklist" gorm:"-"`
+	Progress  TodoProgress    `json:"progress" gorm:"-"`
+}
+
+// TodoProgress counts the steps of a todo that are done: its direct subtasks
+// and its checklist items. Percent is 0 or 100 for a todo without steps,
+// depending on whether it is completed.
+type TodoProgress struct {
+	Subtasks          int `json:"subtasks"`
+	CompletedSubtasks int `json:"completedSubtasks"`
+	ChecklistItems    int `json:"checklistItems"`
+	CheckedItems      int `json:"checkedItems"`
+	Percent           int `json:"percent"`
 }
 
 type CreateTodoRequest struct {
@@ -24,14 +68,73 @@ type CreateTodoRequest struct {
 	Description string  `json:"description" validate:"max=1000"`
 	Priority    string  `json:"priority" validate:"omitempty,oneof=low medium high"`
 	DueDate     *string `json:"dueDate" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
-	Category    string  `json:"category" validate:"omitempty,max=100"`
+	// ProjectID puts the todo in a project instead of the Inbox
+	ProjectID *uint `json:"projectId"`
+	// StartDate hides the todo from the todo listings until then
+	StartDate *string `json:"startDate" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// Recurrence is an RFC 5545 RRULE. The next occurrence is due after the
+	// due date, or after the completion date when RepeatFrom is "completion".
+	Recurrence         string `json:"recurrence" validate:"max=500"`
+	RecurrenceTimezone string `json:"recurrenceTimezone" validate:"omitempty,timezone"`
+	RepeatFrom         string `json:"repeatFrom" validate:"omitempty,oneof=due completion"`
+	// Tags are tag names; missing tags are created. TagIDs must be existing tags.
+	Tags   []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	TagIDs []uint   `json:"tagIds" validate:"omitempty,max=20"`
 }
 
+// UpdateTodoRequest is the full representation of a todo's editable fields.
+// PUT replaces every field with the request, so omitted fields are cleared.
+// PATCH documents are applied to this representation before validation.
 type UpdateTodoRequest struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
+	Title       string  `json:"title" validate:"required,min=1,max=200"`
+	Description string  `json:"description" validate:"max=1000"`
 	Completed   bool    `json:"completed"`
 	Priority    string  `json:"priority" validate:"omitempty,oneof=low medium high"`
 	DueDate     *string `json:"dueDate" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
-	Category    string  `json:"category" validate:"omitempty,max=100"`
+	ProjectID   *uint   `json:"projectId"`
+	StartDate   *string `json:"startDate" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// Recurrence, RecurrenceTimezone and RepeatFrom are as in CreateTodoRequest
+	Recurrence         string `json:"recurrence" validate:"max=500"`
+	RecurrenceTimezone string `json:"recurrenceTimezone" validate:"omitempty,timezone"`
+	RepeatFrom         string `json:"repeatFrom" validate:"omitempty,oneof=due completion"`
+	// Tags and TagIDs together replace the tags of the todo, as in CreateTodoRequest
+	Tags   []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	TagIDs []uint   `json:"tagIds" validate:"omitempty,max=20"`
 }
+
+// SnoozeTodoRequest hides a todo from the todo listings until a time given
+// in exactly one of its fields
+type SnoozeTodoRequest struct {
+	// Until is the end of the snooze
+	Until *string `json:"until" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// Duration is counted from now, in minutes, hours, days or weeks such as "90m", "3h", "2d" or "1w"
+	Duration string `json:"duration" validate:"omitempty,max=20"`
+	// Preset is one of the Snooze* values, resolved in the user's time zone
+	Preset string `json:"preset" validate:"omitempty,oneof=tomorrow weekend next_week next_month"`
+}
+
+// Snooze presets. Each one ends at the start of a day in the user's time zone.
+const (
+	SnoozeTomorrow  = "tomorrow"
+	SnoozeWeekend   = "weekend"    // the next Saturday
+	SnoozeNextWeek  = "next_week"  // the first day of next week, as the user's week start says
+	SnoozeNextMonth = "next_month" // the first day of next month
+)
+
+// Where the next occurrence of a recurring todo is counted from
+const (
+	RepeatFromDue        = "due"        // the due date of the completed todo (default)
+	RepeatFromCompletion = "completion" // the day the todo was completed
+)
+
+// How DELETE /api/todos/{id} handles the subtasks of the deleted todo
+const (
+	SubtasksDelete  = "delete"  // the subtasks are deleted with it (default)
+	SubtasksPromote = "promote" // the subtasks move up to the deleted todo's parent
+)
+
+// Supported PATCH document formats
+const (
+	PatchTypeMerge = "merge" // RFC 7396 JSON Merge Patch
+	PatchTypeJSON  = "json"  // RFC 6902 JSON Patch
+)
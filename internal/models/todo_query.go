@@ -0,0 +1,146 @@
+package models
+
+import "time"
+
+// Sortable fields accepted by the todo list endpoint
+const (
+	TodoSortCreatedAt = "createdAt"
+	TodoSortUpdatedAt = "updatedAt"
+	TodoSortDueDate   = "dueDate"
+	TodoSortPriority  = "priority"
+	TodoSortTitle     = "title"
+)
+
+// Sort directions
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// Page size limits for todo listings
+const (
+	DefaultTodoPageSize = 20
+	MaxTodoPageSize     = 100
+)
+
+// ListTodosRequest holds the raw query parameters of GET /api/todos
+type ListTodosRequest struct {
+	Limit     int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor    string `json:"cursor"`
+	Completed *bool  `json:"completed"`
+	Priority  string `json:"priority" validate:"omitempty,oneof=low medium high"`
+	Status    string `json:"status" validate:"max=50"`
+	// ProjectID selects the todos of a project, or of the Inbox when it is 0
+	ProjectID *uint `json:"projectId"`
+	// Tags and TagIDs select todos by tag name or ID, matched as TagMatch says
+	Tags      []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	TagIDs    []uint   `json:"tagIds" validate:"omitempty,max=20"`
+	TagMatch  string   `json:"tagMatch" validate:"omitempty,oneof=any all"`
+	DueBefore *string  `json:"dueBefore" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	DueAfter  *string  `json:"dueAfter" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Overdue   *bool    `json:"overdue"`
+	Sort      string   `json:"sort" validate:"omitempty,oneof=createdAt updatedAt dueDate priority title"`
+	Order     string   `json:"order" validate:"omitempty,oneof=asc desc"`
+	// IncludeDeferred lists the todos whose start date or snooze is in the future too
+	IncludeDeferred bool `json:"includeDeferred"`
+}
+
+// TodoFilter narrows a todo listing. Nil or empty fields are not applied.
+type TodoFilter struct {
+	Completed *bool
+	Priority  string
+	Status    string
+	// ProjectID selects a project, or the Inbox when it is 0. Without it the
+	// todos of archived projects are left out.
+	ProjectID *uint
+	// TagIDs selects todos with any or all of the tags, as TagMatch says
+	TagIDs    []uint
+	TagMatch  string
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	Overdue   *bool
+	// IncludeDeferred keeps the todos whose start date or snooze is in the
+	// future, which are left out otherwise
+	IncludeDeferred bool
+}
+
+// TodoQuery is the backend-agnostic description of a todo listing
+type TodoQuery struct {
+	Filter TodoFilter
+	Sort   string
+	Order  string
+	Limit  int
+	Cursor *TodoCursor
+}
+
+// TodoCursor identifies the last item of a page for keyset pagination.
+// Value is the sort field of that item, serialized by the repository.
+type TodoCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// TodoPage is the response envelope for todo listings
+type TodoPage struct {
+	Items      []Todo `json:"items"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	TotalCount int64  `json:"totalCount"`
+}
+
+// SearchTodosRequest holds the raw query parameters of GET /api/todos/search
+type SearchTodosRequest struct {
+	Query     string   `json:"q" validate:"required,max=200"`
+	Limit     int      `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset    int      `json:"offset" validate:"omitempty,min=0"`
+	Completed *bool    `json:"completed"`
+	Priority  string   `json:"priority" validate:"omitempty,oneof=low medium high"`
+	ProjectID *uint    `json:"projectId"`
+	Tags      []string `json:"tags" validate:"omitempty,max=20,dive,required,max=50"`
+	TagIDs    []uint   `json:"tagIds" validate:"omitempty,max=20"`
+	TagMatch  string   `json:"tagMatch" validate:"omitempty,oneof=any all"`
+}
+
+// TodoSearchQuery is the backend-agnostic description of a full-text search
+type TodoSearchQuery struct {
+	Text   string
+	Filter TodoFilter
+	Limit  int
+	Offset int
+}
+
+// TodoSearchResult is a matching todo with its relevance and highlighted snippets.
+// The highlights are HTML: the text is escaped and matched terms are wrapped
+// in <mark></mark>.
+type TodoSearchResult struct {
+	Todo                 Todo    `json:"todo"`
+	Rank                 float64 `json:"rank"`
+	TitleHighlight       string  `json:"titleHighlight"`
+	DescriptionHighlight string  `json:"descriptionHighlight"`
+}
+
+// TodoSearchPage is the response envelope for todo searches
+type TodoSearchPage struct {
+	Items      []TodoSearchResult `json:"items"`
+	TotalCount int64              `json:"totalCount"`
+}
+
+// ListTrashRequest holds the raw query parameters of GET /api/todos/trash
+type ListTrashRequest struct {
+	Limit  int `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int `json:"offset" validate:"omitempty,min=0"`
+}
+
+// TrashedTodo is a deleted todo that can still be restored
+type TrashedTodo struct {
+	Todo
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// TrashPage is the response envelope for the trash listing, most recently
+// deleted first
+type TrashPage struct {
+	Items      []TrashedTodo `json:"items"`
+	TotalCount int64         `json:"totalCount"`
+}
@@ -1,6 +1,11 @@
 package models
 
+// Token is the result of a login. When the account has two-factor
+// authentication enabled, only MFARequired and MFAToken are set, and the
+// token pair is obtained from /api/auth/mfa/verify.
 type Token struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refreshToken"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	MFARequired  bool   `json:"mfaRequired,omitempty"`
+	MFAToken     string `json:"mfaToken,omitempty"`
 }
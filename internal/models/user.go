@@ -7,14 +7,86 @@ import (
 )
 
 type User struct {
-	ID        uint64         `json:"id" gorm:"primaryKey"`
-	CreatedAt time.Time      `json:"createdAt"`
-	UpdatedAt time.Time      `json:"updatedAt"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
-	Email     string         `json:"email" gorm:"unique"`
-	Password  string         `json:"-"`
-	FirstName string         `json:"firstName"`
-	LastName  string         `json:"lastName"`
+	ID            uint64         `json:"id" gorm:"primaryKey"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+	Email         string         `json:"email" gorm:"uniqueIndex:idx_users_email,where:deleted_at IS NULL"`
+	Password      string         `json:"-"`
+	FirstName     string         `json:"firstName"`
+	LastName      string         `json:"lastName"`
+	EmailVerified bool           `json:"emailVerified" gorm:"not null;default:false"`
+	VerifiedAt    *time.Time     `json:"verifiedAt,omitempty"`
+	Role          string         `json:"role" gorm:"type:varchar(20);not null;default:user;index"`
+	// DisabledAt is set while an administrator has disabled the account
+	DisabledAt *time.Time `json:"disabledAt,omitempty"`
+	// PasswordResetRequired blocks logging in until the password is reset
+	PasswordResetRequired bool            `json:"passwordResetRequired" gorm:"not null;default:false"`
+	Preferences           UserPreferences `json:"preferences" gorm:"serializer:json;type:text"`
+}
+
+// UserPreferences are the settings users choose for themselves
+type UserPreferences struct {
+	// Timezone is the IANA time zone dates are resolved in, e.g. "Europe/Paris".
+	// Empty means UTC.
+	Timezone string `json:"timezone,omitempty"`
+	// WeekStart is the first day of the week, "monday" (the default) or "sunday"
+	WeekStart string `json:"weekStart,omitempty"`
+}
+
+// Disabled reports whether an administrator disabled the account
+func (u *User) Disabled() bool {
+	return u.DisabledAt != nil
+}
+
+// DefaultUserPageSize is the page size of user listings without a limit
+const DefaultUserPageSize = 20
+
+// ListUsersRequest holds the query parameters of GET /api/admin/users
+type ListUsersRequest struct {
+	// Search matches the email and names, case-insensitively
+	Search   string `json:"search" validate:"max=200"`
+	Role     string `json:"role" validate:"omitempty,oneof=user admin"`
+	Disabled *bool  `json:"disabled"`
+	Limit    int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset   int    `json:"offset" validate:"omitempty,min=0"`
+}
+
+// UserPage is the response envelope for user listings
+type UserPage struct {
+	Items      []User `json:"items"`
+	TotalCount int64  `json:"totalCount"`
+}
+
+// UpdateProfileRequest is the body of PATCH /api/me. Omitted fields are left unchanged.
+type UpdateProfileRequest struct {
+	FirstName   *string                   `json:"firstName" validate:"omitempty,max=100"`
+	LastName    *string                   `json:"lastName" validate:"omitempty,max=100"`
+	Preferences *UpdatePreferencesRequest `json:"preferences"`
+}
+
+// UpdatePreferencesRequest changes the given preferences. An empty string
+// restores the default.
+type UpdatePreferencesRequest struct {
+	Timezone  *string `json:"timezone" validate:"omitempty,timezone"`
+	WeekStart *string `json:"weekStart" validate:"omitempty,oneof=monday sunday"`
+}
+
+// ChangePasswordRequest is the body of POST /api/me/password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+// ChangeEmailRequest is the body of POST /api/me/email
+type ChangeEmailRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// DeleteAccountRequest is the body of DELETE /api/me
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
 }
 
 type CreateUserRequest struct {
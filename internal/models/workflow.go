@@ -0,0 +1,96 @@
+package models
+
+import "time"
+
+// Status categories. The category of a todo's status decides whether the todo
+// counts as completed and which of its timestamps are set.
+const (
+	StatusCategoryTodo       = "todo"        // not started
+	StatusCategoryInProgress = "in_progress" // started; sets StartedAt
+	StatusCategoryDone       = "done"        // completed; sets CompletedAt
+	StatusCategoryCancelled  = "cancelled"   // dropped; sets CancelledAt
+)
+
+// MaxWorkflowStatuses bounds the number of statuses of a workflow
+const MaxWorkflowStatuses = 20
+
+// WorkflowStatus is a status todos can have. Transitions are the keys of the
+// statuses a todo can move to from this one.
+type WorkflowStatus struct {
+	Key         string   `json:"key" validate:"required,max=50"`
+	Name        string   `json:"name" validate:"required,max=100"`
+	Category    string   `json:"category" validate:"required,oneof=todo in_progress done cancelled"`
+	Transitions []string `json:"transitions" validate:"max=20,dive,required,max=50"`
+}
+
+// Workflow is the status set of the todos of a user, or of the todos of one
+// of their projects when ProjectID is set. New todos get the first status.
+type Workflow struct {
+	ID        uint             `json:"id" gorm:"primaryKey"`
+	UserID    uint64           `json:"-" gorm:"not null;index"`
+	User      *User            `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ProjectID *uint            `json:"projectId" gorm:"index"`
+	Project   *Project         `json:"-" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Statuses  []WorkflowStatus `json:"statuses" gorm:"serializer:json;type:text;not null"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+// Status returns the status of the workflow with the key
+func (w *Workflow) Status(key string) (WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Key == key {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// FirstStatus returns the first status of the category, falling back to the
+// first status of the workflow
+func (w *Workflow) FirstStatus(category string) WorkflowStatus {
+	for _, status := range w.Statuses {
+		if status.Category == category {
+			return status
+		}
+	}
+	return w.Statuses[0]
+}
+
+// Built-in statuses of the default workflow
+const (
+	StatusTodo       = "todo"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusWaiting    = "waiting"
+	StatusDone       = "done"
+	StatusCancelled  = "cancelled"
+)
+
+// DefaultWorkflow returns the workflow of users who have not set their own
+func DefaultWorkflow() *Workflow {
+	return &Workflow{Statuses: []WorkflowStatus{
+		{Key: StatusTodo, Name: "To do", Category: StatusCategoryTodo,
+			Transitions: []string{StatusInProgress, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled}},
+		{Key: StatusInProgress, Name: "In progress", Category: StatusCategoryInProgress,
+			Transitions: []string{StatusTodo, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled}},
+		{Key: StatusBlocked, Name: "Blocked", Category: StatusCategoryInProgress,
+			Transitions: []string{StatusTodo, StatusInProgress, StatusWaiting, StatusCancelled}},
+		{Key: StatusWaiting, Name: "Waiting on someone", Category: StatusCategoryInProgress,
+			Transitions: []string{StatusTodo, StatusInProgress, StatusBlocked, StatusDone, StatusCancelled}},
+		{Key: StatusDone, Name: "Done", Category: StatusCategoryDone,
+			Transitions: []string{StatusTodo, StatusInProgress}},
+		{Key: StatusCancelled, Name: "Cancelled", Category: StatusCategoryCancelled,
+			Transitions: []string{StatusTodo}},
+	}}
+}
+
+// SetWorkflowRequest replaces the statuses of a workflow
+type SetWorkflowRequest struct {
+	Statuses []WorkflowStatus `json:"statuses" validate:"required,min=1,max=20,dive"`
+}
+
+// TransitionTodoRequest moves a todo to another status of its workflow
+type TransitionTodoRequest struct {
+	Status string `json:"status" validate:"required,max=50"`
+}
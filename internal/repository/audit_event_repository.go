@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// AuditEventRepository defines the interface for audit event data access operations
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+	// ListByUserID returns the events about the user, oldest first
+	ListByUserID(ctx context.Context, userID uint64) ([]models.AuditEvent, error)
+}
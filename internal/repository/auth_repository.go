@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"todo-list-api/internal/models"
 )
 
@@ -10,4 +11,13 @@ type AuthRepository interface {
 	CreateUser(ctx context.Context, user *models.User) (*models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	// UpdatePassword sets the password and lifts a required password reset
+	UpdatePassword(ctx context.Context, id uint64, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, id uint64, verifiedAt time.Time) error
+	// UpdateProfile saves the names and preferences of the user
+	UpdateProfile(ctx context.Context, user *models.User) error
+	// UpdateEmail changes the email of the user to an address verified at verifiedAt
+	UpdateEmail(ctx context.Context, id uint64, email string, verifiedAt time.Time) error
+	// DeleteUser soft deletes the user together with their todos
+	DeleteUser(ctx context.Context, id uint64) error
 }
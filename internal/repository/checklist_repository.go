@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// ChecklistRepository defines the interface for checklist item data access
+// operations. Items are scoped to their todo; ownership of the todo is
+// checked by the caller.
+type ChecklistRepository interface {
+	// ListByTodoID returns the checklist of the todo in order
+	ListByTodoID(ctx context.Context, todoID uint) ([]models.ChecklistItem, error)
+	GetByID(ctx context.Context, todoID uint, id uint) (*models.ChecklistItem, error)
+	Create(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error)
+	// Update overwrites the title, checked state and position of the item
+	Update(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error)
+	Delete(ctx context.Context, todoID uint, id uint) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// ClientKeyRepository defines the interface for client key data access operations
+type ClientKeyRepository interface {
+	Create(ctx context.Context, key *models.ClientKey) (*models.ClientKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*models.ClientKey, error)
+	// List returns every key that was not revoked, newest first
+	List(ctx context.Context) ([]models.ClientKey, error)
+	// Revoke revokes a key. It returns false if there is no such active key.
+	Revoke(ctx context.Context, id uint64) (bool, error)
+	// TouchLastUsed records a use of the key. Uses less than a minute apart
+	// are not written, so busy keys do not cause a write per request.
+	TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error
+}
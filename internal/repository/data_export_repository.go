@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// DataExportRepository defines the interface for data export operations
+type DataExportRepository interface {
+	Create(ctx context.Context, export *models.DataExport) (*models.DataExport, error)
+	// GetByID returns the export without its archive
+	GetByID(ctx context.Context, id string) (*models.DataExport, error)
+	// GetPendingByUserID returns the export of the user still being built
+	GetPendingByUserID(ctx context.Context, userID uint64) (*models.DataExport, error)
+	GetArchive(ctx context.Context, id string) ([]byte, error)
+	// Complete stores the archive of a pending export and marks it ready
+	Complete(ctx context.Context, id string, archive []byte, completedAt time.Time) error
+	// Fail marks a pending export as failed
+	Fail(ctx context.Context, id string, completedAt time.Time) error
+	// FailStale marks the exports still pending that were created before
+	// createdBefore as failed and returns how many
+	FailStale(ctx context.Context, createdBefore time.Time, completedAt time.Time) (int64, error)
+	// DeleteExpired deletes the exports that expired before now and returns how many
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
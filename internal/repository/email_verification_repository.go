@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// EmailVerificationRepository defines the interface for email verification token operations
+type EmailVerificationRepository interface {
+	Create(ctx context.Context, token *models.EmailVerificationToken) (*models.EmailVerificationToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error)
+	// MarkUsed consumes an unused token. It returns false if the token was already used.
+	MarkUsed(ctx context.Context, id uint64) (bool, error)
+	// InvalidateAllForUser consumes every outstanding token of the user
+	InvalidateAllForUser(ctx context.Context, userID uint64) error
+}
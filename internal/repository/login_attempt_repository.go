@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// LoginAttemptRepository tracks failed logins per key (email or client IP)
+type LoginAttemptRepository interface {
+	// Get returns the attempts of key, or gorm.ErrRecordNotFound if there are none
+	Get(ctx context.Context, key string) (*models.LoginAttempt, error)
+	// RecordFailure counts a failed login at the given time and returns the
+	// updated attempts. Failures are forgotten once none happened for window.
+	RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*models.LoginAttempt, error)
+	// Lock refuses logins for key until the given time
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset forgets the failures of key
+	Reset(ctx context.Context, key string) error
+}
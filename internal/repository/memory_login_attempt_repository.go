@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// memorySweepInterval is how often stale attempts are dropped from memory
+const memorySweepInterval = time.Minute
+
+type memoryLoginAttemptRepository struct {
+	mu        sync.Mutex
+	attempts  map[string]*models.LoginAttempt
+	lastSweep time.Time
+	// maxAge is the longest window seen, after which idle attempts are dropped
+	maxAge time.Duration
+}
+
+// NewMemoryLoginAttemptRepository creates an in-memory implementation of
+// LoginAttemptRepository. Counts are per instance and lost on restart, so it
+// suits single-instance deployments and tests.
+func NewMemoryLoginAttemptRepository() LoginAttemptRepository {
+	return &memoryLoginAttemptRepository{
+		attempts:  make(map[string]*models.LoginAttempt),
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *memoryLoginAttemptRepository) Get(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+func (r *memoryLoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if window > r.maxAge {
+		r.maxAge = window
+	}
+	r.sweep(at)
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = &models.LoginAttempt{Key: key}
+		r.attempts[key] = attempt
+	}
+	if attempt.LastFailureAt.Before(at.Add(-window)) {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = at
+
+	copied := *attempt
+	return &copied, nil
+}
+
+func (r *memoryLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+func (r *memoryLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+// sweep drops attempts that are neither recent nor locked. It must be called
+// with the lock held.
+func (r *memoryLoginAttemptRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < memorySweepInterval {
+		return
+	}
+	r.lastSweep = now
+
+	for key, attempt := range r.attempts {
+		locked := attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil)
+		if !locked && now.Sub(attempt.LastFailureAt) > r.maxAge {
+			delete(r.attempts, key)
+		}
+	}
+}
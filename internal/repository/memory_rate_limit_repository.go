@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+type memoryRateLimitRepository struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+// NewMemoryRateLimitRepository creates an in-memory implementation of
+// RateLimitRepository. Every instance limits clients on its own.
+func NewMemoryRateLimitRepository() RateLimitRepository {
+	return &memoryRateLimitRepository{
+		buckets:   make(map[string]*memoryBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *memoryRateLimitRepository) Take(ctx context.Context, key string, limit models.RateLimit, now time.Time) (*models.RateLimitResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sweep(now)
+
+	capacity := float64(limit.Requests)
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: capacity, updatedAt: now}
+		r.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.updatedAt).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = math.Min(capacity, bucket.tokens+elapsed*limit.RefillRate())
+		bucket.updatedAt = now
+	}
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	result := models.NewRateLimitResult(limit, bucket.tokens, allowed)
+	bucket.fullAt = now.Add(result.Reset)
+	return result, nil
+}
+
+// sweep drops the buckets that are full again, which behave exactly like
+// missing ones. It must be called with the lock held.
+func (r *memoryRateLimitRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < memorySweepInterval {
+		return
+	}
+	r.lastSweep = now
+
+	for key, bucket := range r.buckets {
+		if now.After(bucket.fullAt) {
+			delete(r.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRateLimitRepository_Refill(t *testing.T) {
+	repo := NewMemoryRateLimitRepository()
+	limit := models.RateLimit{Requests: 3, Period: 3 * time.Second}
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		result, err := repo.Take(t.Context(), "client", limit, now)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	result, err := repo.Take(t.Context(), "client", limit, now)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Second, result.RetryAfter)
+
+	// One request is regained per second, and the allowance never exceeds the limit
+	result, err = repo.Take(t.Context(), "client", limit, now.Add(time.Second))
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	result, err = repo.Take(t.Context(), "client", limit, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// MFARepository defines the interface for two-factor authentication data access operations
+type MFARepository interface {
+	GetCredential(ctx context.Context, userID uint64) (*models.MFACredential, error)
+	// SaveCredential creates or replaces the credential of the user
+	SaveCredential(ctx context.Context, credential *models.MFACredential) error
+	// Confirm enables the credential, recording the step of the confirming code,
+	// and stores the recovery codes
+	Confirm(ctx context.Context, userID uint64, step int64, recoveryCodeHashes []string) error
+	// UseStep records an accepted TOTP code. It returns false if a code of the
+	// same or a later step was already used.
+	UseStep(ctx context.Context, userID uint64, step int64) (bool, error)
+	// RecordFailedAttempt counts a wrong code and locks the credential until
+	// lockedUntil once maxAttempts consecutive codes were wrong
+	RecordFailedAttempt(ctx context.Context, userID uint64, maxAttempts int, lockedUntil time.Time) error
+	ResetFailedAttempts(ctx context.Context, userID uint64) error
+	// UseRecoveryCode consumes an unused recovery code. It returns false if no such code exists.
+	UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error
+	// Delete removes the credential and recovery codes, disabling two-factor authentication
+	Delete(ctx context.Context, userID uint64) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// NotificationRepository defines the interface for in-app notification data access operations
+type NotificationRepository interface {
+	// List returns the newest notifications of the user, at most limit, only
+	// the unread ones when unreadOnly is set
+	List(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]models.Notification, error)
+	Create(ctx context.Context, notification *models.Notification) (*models.Notification, error)
+	MarkRead(ctx context.Context, userID uint, id uint, readAt time.Time) (*models.Notification, error)
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// PasswordResetRepository defines the interface for password reset token operations
+type PasswordResetRepository interface {
+	Create(ctx context.Context, token *models.PasswordResetToken) (*models.PasswordResetToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	// MarkUsed consumes an unused token. It returns false if the token was
+	// already used, so a token can never be redeemed twice.
+	MarkUsed(ctx context.Context, id uint64) (bool, error)
+	// InvalidateAllForUser consumes every outstanding token of the user
+	InvalidateAllForUser(ctx context.Context, userID uint64) error
+}
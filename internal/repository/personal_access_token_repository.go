@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// PersonalAccessTokenRepository defines the interface for personal access token data access operations
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *models.PersonalAccessToken) (*models.PersonalAccessToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error)
+	// ListActiveByUserID returns the tokens that are neither revoked nor expired, newest first
+	ListActiveByUserID(ctx context.Context, userID uint64) ([]models.PersonalAccessToken, error)
+	// Revoke revokes one of the user's tokens. It returns false if the user has no such active token.
+	Revoke(ctx context.Context, userID uint64, id uint64) (bool, error)
+	// TouchLastUsed records a use of the token. Uses less than a minute apart
+	// are not written, so busy tokens do not cause a write per request.
+	TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresAuditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresAuditEventRepository creates a new PostgreSQL implementation of AuditEventRepository
+func NewPostgresAuditEventRepository(db *gorm.DB) AuditEventRepository {
+	return &postgresAuditEventRepository{
+		db: db,
+	}
+}
+
+func (r *postgresAuditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *postgresAuditEventRepository) ListByUserID(ctx context.Context, userID uint64) ([]models.AuditEvent, error) {
+	var events []models.AuditEvent
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("occurred_at ASC, id ASC").Find(&events)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return events, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"todo-list-api/internal/models"
 
 	"gorm.io/gorm"
@@ -43,3 +44,67 @@ func (r *PostgresAuthRepository) GetUserByID(ctx context.Context, id uint) (*mod
 	}
 	return &user, nil
 }
+
+func (r *PostgresAuthRepository) UpdatePassword(ctx context.Context, id uint64, passwordHash string) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"password": passwordHash, "password_reset_required": false})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepository) MarkEmailVerified(ctx context.Context, id uint64, verifiedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"email_verified": true, "verified_at": verifiedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepository) UpdateProfile(ctx context.Context, user *models.User) error {
+	result := r.db.WithContext(ctx).Model(user).Select("first_name", "last_name", "preferences").Updates(user)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepository) UpdateEmail(ctx context.Context, id uint64, email string, verifiedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"email": email, "email_verified": true, "verified_at": verifiedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepository) DeleteUser(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", id).Delete(&models.Todo{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&models.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresChecklistRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresChecklistRepository creates a new PostgreSQL implementation of ChecklistRepository
+func NewPostgresChecklistRepository(db *gorm.DB) ChecklistRepository {
+	return &postgresChecklistRepository{
+		db: db,
+	}
+}
+
+func (r *postgresChecklistRepository) ListByTodoID(ctx context.Context, todoID uint) ([]models.ChecklistItem, error) {
+	items := []models.ChecklistItem{}
+	result := r.db.WithContext(ctx).Where("todo_id = ?", todoID).Order("position ASC, id ASC").Find(&items)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return items, nil
+}
+
+func (r *postgresChecklistRepository) GetByID(ctx context.Context, todoID uint, id uint) (*models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	result := r.db.WithContext(ctx).Where("todo_id = ?", todoID).First(&item, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &item, nil
+}
+
+func (r *postgresChecklistRepository) Create(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	result := r.db.WithContext(ctx).Create(item)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return item, nil
+}
+
+func (r *postgresChecklistRepository) Update(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	result := r.db.WithContext(ctx).Model(&models.ChecklistItem{}).
+		Where("id = ? AND todo_id = ?", item.ID, item.TodoID).
+		Select("Title", "Checked", "Position", "UpdatedAt").
+		Updates(item)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errors.New("checklist item not found")
+	}
+
+	return r.GetByID(ctx, item.TodoID, item.ID)
+}
+
+func (r *postgresChecklistRepository) Delete(ctx context.Context, todoID uint, id uint) error {
+	result := r.db.WithContext(ctx).Where("todo_id = ?", todoID).Delete(&models.ChecklistItem{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("checklist item not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresClientKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresClientKeyRepository creates a new PostgreSQL implementation of ClientKeyRepository
+func NewPostgresClientKeyRepository(db *gorm.DB) ClientKeyRepository {
+	return &postgresClientKeyRepository{
+		db: db,
+	}
+}
+
+func (r *postgresClientKeyRepository) Create(ctx context.Context, key *models.ClientKey) (*models.ClientKey, error) {
+	result := r.db.WithContext(ctx).Create(key)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return key, nil
+}
+
+func (r *postgresClientKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.ClientKey, error) {
+	var key models.ClientKey
+	result := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).First(&key)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &key, nil
+}
+
+func (r *postgresClientKeyRepository) List(ctx context.Context) ([]models.ClientKey, error) {
+	var keys []models.ClientKey
+	result := r.db.WithContext(ctx).
+		Where("revoked_at IS NULL").
+		Order("created_at DESC, id DESC").
+		Find(&keys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return keys, nil
+}
+
+func (r *postgresClientKeyRepository) Revoke(ctx context.Context, id uint64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.ClientKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresClientKeyRepository) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.ClientKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt.Add(-time.Minute)).
+		Update("last_used_at", usedAt).Error
+}
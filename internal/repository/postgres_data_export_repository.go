@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresDataExportRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresDataExportRepository creates a new PostgreSQL implementation of DataExportRepository
+func NewPostgresDataExportRepository(db *gorm.DB) DataExportRepository {
+	return &postgresDataExportRepository{
+		db: db,
+	}
+}
+
+func (r *postgresDataExportRepository) Create(ctx context.Context, export *models.DataExport) (*models.DataExport, error) {
+	result := r.db.WithContext(ctx).Create(export)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return export, nil
+}
+
+func (r *postgresDataExportRepository) GetByID(ctx context.Context, id string) (*models.DataExport, error) {
+	var export models.DataExport
+	result := r.db.WithContext(ctx).Omit("archive").Where("id = ?", id).First(&export)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &export, nil
+}
+
+func (r *postgresDataExportRepository) GetPendingByUserID(ctx context.Context, userID uint64) (*models.DataExport, error) {
+	var export models.DataExport
+	result := r.db.WithContext(ctx).Omit("archive").
+		Where("user_id = ? AND status = ?", userID, models.DataExportPending).
+		First(&export)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &export, nil
+}
+
+func (r *postgresDataExportRepository) GetArchive(ctx context.Context, id string) ([]byte, error) {
+	var export models.DataExport
+	result := r.db.WithContext(ctx).Select("archive").Where("id = ?", id).First(&export)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return export.Archive, nil
+}
+
+func (r *postgresDataExportRepository) Complete(ctx context.Context, id string, archive []byte, completedAt time.Time) error {
+	return r.finish(ctx, id, map[string]any{
+		"status":       models.DataExportReady,
+		"archive":      archive,
+		"completed_at": completedAt,
+	})
+}
+
+func (r *postgresDataExportRepository) Fail(ctx context.Context, id string, completedAt time.Time) error {
+	return r.finish(ctx, id, map[string]any{
+		"status":       models.DataExportFailed,
+		"completed_at": completedAt,
+	})
+}
+
+func (r *postgresDataExportRepository) FailStale(ctx context.Context, createdBefore time.Time, completedAt time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.DataExport{}).
+		Where("status = ? AND created_at < ?", models.DataExportPending, createdBefore).
+		Updates(map[string]any{
+			"status":       models.DataExportFailed,
+			"completed_at": completedAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// finish applies updates to a pending export
+func (r *postgresDataExportRepository) finish(ctx context.Context, id string, updates map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&models.DataExport{}).
+		Where("id = ? AND status = ?", id, models.DataExportPending).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *postgresDataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&models.DataExport{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresEmailVerificationRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresEmailVerificationRepository creates a new PostgreSQL implementation of EmailVerificationRepository
+func NewPostgresEmailVerificationRepository(db *gorm.DB) EmailVerificationRepository {
+	return &postgresEmailVerificationRepository{
+		db: db,
+	}
+}
+
+func (r *postgresEmailVerificationRepository) Create(ctx context.Context, token *models.EmailVerificationToken) (*models.EmailVerificationToken, error) {
+	result := r.db.WithContext(ctx).Create(token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return token, nil
+}
+
+func (r *postgresEmailVerificationRepository) GetByHash(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
+	var token models.EmailVerificationToken
+	result := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+func (r *postgresEmailVerificationRepository) MarkUsed(ctx context.Context, id uint64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresEmailVerificationRepository) InvalidateAllForUser(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Model(&models.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now().UTC()).Error
+}
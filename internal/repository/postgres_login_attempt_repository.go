@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresLoginAttemptRepository struct {
+	db        *gorm.DB
+	mu        sync.Mutex
+	lastSweep time.Time
+	// maxAge is the longest window seen, after which idle attempts are deleted
+	maxAge time.Duration
+}
+
+// NewPostgresLoginAttemptRepository creates a new PostgreSQL implementation of
+// LoginAttemptRepository, which shares the counts between all instances
+func NewPostgresLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &postgresLoginAttemptRepository{
+		db:        db,
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *postgresLoginAttemptRepository) Get(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	result := r.db.WithContext(ctx).Where("key = ?", key).First(&attempt)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attempt, nil
+}
+
+func (r *postgresLoginAttemptRepository) RecordFailure(ctx context.Context, key string, at time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	r.sweep(ctx, at, window)
+
+	// A single upsert, so concurrent failures on several instances are all counted
+	var attempt models.LoginAttempt
+	result := r.db.WithContext(ctx).Raw(`
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES (?, 1, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING *`, key, at, at.Add(-window)).Scan(&attempt)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &attempt, nil
+}
+
+func (r *postgresLoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.LoginAttempt{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+}
+
+func (r *postgresLoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Where("key = ?", key).Delete(&models.LoginAttempt{}).Error
+}
+
+// sweep deletes attempts that are neither recent nor locked, at most once per
+// memorySweepInterval per instance
+func (r *postgresLoginAttemptRepository) sweep(ctx context.Context, now time.Time, window time.Duration) {
+	r.mu.Lock()
+	if window > r.maxAge {
+		r.maxAge = window
+	}
+	if now.Sub(r.lastSweep) < memorySweepInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSweep = now
+	maxAge := r.maxAge
+	r.mu.Unlock()
+
+	err := r.db.WithContext(ctx).
+		Where("last_failure_at < ?", now.Add(-maxAge)).
+		Where("locked_until IS NULL OR locked_until < ?", now).
+		Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		log.Printf("Failed to delete stale login attempts: %v", err)
+	}
+}
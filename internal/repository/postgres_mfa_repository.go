@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresMFARepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresMFARepository creates a new PostgreSQL implementation of MFARepository
+func NewPostgresMFARepository(db *gorm.DB) MFARepository {
+	return &postgresMFARepository{
+		db: db,
+	}
+}
+
+func (r *postgresMFARepository) GetCredential(ctx context.Context, userID uint64) (*models.MFACredential, error) {
+	var credential models.MFACredential
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&credential)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &credential, nil
+}
+
+func (r *postgresMFARepository) SaveCredential(ctx context.Context, credential *models.MFACredential) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"secret", "last_used_step", "failed_attempts", "locked_until", "confirmed_at", "updated_at",
+		}),
+	}).Create(credential).Error
+}
+
+func (r *postgresMFARepository) Confirm(ctx context.Context, userID uint64, step int64, recoveryCodeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MFACredential{}).
+			Where("user_id = ? AND confirmed_at IS NULL", userID).
+			Updates(map[string]interface{}{"confirmed_at": time.Now().UTC(), "last_used_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+func (r *postgresMFARepository) UseStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.MFACredential{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresMFARepository) RecordFailedAttempt(ctx context.Context, userID uint64, maxAttempts int, lockedUntil time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.MFACredential{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{
+			"failed_attempts": gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN 0 ELSE failed_attempts + 1 END", maxAttempts),
+			"locked_until":    gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN ?::timestamptz ELSE locked_until END", maxAttempts, lockedUntil),
+		}).Error
+}
+
+func (r *postgresMFARepository) ResetFailedAttempts(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Model(&models.MFACredential{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]interface{}{"failed_attempts": 0, "locked_until": nil}).Error
+}
+
+func (r *postgresMFARepository) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+func (r *postgresMFARepository) Delete(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.MFACredential{}).Error
+	})
+}
+
+// replaceRecoveryCodes swaps all recovery codes of the user for new ones within tx
+func replaceRecoveryCodes(tx *gorm.DB, userID uint64, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	codes := make([]models.RecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = models.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresNotificationRepository creates a new PostgreSQL implementation of NotificationRepository
+func NewPostgresNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &postgresNotificationRepository{
+		db: db,
+	}
+}
+
+func (r *postgresNotificationRepository) List(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]models.Notification, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	notifications := []models.Notification{}
+	result := query.Order("created_at DESC, id DESC").Limit(limit).Find(&notifications)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return notifications, nil
+}
+
+func (r *postgresNotificationRepository) Create(ctx context.Context, notification *models.Notification) (*models.Notification, error) {
+	result := r.db.WithContext(ctx).Create(notification)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return notification, nil
+}
+
+func (r *postgresNotificationRepository) MarkRead(ctx context.Context, userID uint, id uint, readAt time.Time) (*models.Notification, error) {
+	// Notifications read earlier keep their first read time
+	result := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", readAt)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var notification models.Notification
+	result = r.db.WithContext(ctx).Where("user_id = ?", userID).First(&notification, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notification not found")
+		}
+		return nil, result.Error
+	}
+	return &notification, nil
+}
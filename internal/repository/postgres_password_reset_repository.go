@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresPasswordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresPasswordResetRepository creates a new PostgreSQL implementation of PasswordResetRepository
+func NewPostgresPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &postgresPasswordResetRepository{
+		db: db,
+	}
+}
+
+func (r *postgresPasswordResetRepository) Create(ctx context.Context, token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	result := r.db.WithContext(ctx).Create(token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return token, nil
+}
+
+func (r *postgresPasswordResetRepository) GetByHash(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	result := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+func (r *postgresPasswordResetRepository) MarkUsed(ctx context.Context, id uint64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresPasswordResetRepository) InvalidateAllForUser(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now().UTC()).Error
+}
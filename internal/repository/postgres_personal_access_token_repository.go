@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresPersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresPersonalAccessTokenRepository creates a new PostgreSQL implementation of PersonalAccessTokenRepository
+func NewPostgresPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &postgresPersonalAccessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *postgresPersonalAccessTokenRepository) Create(ctx context.Context, token *models.PersonalAccessToken) (*models.PersonalAccessToken, error) {
+	result := r.db.WithContext(ctx).Create(token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return token, nil
+}
+
+func (r *postgresPersonalAccessTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, error) {
+	var token models.PersonalAccessToken
+	result := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+func (r *postgresPersonalAccessTokenRepository) ListActiveByUserID(ctx context.Context, userID uint64) ([]models.PersonalAccessToken, error) {
+	var tokens []models.PersonalAccessToken
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now().UTC()).
+		Order("created_at DESC, id DESC").
+		Find(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tokens, nil
+}
+
+func (r *postgresPersonalAccessTokenRepository) Revoke(ctx context.Context, userID uint64, id uint64) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresPersonalAccessTokenRepository) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.PersonalAccessToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt.Add(-time.Minute)).
+		Update("last_used_at", usedAt).Error
+}
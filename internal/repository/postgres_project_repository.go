@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresProjectRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresProjectRepository creates a new PostgreSQL implementation of ProjectRepository
+func NewPostgresProjectRepository(db *gorm.DB) ProjectRepository {
+	return &postgresProjectRepository{
+		db: db,
+	}
+}
+
+func (r *postgresProjectRepository) List(ctx context.Context, userID uint, includeArchived bool) ([]models.Project, error) {
+	projects := []models.Project{}
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	result := query.Order("position ASC, id ASC").Find(&projects)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return projects, nil
+}
+
+func (r *postgresProjectRepository) GetByID(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	var project models.Project
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&project, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &project, nil
+}
+
+func (r *postgresProjectRepository) Create(ctx context.Context, project *models.Project) (*models.Project, error) {
+	result := r.db.WithContext(ctx).Create(project)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return project, nil
+}
+
+func (r *postgresProjectRepository) Update(ctx context.Context, project *models.Project) (*models.Project, error) {
+	result := r.db.WithContext(ctx).Model(&models.Project{}).
+		Where("id = ? AND user_id = ?", project.ID, project.UserID).
+		Select("Name", "Color", "Icon", "Archived", "Position", "UpdatedAt").
+		Updates(project)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errors.New("project not found")
+	}
+
+	return r.GetByID(ctx, uint(project.UserID), project.ID)
+}
+
+func (r *postgresProjectRepository) Delete(ctx context.Context, userID uint, id uint) error {
+	// The todos.project_id foreign key moves the todos to the Inbox
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.Project{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("project not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// idleBucketTimeout is how long unused buckets are kept in PostgreSQL. It
+// exceeds the period of every limit, so deleted buckets were full anyway.
+const idleBucketTimeout = 24 * time.Hour
+
+// refilledTokens is the SQL for the tokens of bucket b once refilled up to @now
+const refilledTokens = `LEAST(CAST(@capacity AS double precision),
+	b.tokens + GREATEST(0, EXTRACT(EPOCH FROM (CAST(@now AS timestamptz) - b.updated_at))) * CAST(@rate AS double precision))`
+
+type postgresRateLimitRepository struct {
+	db        *gorm.DB
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewPostgresRateLimitRepository creates a new PostgreSQL implementation of
+// RateLimitRepository, which shares the buckets between all instances
+func NewPostgresRateLimitRepository(db *gorm.DB) RateLimitRepository {
+	return &postgresRateLimitRepository{
+		db:        db,
+		lastSweep: time.Now(),
+	}
+}
+
+func (r *postgresRateLimitRepository) Take(ctx context.Context, key string, limit models.RateLimit, now time.Time) (*models.RateLimitResult, error) {
+	r.sweep(ctx, now)
+
+	// Refilling and taking happen in a single upsert, so concurrent requests
+	// on several instances never take the same token. The SET expressions all
+	// see the bucket as it was before the update.
+	var bucket models.RateLimitBucket
+	result := r.db.WithContext(ctx).Raw(fmt.Sprintf(`
+		INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+		VALUES (@key, CAST(@capacity AS double precision) - 1, true, @now)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = CASE WHEN %[1]s >= 1 THEN %[1]s - 1 ELSE %[1]s END,
+			allowed = %[1]s >= 1,
+			updated_at = GREATEST(b.updated_at, EXCLUDED.updated_at)
+		RETURNING *`, refilledTokens),
+		map[string]interface{}{
+			"key":      key,
+			"capacity": float64(limit.Requests),
+			"rate":     limit.RefillRate(),
+			"now":      now,
+		}).Scan(&bucket)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return models.NewRateLimitResult(limit, bucket.Tokens, bucket.Allowed), nil
+}
+
+// sweep deletes idle buckets, at most once per memorySweepInterval per instance
+func (r *postgresRateLimitRepository) sweep(ctx context.Context, now time.Time) {
+	r.mu.Lock()
+	if now.Sub(r.lastSweep) < memorySweepInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.lastSweep = now
+	r.mu.Unlock()
+
+	err := r.db.WithContext(ctx).
+		Where("updated_at < ?", now.Add(-idleBucketTimeout)).
+		Delete(&models.RateLimitBucket{}).Error
+	if err != nil {
+		log.Printf("Failed to delete idle rate limit buckets: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresRefreshTokenRepository creates a new PostgreSQL implementation of RefreshTokenRepository
+func NewPostgresRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &postgresRefreshTokenRepository{
+		db: db,
+	}
+}
+
+func (r *postgresRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error) {
+	result := r.db.WithContext(ctx).Create(token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return token, nil
+}
+
+func (r *postgresRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	result := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &token, nil
+}
+
+func (r *postgresRefreshTokenRepository) MarkRotated(ctx context.Context, id uint64) (bool, error) {
+	// The conditional update makes rotation atomic: of two concurrent refreshes
+	// with the same token only one can succeed.
+	result := r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Update("rotated_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now().UTC()).Error
+}
+
+func (r *postgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now().UTC()).Error
+}
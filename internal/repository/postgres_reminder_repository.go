@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresReminderRepository creates a new PostgreSQL implementation of ReminderRepository
+func NewPostgresReminderRepository(db *gorm.DB) ReminderRepository {
+	return &postgresReminderRepository{
+		db: db,
+	}
+}
+
+func (r *postgresReminderRepository) ListByTodoID(ctx context.Context, todoID uint) ([]models.Reminder, error) {
+	reminders := []models.Reminder{}
+	result := r.db.WithContext(ctx).Where("todo_id = ?", todoID).Order("id ASC").Find(&reminders)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return reminders, nil
+}
+
+func (r *postgresReminderRepository) Create(ctx context.Context, reminder *models.Reminder) (*models.Reminder, error) {
+	result := r.db.WithContext(ctx).Create(reminder)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return reminder, nil
+}
+
+func (r *postgresReminderRepository) Delete(ctx context.Context, todoID uint, id uint) error {
+	result := r.db.WithContext(ctx).Where("todo_id = ?", todoID).Delete(&models.Reminder{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("reminder not found")
+	}
+
+	return nil
+}
+
+// claimDueRemindersSQL selects the reminders due at a time. A relative
+// reminder is due offset_minutes before the current due date of its todo.
+// Reminders of deleted accounts do not fire. Rows locked by another
+// scheduler's claim are skipped rather than waited for.
+const claimDueRemindersSQL = `
+SELECT reminders.id FROM reminders
+JOIN todos ON todos.id = reminders.todo_id
+JOIN users ON users.id = todos.user_id
+WHERE reminders.status = ?
+  AND users.deleted_at IS NULL
+  AND (reminders.next_attempt_at IS NULL OR reminders.next_attempt_at <= ?)
+  AND todos.deleted_at IS NULL AND todos.completed = false AND todos.cancelled_at IS NULL
+  AND COALESCE(reminders.remind_at, todos.due_date - reminders.offset_minutes * interval '1 minute') <= ?
+ORDER BY reminders.id
+LIMIT ?
+FOR UPDATE OF reminders SKIP LOCKED`
+
+// ClaimDue loads the claimed reminders with their todo in the claiming
+// transaction. A todo deleted once it is over leaves Todo nil.
+func (r *postgresReminderRepository) ClaimDue(ctx context.Context, now time.Time, until time.Time, limit int) ([]models.Reminder, error) {
+	reminders := []models.Reminder{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		if err := tx.Raw(claimDueRemindersSQL, models.ReminderPending, now, now, limit).Scan(&ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		err := tx.Model(&models.Reminder{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"next_attempt_at": until,
+			"attempts":        gorm.Expr("attempts + 1"),
+			"updated_at":      now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Preload("Todo.User").Where("id IN ?", ids).Order("id ASC").Find(&reminders).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *postgresReminderRepository) Release(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&models.Reminder{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+		"next_attempt_at": nil,
+		"attempts":        gorm.Expr("attempts - 1"),
+	}).Error
+}
+
+func (r *postgresReminderRepository) MarkSent(ctx context.Context, id uint, sentAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Reminder{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          models.ReminderSent,
+		"sent_at":         sentAt,
+		"next_attempt_at": nil,
+		"last_error":      "",
+		"updated_at":      sentAt,
+	}).Error
+}
+
+func (r *postgresReminderRepository) MarkFailed(ctx context.Context, id uint, lastError string, retryAt *time.Time) error {
+	status := models.ReminderPending
+	if retryAt == nil {
+		status = models.ReminderFailed
+	}
+	return r.db.WithContext(ctx).Model(&models.Reminder{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"last_error":      lastError,
+		"next_attempt_at": retryAt,
+		"updated_at":      time.Now().UTC(),
+	}).Error
+}
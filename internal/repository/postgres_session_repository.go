@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresSessionRepository creates a new PostgreSQL implementation of SessionRepository
+func NewPostgresSessionRepository(db *gorm.DB) SessionRepository {
+	return &postgresSessionRepository{
+		db: db,
+	}
+}
+
+func (r *postgresSessionRepository) Create(ctx context.Context, session *models.Session) (*models.Session, error) {
+	result := r.db.WithContext(ctx).Create(session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return session, nil
+}
+
+func (r *postgresSessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	var session models.Session
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+func (r *postgresSessionRepository) ListActiveByUserID(ctx context.Context, userID uint64) ([]models.Session, error) {
+	var sessions []models.Session
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now().UTC()).
+		Order("last_used_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+func (r *postgresSessionRepository) ListByUserID(ctx context.Context, userID uint64) ([]models.Session, error) {
+	var sessions []models.Session
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+func (r *postgresSessionRepository) Touch(ctx context.Context, id string, client *models.ClientInfo, expiresAt time.Time) error {
+	updates := map[string]any{
+		"last_used_at": time.Now().UTC(),
+		"expires_at":   expiresAt,
+	}
+	if client != nil {
+		updates["user_agent"] = client.UserAgent
+		updates["ip_address"] = client.IPAddress
+	}
+
+	return r.db.WithContext(ctx).Model(&models.Session{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *postgresSessionRepository) Revoke(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now().UTC()).Error
+}
+
+func (r *postgresSessionRepository) RevokeAllForUser(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now().UTC()).Error
+}
+
+func (r *postgresSessionRepository) IsRevoked(ctx context.Context, id string) (bool, error) {
+	var session models.Session
+	result := r.db.WithContext(ctx).Select("revoked_at").Where("id = ?", id).First(&session)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, result.Error
+	}
+	return session.RevokedAt != nil, nil
+}
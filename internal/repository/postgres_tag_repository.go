@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresTagRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresTagRepository creates a new PostgreSQL implementation of TagRepository
+func NewPostgresTagRepository(db *gorm.DB) TagRepository {
+	return &postgresTagRepository{
+		db: db,
+	}
+}
+
+func (r *postgresTagRepository) List(ctx context.Context, userID uint) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("lower(name) ASC, id ASC").Find(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tags, nil
+}
+
+func (r *postgresTagRepository) GetByID(ctx context.Context, userID uint, id uint) (*models.Tag, error) {
+	var tag models.Tag
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&tag, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &tag, nil
+}
+
+func (r *postgresTagRepository) GetByName(ctx context.Context, userID uint, name string) (*models.Tag, error) {
+	var tag models.Tag
+	result := r.db.WithContext(ctx).Where("user_id = ? AND lower(name) = ?", userID, strings.ToLower(name)).First(&tag)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &tag, nil
+}
+
+func (r *postgresTagRepository) FindByIDs(ctx context.Context, userID uint, ids []uint) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	result := r.db.WithContext(ctx).Where("user_id = ? AND id IN ?", userID, ids).Find(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tags, nil
+}
+
+func (r *postgresTagRepository) FindByNames(ctx context.Context, userID uint, names []string) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	lowered := make([]string, 0, len(names))
+	for _, name := range names {
+		lowered = append(lowered, strings.ToLower(name))
+	}
+
+	result := r.db.WithContext(ctx).Where("user_id = ? AND lower(name) IN ?", userID, lowered).Find(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tags, nil
+}
+
+func (r *postgresTagRepository) EnsureNames(ctx context.Context, userID uint, names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	now := time.Now().UTC()
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, models.Tag{UserID: uint64(userID), Name: name, CreatedAt: now, UpdatedAt: now})
+	}
+
+	// Names that exist already, in any case, keep their tag
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&tags)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return r.FindByNames(ctx, userID, names)
+}
+
+func (r *postgresTagRepository) Create(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	result := r.db.WithContext(ctx).Create(tag)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return tag, nil
+}
+
+func (r *postgresTagRepository) Update(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	result := r.db.WithContext(ctx).Model(&models.Tag{}).
+		Where("id = ? AND user_id = ?", tag.ID, tag.UserID).
+		Select("Name", "Color", "UpdatedAt").
+		Updates(tag)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errors.New("tag not found")
+	}
+
+	return r.GetByID(ctx, uint(tag.UserID), tag.ID)
+}
+
+func (r *postgresTagRepository) Delete(ctx context.Context, userID uint, id uint) error {
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.Tag{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("tag not found")
+	}
+
+	return nil
+}
+
+func (r *postgresTagRepository) Merge(ctx context.Context, userID uint, sourceID uint, targetID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Tag{}).Where("user_id = ? AND id IN ?", userID, []uint{sourceID, targetID}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != 2 {
+			return errors.New("tag not found")
+		}
+
+		// Todos that have both tags keep a single link
+		err := tx.Exec(`INSERT INTO todo_tags (todo_id, tag_id)
+			SELECT todo_id, ? FROM todo_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, targetID, sourceID).Error
+		if err != nil {
+			return err
+		}
+
+		// Deleting the tag deletes its remaining links
+		return tx.Where("user_id = ?", userID).Delete(&models.Tag{}, sourceID).Error
+	})
+}
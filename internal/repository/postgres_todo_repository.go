@@ -3,7 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
 	"todo-list-api/internal/models"
+	"todo-list-api/internal/utils"
+	"unicode"
 
 	"gorm.io/gorm"
 )
@@ -20,50 +27,286 @@ func NewPostgresTodosRepository(db *gorm.DB) TodoRepository {
 }
 
 func (r *postgresTodosRepository) Create(ctx context.Context, todo *models.Todo) (*models.Todo, error) {
-	result := r.db.WithContext(ctx).Create(todo)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(todo).Error; err != nil {
+			return err
+		}
+		return setTodoTags(tx, todo.ID, todo.Tags)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadTodoDetails(ctx, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+func (r *postgresTodosRepository) GetByID(ctx context.Context, userID uint, id uint) (*models.Todo, error) {
+	var todo models.Todo
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&todo, id)
 	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Return nil for not found
+		}
 		return nil, result.Error
 	}
-	return todo, nil
+	if err := r.loadTodoDetails(ctx, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
 }
 
-func (r *postgresTodosRepository) GetAll(ctx context.Context) ([]models.Todo, error) {
+func (r *postgresTodosRepository) Update(ctx context.Context, userID uint, id uint, fromStatus string, todo *models.Todo) (*models.Todo, error) {
+	found := true
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Todo{}).
+			Where("id = ? AND user_id = ? AND status = ?", id, userID, fromStatus).
+			Select(todoEditableFields).
+			Updates(todo)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&models.Todo{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return errors.New("todo status changed")
+			}
+			found = false
+			return nil
+		}
+
+		if err := setTodoTags(tx, id, todo.Tags); err != nil {
+			return err
+		}
+
+		// The next occurrence of a completed recurring todo is created with it
+		if next := todo.NextOccurrence; next != nil {
+			if err := tx.Create(next).Error; err != nil {
+				return err
+			}
+			if err := setTodoTags(tx, next.ID, next.Tags); err != nil {
+				return err
+			}
+			return copyRelativeReminders(tx, id, next)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil // Todo not found
+	}
+
+	// Return the updated todo
+	updated, err := r.GetByID(ctx, userID, id)
+	if err != nil || updated == nil {
+		return updated, err
+	}
+	if next := todo.NextOccurrence; next != nil {
+		if err := r.loadTodoDetails(ctx, next); err != nil {
+			return nil, err
+		}
+		updated.NextOccurrence = next
+	}
+	return updated, nil
+}
+
+func (r *postgresTodosRepository) Delete(ctx context.Context, userID uint, id uint, subtasks string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var todo models.Todo
+		if err := tx.Where("user_id = ?", userID).First(&todo, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("todo not found")
+			}
+			return err
+		}
+
+		if subtasks == models.SubtasksPromote {
+			if err := promoteSubtasks(tx, &todo); err != nil {
+				return err
+			}
+		}
+
+		// The whole subtree is deleted in one statement, so it shares its
+		// deleted_at and is restored together
+		return tx.Where("user_id = ? AND id IN (?)", userID, gorm.Expr(todoSubtreeSQL, id, userID)).
+			Delete(&models.Todo{}).Error
+	})
+}
+
+func (r *postgresTodosRepository) ListAllForExport(ctx context.Context, userID uint) ([]models.Todo, error) {
 	var todos []models.Todo
-	result := r.db.WithContext(ctx).Order("created_at DESC").Find(&todos)
+	result := r.db.WithContext(ctx).Unscoped().Where("user_id = ?", userID).Order("id ASC").Find(&todos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
+	if err := r.loadTodoDetails(ctx, todoPointers(todos)...); err != nil {
+		return nil, err
+	}
 	return todos, nil
 }
 
-func (r *postgresTodosRepository) GetByID(ctx context.Context, id uint) (*models.Todo, error) {
-	var todo models.Todo
-	result := r.db.WithContext(ctx).First(&todo, id)
+func (r *postgresTodosRepository) ListTrash(ctx context.Context, userID uint, limit int, offset int) (*models.TrashPage, error) {
+	base := r.db.WithContext(ctx).Unscoped().Model(&models.Todo{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var todos []models.Todo
+	result := base.Session(&gorm.Session{}).
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&todos)
 	if result.Error != nil {
-		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, nil // Return nil for not found
-		}
 		return nil, result.Error
 	}
-	return &todo, nil
+
+	page := &models.TrashPage{
+		Items:      make([]models.TrashedTodo, 0, len(todos)),
+		TotalCount: total,
+	}
+	for _, todo := range todos {
+		page.Items = append(page.Items, models.TrashedTodo{Todo: todo, DeletedAt: todo.DeletedAt.Time})
+	}
+
+	details := make([]*models.Todo, 0, len(page.Items))
+	for i := range page.Items {
+		details = append(details, &page.Items[i].Todo)
+	}
+	if err := r.loadTodoDetails(ctx, details...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (r *postgresTodosRepository) Restore(ctx context.Context, userID uint, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var todo models.Todo
+		err := tx.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).First(&todo, id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("todo not found")
+			}
+			return err
+		}
+
+		// Subtasks deleted together with the todo come back with it
+		err = tx.Unscoped().Model(&models.Todo{}).
+			Where("id IN (?) AND deleted_at = ?", gorm.Expr(todoSubtreeSQL, id, userID), todo.DeletedAt.Time).
+			Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"updated_at": time.Now().UTC(),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// A subtask whose parent is still deleted becomes a top-level todo
+		if todo.ParentID == nil {
+			return nil
+		}
+		return tx.Model(&models.Todo{}).
+			Where("id = ?", id).
+			Where("NOT EXISTS (SELECT 1 FROM todos AS parent WHERE parent.id = todos.parent_id AND parent.deleted_at IS NULL)").
+			Update("parent_id", nil).Error
+	})
+}
+
+func (r *postgresTodosRepository) DeletePermanently(ctx context.Context, userID uint, id uint, subtasks string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var todo models.Todo
+		if err := tx.Unscoped().Where("user_id = ?", userID).First(&todo, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("todo not found")
+			}
+			return err
+		}
+
+		if subtasks == models.SubtasksPromote {
+			if err := promoteSubtasks(tx, &todo); err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().Where("user_id = ? AND id IN (?)", userID, gorm.Expr(todoSubtreeSQL, id, userID)).
+			Delete(&models.Todo{}).Error
+	})
 }
 
-func (r *postgresTodosRepository) Update(ctx context.Context, id uint, todo *models.Todo) (*models.Todo, error) {
-	result := r.db.WithContext(ctx).Model(&models.Todo{}).Where("id = ?", id).Updates(todo)
+func (r *postgresTodosRepository) ListSubtasks(ctx context.Context, userID uint, id uint) ([]models.Todo, error) {
+	todos := []models.Todo{}
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND parent_id = ?", userID, id).
+		Order("created_at ASC, id ASC").
+		Find(&todos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-
-	if result.RowsAffected == 0 {
-		return nil, nil // Todo not found
+	if err := r.loadTodoDetails(ctx, todoPointers(todos)...); err != nil {
+		return nil, err
 	}
+	return todos, nil
+}
 
-	// Return the updated todo
-	return r.GetByID(ctx, id)
+func (r *postgresTodosRepository) SetParent(ctx context.Context, userID uint, id uint, parentID *uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Moves are serialized per user, so that two concurrent moves cannot
+		// form a cycle together
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", todoHierarchyLock, userID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.Todo{}).Where("id = ? AND user_id = ?", id, userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("todo not found")
+		}
+
+		if parentID != nil {
+			if err := tx.Model(&models.Todo{}).Where("id = ? AND user_id = ?", *parentID, userID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return errors.New("todo not found")
+			}
+
+			// The new parent must not be the todo itself or one of its subtasks
+			err := tx.Model(&models.Todo{}).
+				Where("id = ? AND id IN (?)", *parentID, gorm.Expr(todoSubtreeSQL, id, userID)).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return errors.New("subtask would create a cycle")
+			}
+		}
+
+		return tx.Model(&models.Todo{}).Where("id = ? AND user_id = ?", id, userID).Update("parent_id", parentID).Error
+	})
 }
 
-func (r *postgresTodosRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&models.Todo{}, id)
+func (r *postgresTodosRepository) Snooze(ctx context.Context, userID uint, id uint, until *time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.Todo{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(map[string]interface{}{
+			"snoozed_until": until,
+			"updated_at":    time.Now().UTC(),
+		})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -75,11 +318,466 @@ func (r *postgresTodosRepository) Delete(ctx context.Context, id uint) error {
 	return nil
 }
 
-func (r *postgresTodosRepository) GetByUserID(ctx context.Context, userID uint) ([]models.Todo, error) {
+func (r *postgresTodosRepository) CompleteSubtasks(ctx context.Context, userID uint, id uint, status string) ([]uint, error) {
+	now := time.Now().UTC()
+	recurringIDs := []uint{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		open := tx.Model(&models.Todo{}).
+			Where("user_id = ? AND id <> ? AND id IN (?)", userID, id, gorm.Expr(todoSubtreeSQL, id, userID)).
+			Where("completed = ? AND cancelled_at IS NULL", false)
+
+		err := open.Session(&gorm.Session{}).
+			Where("COALESCE(recurrence, '') <> ''").
+			Order("id ASC").
+			Pluck("id", &recurringIDs).Error
+		if err != nil {
+			return err
+		}
+
+		return open.Session(&gorm.Session{}).
+			Where("COALESCE(recurrence, '') = ''").
+			Updates(map[string]interface{}{
+				"completed":    true,
+				"status":       status,
+				"completed_at": now,
+				"updated_at":   now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recurringIDs, nil
+}
+
+func (r *postgresTodosRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Delete(&models.Todo{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *postgresTodosRepository) List(ctx context.Context, userID uint, query models.TodoQuery) (*models.TodoPage, error) {
+	column, ok := todoSortColumns[query.Sort]
+	if !ok {
+		return nil, errors.New("invalid sort field")
+	}
+
+	direction := "DESC"
+	comparator := "<"
+	if query.Order == models.SortAsc {
+		direction = "ASC"
+		comparator = ">"
+	}
+
+	base := r.db.WithContext(ctx).Model(&models.Todo{}).
+		Where("user_id = ?", userID).
+		Scopes(applyTodoFilter(query.Filter))
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	tx := base.Session(&gorm.Session{})
+	if query.Cursor != nil {
+		value, err := parseTodoSortValue(query.Sort, query.Cursor.Value)
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		tx = tx.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparator), value, query.Cursor.ID)
+	}
+
+	// Fetch one extra row to know whether another page follows
 	var todos []models.Todo
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&todos)
+	result := tx.Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(query.Limit + 1).
+		Find(&todos)
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	return todos, nil
+
+	page := &models.TodoPage{Items: todos, TotalCount: total}
+	if len(todos) > query.Limit {
+		page.Items = todos[:query.Limit]
+		last := page.Items[len(page.Items)-1]
+		cursor, err := utils.EncodeCursor(models.TodoCursor{
+			Sort:  query.Sort,
+			Order: query.Order,
+			Value: todoSortValue(query.Sort, &last),
+			ID:    last.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		page.NextCursor = cursor
+	}
+
+	if err := r.loadTodoDetails(ctx, todoPointers(page.Items)...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (r *postgresTodosRepository) Search(ctx context.Context, userID uint, query models.TodoSearchQuery) (*models.TodoSearchPage, error) {
+	tsQuery := buildPrefixTSQuery(query.Text)
+	if tsQuery == "" {
+		return &models.TodoSearchPage{Items: []models.TodoSearchResult{}}, nil
+	}
+
+	base := r.db.WithContext(ctx).Model(&models.Todo{}).
+		Where("user_id = ?", userID).
+		Where("search_vector @@ to_tsquery('simple', ?)", tsQuery).
+		Scopes(applyTodoFilter(query.Filter))
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []todoSearchRow
+	result := base.Session(&gorm.Session{}).
+		Select(`todos.*,
+			ts_rank(search_vector, to_tsquery('simple', ?)) AS rank,
+			ts_headline('simple', translate(title, ?, ''), to_tsquery('simple', ?), ?) AS title_highlight,
+			ts_headline('simple', translate(coalesce(description, ''), ?, ''), to_tsquery('simple', ?), ?) AS description_highlight`,
+			tsQuery,
+			highlightStart+highlightStop, tsQuery, titleHeadlineOptions,
+			highlightStart+highlightStop, tsQuery, descriptionHeadlineOptions).
+		Order("rank DESC, id DESC").
+		Limit(query.Limit).
+		Offset(query.Offset).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	page := &models.TodoSearchPage{
+		Items:      make([]models.TodoSearchResult, 0, len(rows)),
+		TotalCount: total,
+	}
+	for _, row := range rows {
+		page.Items = append(page.Items, models.TodoSearchResult{
+			Todo:                 row.Todo,
+			Rank:                 row.Rank,
+			TitleHighlight:       highlightHTML(row.TitleHighlight),
+			DescriptionHighlight: highlightHTML(row.DescriptionHighlight),
+		})
+	}
+
+	details := make([]*models.Todo, 0, len(page.Items))
+	for i := range page.Items {
+		details = append(details, &page.Items[i].Todo)
+	}
+	if err := r.loadTodoDetails(ctx, details...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// todoSubtreeSQL selects the ids of a todo and all of its subtasks, at any
+// depth and whether deleted or not. UNION skips rows already visited, so the
+// recursion ends even if the data contains a cycle.
+const todoSubtreeSQL = `WITH RECURSIVE subtree(id) AS (
+		SELECT id FROM todos WHERE id = ? AND user_id = ?
+		UNION
+		SELECT todos.id FROM todos JOIN subtree ON todos.parent_id = subtree.id
+	)
+	SELECT id FROM subtree`
+
+// todoHierarchyLock is the advisory lock class taken, per user, while a todo is moved
+const todoHierarchyLock = 1001
+
+// promoteSubtasks moves the subtasks of todo, including deleted ones, up to its parent
+func promoteSubtasks(tx *gorm.DB, todo *models.Todo) error {
+	return tx.Unscoped().Model(&models.Todo{}).
+		Where("parent_id = ?", todo.ID).
+		Update("parent_id", todo.ParentID).Error
+}
+
+// subtaskCount is the number of live subtasks of a todo
+type subtaskCount struct {
+	ParentID  uint
+	Total     int
+	Completed int
+}
+
+// setTodoTags replaces the tags of a todo. Only the IDs of tags are used.
+func setTodoTags(tx *gorm.DB, todoID uint, tags []models.Tag) error {
+	if err := tx.Where("todo_id = ?", todoID).Delete(&models.TodoTag{}).Error; err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	links := make([]models.TodoTag, 0, len(tags))
+	for _, tag := range tags {
+		links = append(links, models.TodoTag{TodoID: todoID, TagID: tag.ID})
+	}
+	return tx.Create(&links).Error
+}
+
+// todoTagRow is a tag together with one of the todos it is linked to
+type todoTagRow struct {
+	models.Tag
+	TodoID uint
+}
+
+// loadTodoDetails fills in the tags, checklist and progress of the todos
+func (r *postgresTodosRepository) loadTodoDetails(ctx context.Context, todos ...*models.Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(todos))
+	byID := make(map[uint]*models.Todo, len(todos))
+	for _, todo := range todos {
+		ids = append(ids, todo.ID)
+		byID[todo.ID] = todo
+		todo.Tags = []models.Tag{}
+		todo.Checklist = []models.ChecklistItem{}
+	}
+
+	var tags []todoTagRow
+	result := r.db.WithContext(ctx).Model(&models.Tag{}).
+		Select("tags.*, todo_tags.todo_id").
+		Joins("JOIN todo_tags ON todo_tags.tag_id = tags.id").
+		Where("todo_tags.todo_id IN ?", ids).
+		Order("lower(tags.name) ASC, tags.id ASC").
+		Scan(&tags)
+	if result.Error != nil {
+		return result.Error
+	}
+	for _, row := range tags {
+		todo := byID[row.TodoID]
+		todo.Tags = append(todo.Tags, row.Tag)
+	}
+
+	var items []models.ChecklistItem
+	result = r.db.WithContext(ctx).Where("todo_id IN ?", ids).Order("position ASC, id ASC").Find(&items)
+	if result.Error != nil {
+		return result.Error
+	}
+	for _, item := range items {
+		todo := byID[item.TodoID]
+		todo.Checklist = append(todo.Checklist, item)
+	}
+
+	var counts []subtaskCount
+	result = r.db.WithContext(ctx).Model(&models.Todo{}).
+		Select("parent_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE completed) AS completed").
+		Where("parent_id IN ?", ids).
+		Group("parent_id").
+		Scan(&counts)
+	if result.Error != nil {
+		return result.Error
+	}
+	subtasks := make(map[uint]subtaskCount, len(counts))
+	for _, count := range counts {
+		subtasks[count.ParentID] = count
+	}
+
+	for _, todo := range todos {
+		todo.Progress = todoProgress(todo, subtasks[todo.ID])
+	}
+	return nil
+}
+
+// todoProgress counts the done steps of a todo with its checklist loaded
+func todoProgress(todo *models.Todo, subtasks subtaskCount) models.TodoProgress {
+	progress := models.TodoProgress{
+		Subtasks:          subtasks.Total,
+		CompletedSubtasks: subtasks.Completed,
+		ChecklistItems:    len(todo.Checklist),
+	}
+	for _, item := range todo.Checklist {
+		if item.Checked {
+			progress.CheckedItems++
+		}
+	}
+
+	steps := progress.Subtasks + progress.ChecklistItems
+	switch {
+	case steps > 0:
+		progress.Percent = (progress.CompletedSubtasks + progress.CheckedItems) * 100 / steps
+	case todo.Completed:
+		progress.Percent = 100
+	}
+	return progress
+}
+
+// todoPointers returns pointers to the elements of todos
+func todoPointers(todos []models.Todo) []*models.Todo {
+	pointers := make([]*models.Todo, 0, len(todos))
+	for i := range todos {
+		pointers = append(pointers, &todos[i])
+	}
+	return pointers
+}
+
+// todoSearchRow is the raw result of a full-text search query
+type todoSearchRow struct {
+	models.Todo
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+// Search snippets are built with ts_headline around matches marked by
+// private-use characters, which are removed from the text beforehand. The
+// snippet is then HTML-escaped and only the marks become <mark></mark>, so
+// that the text of todos cannot inject markup.
+const (
+	highlightStart             = "\uE000"
+	highlightStop              = "\uE001"
+	titleHeadlineOptions       = "StartSel=\uE000, StopSel=\uE001, HighlightAll=true"
+	descriptionHeadlineOptions = "StartSel=\uE000, StopSel=\uE001, MaxWords=35, MinWords=15, MaxFragments=2"
+)
+
+// highlightHTML turns a ts_headline snippet into HTML
+func highlightHTML(snippet string) string {
+	snippet = html.EscapeString(snippet)
+	snippet = strings.ReplaceAll(snippet, highlightStart, "<mark>")
+	return strings.ReplaceAll(snippet, highlightStop, "</mark>")
+}
+
+// buildPrefixTSQuery turns free text into a tsquery that requires every word
+// to match as a prefix, e.g. "buy gro" becomes "buy:* & gro:*". Only letters
+// and digits are kept so the result is always valid tsquery syntax.
+func buildPrefixTSQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, strings.ToLower(word)+":*")
+	}
+	return strings.Join(terms, " & ")
+}
+
+// copyRelativeReminders gives the next occurrence of a recurring todo the
+// reminders of the completed one that are relative to its due date
+func copyRelativeReminders(tx *gorm.DB, todoID uint, next *models.Todo) error {
+	return tx.Exec(`
+		INSERT INTO reminders (todo_id, offset_minutes, channel, webhook_url, status, attempts, created_at, updated_at)
+		SELECT ?, offset_minutes, channel, webhook_url, ?, 0, ?, ?
+		FROM reminders WHERE todo_id = ? AND offset_minutes IS NOT NULL
+		ORDER BY id`,
+		next.ID, models.ReminderPending, next.CreatedAt, next.CreatedAt, todoID).Error
+}
+
+// todoEditableFields are the columns written by Update. They are selected
+// explicitly so that zero values (false, "", nil) are stored too.
+var todoEditableFields = []string{
+	"Title", "Description", "Priority", "DueDate", "StartDate", "ProjectID", "Completed",
+	"Status", "StartedAt", "CompletedAt", "CancelledAt",
+	"Recurrence", "RecurrenceTimezone", "RepeatFrom", "RecurrenceStart", "Occurrence", "UpdatedAt",
+}
+
+// noDueDate stands in for a missing due date so that todos without one sort last
+var noDueDate = time.Date(9999, 12, 31, 23, 59, 59, 0, time.UTC)
+
+// todoSortColumns maps whitelisted sort fields to their SQL expressions
+var todoSortColumns = map[string]string{
+	models.TodoSortCreatedAt: "created_at",
+	models.TodoSortUpdatedAt: "updated_at",
+	models.TodoSortDueDate:   "COALESCE(due_date, '9999-12-31 23:59:59+00')",
+	models.TodoSortPriority:  "CASE priority WHEN 'high' THEN 3 WHEN 'medium' THEN 2 ELSE 1 END",
+	models.TodoSortTitle:     "title",
+}
+
+// priorityRank mirrors the CASE expression used to sort by priority
+func priorityRank(priority string) int {
+	switch priority {
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	default:
+		return 1
+	}
+}
+
+// todoSortValue serializes the sort key of a todo for use in a cursor
+func todoSortValue(sort string, todo *models.Todo) string {
+	switch sort {
+	case models.TodoSortUpdatedAt:
+		return todo.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case models.TodoSortDueDate:
+		if todo.DueDate == nil {
+			return noDueDate.Format(time.RFC3339Nano)
+		}
+		return todo.DueDate.UTC().Format(time.RFC3339Nano)
+	case models.TodoSortPriority:
+		return strconv.Itoa(priorityRank(todo.Priority))
+	case models.TodoSortTitle:
+		return todo.Title
+	default:
+		return todo.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// parseTodoSortValue converts a cursor value back into a typed SQL argument
+func parseTodoSortValue(sort string, value string) (any, error) {
+	switch sort {
+	case models.TodoSortPriority:
+		return strconv.Atoi(value)
+	case models.TodoSortTitle:
+		return value, nil
+	default:
+		return time.Parse(time.RFC3339Nano, value)
+	}
+}
+
+// applyTodoFilter adds the optional filter conditions to a todo query
+func applyTodoFilter(filter models.TodoFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Completed != nil {
+			db = db.Where("completed = ?", *filter.Completed)
+		}
+		if filter.Priority != "" {
+			db = db.Where("priority = ?", filter.Priority)
+		}
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		switch {
+		case filter.ProjectID == nil:
+			db = db.Where("NOT EXISTS (SELECT 1 FROM projects WHERE projects.id = todos.project_id AND projects.archived)")
+		case *filter.ProjectID == 0:
+			db = db.Where("project_id IS NULL")
+		default:
+			db = db.Where("project_id = ?", *filter.ProjectID)
+		}
+		if len(filter.TagIDs) > 0 {
+			if filter.TagMatch == models.TagMatchAll {
+				db = db.Where("(SELECT COUNT(*) FROM todo_tags WHERE todo_tags.todo_id = todos.id AND todo_tags.tag_id IN ?) = ?",
+					filter.TagIDs, len(filter.TagIDs))
+			} else {
+				db = db.Where("EXISTS (SELECT 1 FROM todo_tags WHERE todo_tags.todo_id = todos.id AND todo_tags.tag_id IN ?)", filter.TagIDs)
+			}
+		}
+		if filter.DueBefore != nil {
+			db = db.Where("due_date < ?", *filter.DueBefore)
+		}
+		if filter.DueAfter != nil {
+			db = db.Where("due_date > ?", *filter.DueAfter)
+		}
+		if filter.Overdue != nil {
+			now := time.Now().UTC()
+			if *filter.Overdue {
+				db = db.Where("completed = ? AND cancelled_at IS NULL AND due_date < ?", false, now)
+			} else {
+				db = db.Where("(completed = ? OR cancelled_at IS NOT NULL OR due_date IS NULL OR due_date >= ?)", true, now)
+			}
+		}
+		if !filter.IncludeDeferred {
+			now := time.Now().UTC()
+			db = db.Where("(start_date IS NULL OR start_date <= ?) AND (snoozed_until IS NULL OR snoozed_until <= ?)", now, now)
+		}
+		return db
+	}
 }
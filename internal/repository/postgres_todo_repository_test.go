@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightHTML(t *testing.T) {
+	// A snippet of the title `<script>alert("x")</script> & groceries` matching "groceries"
+	snippet := `<script>alert("x")</script> & ` + highlightStart + "groceries" + highlightStop
+
+	assert.Equal(t,
+		`&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; &amp; <mark>groceries</mark>`,
+		highlightHTML(snippet))
+}
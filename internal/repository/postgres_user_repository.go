@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type postgresUserRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresUserRepository creates a new PostgreSQL implementation of UserRepository
+func NewPostgresUserRepository(db *gorm.DB) UserRepository {
+	return &postgresUserRepository{
+		db: db,
+	}
+}
+
+func (r *postgresUserRepository) List(ctx context.Context, req *models.ListUsersRequest) ([]models.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.User{})
+
+	if search := strings.TrimSpace(req.Search); search != "" {
+		pattern := "%" + escapeLike(search) + "%"
+		query = query.Where("email ILIKE ? OR first_name ILIKE ? OR last_name ILIKE ?", pattern, pattern, pattern)
+	}
+	if req.Role != "" {
+		query = query.Where("role = ?", req.Role)
+	}
+	if req.Disabled != nil {
+		if *req.Disabled {
+			query = query.Where("disabled_at IS NOT NULL")
+		} else {
+			query = query.Where("disabled_at IS NULL")
+		}
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	result := query.Session(&gorm.Session{}).Order("id ASC").Limit(req.Limit).Offset(req.Offset).Find(&users)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return users, total, nil
+}
+
+func (r *postgresUserRepository) SetDisabledAt(ctx context.Context, id uint64, disabledAt *time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("disabled_at", disabledAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *postgresUserRepository) RequirePasswordReset(ctx context.Context, id uint64) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("password_reset_required", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *postgresUserRepository) PromoteFirstAdmin(ctx context.Context, email string) (bool, error) {
+	// A single statement, so concurrent instances cannot both promote someone
+	admins := r.db.Model(&models.User{}).Select("1").Where("role = ?", models.RoleAdmin)
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("email = ? AND NOT EXISTS (?)", email, admins).
+		Update("role", models.RoleAdmin)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *postgresUserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uint64
+		if err := tx.Unscoped().Model(&models.User{}).Where("deleted_at < ?", deletedBefore).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		// Sessions, tokens and exports go with the users through their foreign keys
+		if err := tx.Unscoped().Where("user_id IN ?", ids).Delete(&models.Todo{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id IN ?", ids).Delete(&models.AuditEvent{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.User{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes s match literally inside a LIKE pattern
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
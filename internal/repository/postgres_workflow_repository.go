@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"todo-list-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type postgresWorkflowRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresWorkflowRepository creates a new PostgreSQL implementation of WorkflowRepository
+func NewPostgresWorkflowRepository(db *gorm.DB) WorkflowRepository {
+	return &postgresWorkflowRepository{
+		db: db,
+	}
+}
+
+func (r *postgresWorkflowRepository) Get(ctx context.Context, userID uint, projectID *uint) (*models.Workflow, error) {
+	var workflow models.Workflow
+	result := r.db.WithContext(ctx).Scopes(workflowOf(userID, projectID)).First(&workflow)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &workflow, nil
+}
+
+func (r *postgresWorkflowRepository) Save(ctx context.Context, workflow *models.Workflow) (*models.Workflow, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.Workflow
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Scopes(workflowOf(uint(workflow.UserID), workflow.ProjectID)).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(workflow).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		workflow.ID = existing.ID
+		workflow.CreatedAt = existing.CreatedAt
+		return tx.Model(&existing).Select("Statuses", "UpdatedAt").Updates(workflow).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workflow, nil
+}
+
+func (r *postgresWorkflowRepository) Delete(ctx context.Context, userID uint, projectID *uint) error {
+	result := r.db.WithContext(ctx).Scopes(workflowOf(userID, projectID)).Delete(&models.Workflow{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("workflow not found")
+	}
+
+	return nil
+}
+
+// workflowOf selects the workflow of a user, or of one of their projects
+func workflowOf(userID uint, projectID *uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("user_id = ?", userID)
+		if projectID == nil {
+			return db.Where("project_id IS NULL")
+		}
+		return db.Where("project_id = ?", *projectID)
+	}
+}
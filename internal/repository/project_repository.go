@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// ProjectRepository defines the interface for project data access operations.
+// Projects are scoped to their user.
+type ProjectRepository interface {
+	// List returns the projects of the user in order, archived ones only when
+	// includeArchived is set
+	List(ctx context.Context, userID uint, includeArchived bool) ([]models.Project, error)
+	GetByID(ctx context.Context, userID uint, id uint) (*models.Project, error)
+	Create(ctx context.Context, project *models.Project) (*models.Project, error)
+	// Update overwrites the name, color, icon, archived flag and position of the project
+	Update(ctx context.Context, project *models.Project) (*models.Project, error)
+	// Delete deletes the project. Its todos move to the Inbox.
+	Delete(ctx context.Context, userID uint, id uint) error
+}
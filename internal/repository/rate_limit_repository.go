@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// RateLimitRepository holds the token buckets of rate limited clients
+type RateLimitRepository interface {
+	// Take refills the bucket of key for the time elapsed until now and takes
+	// one request from it if there is one left
+	Take(ctx context.Context, key string, limit models.RateLimit, now time.Time) (*models.RateLimitResult, error)
+}
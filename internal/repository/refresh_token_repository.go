@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// RefreshTokenRepository defines the interface for persisted refresh token operations
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	// MarkRotated flags an active token as used. It returns false if the token
+	// was already rotated or revoked, which callers must treat as reuse.
+	MarkRotated(ctx context.Context, id uint64) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID uint64) error
+}
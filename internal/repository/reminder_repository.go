@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// ReminderRepository defines the interface for reminder data access
+// operations. Reminders are scoped to their todo; ownership of the todo is
+// checked by the caller.
+type ReminderRepository interface {
+	// ListByTodoID returns the reminders of the todo in creation order
+	ListByTodoID(ctx context.Context, todoID uint) ([]models.Reminder, error)
+	Create(ctx context.Context, reminder *models.Reminder) (*models.Reminder, error)
+	Delete(ctx context.Context, todoID uint, id uint) error
+	// ClaimDue claims up to limit pending reminders that are due at now, with
+	// their todo and its owner. Claimed reminders count an attempt and are
+	// held back until until, so that other schedulers skip them meanwhile.
+	ClaimDue(ctx context.Context, now time.Time, until time.Time, limit int) ([]models.Reminder, error)
+	// Release returns claimed reminders that were not attempted
+	Release(ctx context.Context, ids []uint) error
+	MarkSent(ctx context.Context, id uint, sentAt time.Time) error
+	// MarkFailed records a failed delivery. The reminder is retried at
+	// retryAt, or given up when retryAt is nil.
+	MarkFailed(ctx context.Context, id uint, lastError string, retryAt *time.Time) error
+}
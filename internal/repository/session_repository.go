@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// SessionRepository defines the interface for login session data access operations
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) (*models.Session, error)
+	GetByID(ctx context.Context, id string) (*models.Session, error)
+	// ListActiveByUserID returns the sessions that are neither revoked nor expired
+	ListActiveByUserID(ctx context.Context, userID uint64) ([]models.Session, error)
+	// ListByUserID returns every session of the user, including ended ones, oldest first
+	ListByUserID(ctx context.Context, userID uint64) ([]models.Session, error)
+	// Touch records a use of the session from the given client
+	Touch(ctx context.Context, id string, client *models.ClientInfo, expiresAt time.Time) error
+	Revoke(ctx context.Context, id string) error
+	RevokeAllForUser(ctx context.Context, userID uint64) error
+	// IsRevoked reports whether the session was revoked. Unknown sessions are not revoked.
+	IsRevoked(ctx context.Context, id string) (bool, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// TagRepository defines the interface for tag data access operations. Tags
+// are scoped to their user and their names are compared ignoring case.
+type TagRepository interface {
+	// List returns the tags of the user by name
+	List(ctx context.Context, userID uint) ([]models.Tag, error)
+	GetByID(ctx context.Context, userID uint, id uint) (*models.Tag, error)
+	GetByName(ctx context.Context, userID uint, name string) (*models.Tag, error)
+	// FindByIDs returns the tags among ids that belong to the user
+	FindByIDs(ctx context.Context, userID uint, ids []uint) ([]models.Tag, error)
+	// FindByNames returns the tags of the user with one of the names
+	FindByNames(ctx context.Context, userID uint, names []string) ([]models.Tag, error)
+	// EnsureNames returns the tags with the names, creating the missing ones
+	EnsureNames(ctx context.Context, userID uint, names []string) ([]models.Tag, error)
+	Create(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+	// Update overwrites the name and color of the tag
+	Update(ctx context.Context, tag *models.Tag) (*models.Tag, error)
+	Delete(ctx context.Context, userID uint, id uint) error
+	// Merge moves the todos of tag sourceID to tag targetID and deletes sourceID
+	Merge(ctx context.Context, userID uint, sourceID uint, targetID uint) error
+}
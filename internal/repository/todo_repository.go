@@ -2,15 +2,53 @@ package repository
 
 import (
 	"context"
+	"time"
 	"todo-list-api/internal/models"
 )
 
-// TodoRepository defines the interface for todo data access operations
+// TodoRepository defines the interface for todo data access operations.
+// Every read and write is scoped to the owning user: rows belonging to
+// another user behave exactly like rows that do not exist.
 type TodoRepository interface {
 	Create(ctx context.Context, todo *models.Todo) (*models.Todo, error)
-	GetAll(ctx context.Context) ([]models.Todo, error)
-	GetByID(ctx context.Context, id uint) (*models.Todo, error)
-	Update(ctx context.Context, id uint, todo *models.Todo) (*models.Todo, error)
-	Delete(ctx context.Context, id uint) error
-	GetByUserID(ctx context.Context, userID uint) ([]models.Todo, error)
+	GetByID(ctx context.Context, userID uint, id uint) (*models.Todo, error)
+	// Update overwrites every editable field of the todo, including zero values,
+	// provided its status is still fromStatus. It fails with "todo status
+	// changed" when another update changed the status in the meantime.
+	Update(ctx context.Context, userID uint, id uint, fromStatus string, todo *models.Todo) (*models.Todo, error)
+	// Delete moves the todo to the trash. Its subtasks go with it, or move up
+	// to its parent when subtasks is models.SubtasksPromote.
+	Delete(ctx context.Context, userID uint, id uint, subtasks string) error
+	// List returns one page of the user's todos matching query, together with
+	// the total number of matches and the cursor of the following page.
+	List(ctx context.Context, userID uint, query models.TodoQuery) (*models.TodoPage, error)
+	// Search runs a ranked full-text search over the user's todo titles and
+	// descriptions. Every word of the query is matched as a prefix.
+	Search(ctx context.Context, userID uint, query models.TodoSearchQuery) (*models.TodoSearchPage, error)
+	// ListAllForExport returns every todo of the user, including deleted ones, oldest first
+	ListAllForExport(ctx context.Context, userID uint) ([]models.Todo, error)
+	// ListTrash returns one page of the user's deleted todos, most recently deleted first
+	ListTrash(ctx context.Context, userID uint, limit int, offset int) (*models.TrashPage, error)
+	// Restore undeletes a todo in the user's trash, together with the subtasks
+	// deleted with it. It becomes a top-level todo if its parent is still deleted.
+	Restore(ctx context.Context, userID uint, id uint) error
+	// DeletePermanently removes the todo for good, whether or not it is in the
+	// trash. subtasks is handled as in Delete.
+	DeletePermanently(ctx context.Context, userID uint, id uint, subtasks string) error
+	// ListSubtasks returns the direct subtasks of the todo, oldest first
+	ListSubtasks(ctx context.Context, userID uint, id uint) ([]models.Todo, error)
+	// SetParent makes the todo a subtask of parentID, or a top-level todo when
+	// parentID is nil. It fails if the parent is the todo or one of its subtasks.
+	SetParent(ctx context.Context, userID uint, id uint, parentID *uint) error
+	// Snooze hides the todo from the todo listings until until, or wakes it
+	// up when until is nil
+	Snooze(ctx context.Context, userID uint, id uint, until *time.Time) error
+	// CompleteSubtasks moves every open subtask of the todo, at any depth, to
+	// the status, which is in the done category. Cancelled subtasks are left alone.
+	// Recurring subtasks are left open too, and their IDs are returned so that
+	// they can be completed one by one, creating their next occurrence.
+	CompleteSubtasks(ctx context.Context, userID uint, id uint, status string) ([]uint, error)
+	// PurgeTrash permanently removes every todo deleted before deletedBefore
+	// and returns how many were removed
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// UserRepository defines the interface for the account administration data access operations
+type UserRepository interface {
+	// List returns a page of users matching req, oldest first, and the number of matches
+	List(ctx context.Context, req *models.ListUsersRequest) ([]models.User, int64, error)
+	// SetDisabledAt disables the account, or re-enables it when disabledAt is nil
+	SetDisabledAt(ctx context.Context, id uint64, disabledAt *time.Time) error
+	// RequirePasswordReset blocks logging in to the account until its password is reset
+	RequirePasswordReset(ctx context.Context, id uint64) error
+	// PromoteFirstAdmin makes the user with the email an admin, unless there
+	// already is one. It returns whether the user was promoted.
+	PromoteFirstAdmin(ctx context.Context, email string) (bool, error)
+	// PurgeDeleted permanently deletes the users deleted before deletedBefore,
+	// with their todos and audit events, and returns how many users it deleted
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
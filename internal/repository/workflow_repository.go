@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// WorkflowRepository defines the interface for workflow data access operations.
+// A nil projectID is the workflow of the user, otherwise that of the project.
+type WorkflowRepository interface {
+	// Get returns the workflow, or nil when none is set
+	Get(ctx context.Context, userID uint, projectID *uint) (*models.Workflow, error)
+	// Save creates the workflow of the user or project, or replaces its statuses
+	Save(ctx context.Context, workflow *models.Workflow) (*models.Workflow, error)
+	Delete(ctx context.Context, userID uint, projectID *uint) error
+}
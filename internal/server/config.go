@@ -0,0 +1,189 @@
+package server
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list-api/internal/mailer"
+	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/service"
+
+	"gorm.io/gorm"
+)
+
+// loginThrottleFromEnv creates the login throttle configured by the LOGIN_*
+// variables. Its counts are kept in PostgreSQL, shared by every instance,
+// unless LOGIN_THROTTLE_STORE is "memory".
+func loginThrottleFromEnv(db *gorm.DB) (*service.LoginThrottle, error) {
+	config := service.DefaultLoginThrottleConfig()
+	var err error
+
+	if config.MaxFailures, err = envInt("LOGIN_MAX_FAILURES", config.MaxFailures); err != nil {
+		return nil, err
+	}
+	if config.MaxIPFailures, err = envInt("LOGIN_MAX_IP_FAILURES", config.MaxIPFailures); err != nil {
+		return nil, err
+	}
+	if config.BaseLockout, err = envDuration("LOGIN_LOCKOUT", config.BaseLockout); err != nil {
+		return nil, err
+	}
+	if config.MaxLockout, err = envDuration("LOGIN_MAX_LOCKOUT", config.MaxLockout); err != nil {
+		return nil, err
+	}
+	if config.FailureWindow, err = envDuration("LOGIN_FAILURE_WINDOW", config.FailureWindow); err != nil {
+		return nil, err
+	}
+
+	switch store := os.Getenv("LOGIN_THROTTLE_STORE"); store {
+	case "", "postgres":
+		return service.NewLoginThrottle(repository.NewPostgresLoginAttemptRepository(db), config), nil
+	case "memory":
+		return service.NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), config), nil
+	default:
+		return nil, fmt.Errorf("unknown LOGIN_THROTTLE_STORE %q", store)
+	}
+}
+
+// envInt reads a positive integer variable, or returns def when it is not set
+func envInt(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return n, nil
+}
+
+// envDuration reads a positive duration variable such as "15m", or returns
+// def when it is not set
+func envDuration(name string, def time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as 15m", name)
+	}
+	return d, nil
+}
+
+// Default rate limits. Authentication endpoints are a target for guessing
+// and spam, so they get a much lower limit than the rest of the API.
+var (
+	defaultAuthRateLimit = models.RateLimit{Requests: 20, Period: time.Minute}
+	defaultAPIRateLimit  = models.RateLimit{Requests: 300, Period: time.Minute}
+)
+
+// rateLimits holds the rate limits of the route groups and where their buckets are stored
+type rateLimits struct {
+	store middleware.RateLimitStore
+	auth  models.RateLimit
+	api   models.RateLimit
+}
+
+// rateLimitsFromEnv reads the RATE_LIMIT_* variables. Buckets are kept in
+// memory, per instance, unless RATE_LIMIT_STORE is "postgres".
+func rateLimitsFromEnv(db *gorm.DB) (*rateLimits, error) {
+	limits := &rateLimits{}
+	var err error
+
+	if limits.auth, err = envRateLimit("RATE_LIMIT_AUTH", defaultAuthRateLimit); err != nil {
+		return nil, err
+	}
+	if limits.api, err = envRateLimit("RATE_LIMIT_API", defaultAPIRateLimit); err != nil {
+		return nil, err
+	}
+
+	switch store := os.Getenv("RATE_LIMIT_STORE"); store {
+	case "", "memory":
+		limits.store = repository.NewMemoryRateLimitRepository()
+	case "postgres":
+		limits.store = repository.NewPostgresRateLimitRepository(db)
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_STORE %q", store)
+	}
+	return limits, nil
+}
+
+// envRateLimit reads a rate limit variable such as "100/1m" (100 requests per
+// minute), or returns def when it is not set
+func envRateLimit(name string, def models.RateLimit) (models.RateLimit, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+
+	requests, period, ok := strings.Cut(value, "/")
+	n, err := strconv.Atoi(requests)
+	if !ok || err != nil || n <= 0 {
+		return models.RateLimit{}, fmt.Errorf("%s must be requests per period such as 100/1m", name)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return models.RateLimit{}, fmt.Errorf("%s must be requests per period such as 100/1m", name)
+	}
+	return models.RateLimit{Requests: n, Period: d}, nil
+}
+
+// dataRetentionFromEnv reads ACCOUNT_DELETION_GRACE_PERIOD and
+// TODO_TRASH_RETENTION, such as "720h"
+func dataRetentionFromEnv() (service.DataRetentionConfig, error) {
+	config := service.DefaultDataRetentionConfig()
+	var err error
+
+	if config.AccountGracePeriod, err = envDuration("ACCOUNT_DELETION_GRACE_PERIOD", config.AccountGracePeriod); err != nil {
+		return config, err
+	}
+	if config.TrashRetention, err = envDuration("TODO_TRASH_RETENTION", config.TrashRetention); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// reminderSchedulerFromEnv creates the reminder scheduler configured by the
+// REMINDER_* variables. Webhooks are signed with REMINDER_WEBHOOK_SECRET when
+// it is set.
+func reminderSchedulerFromEnv(db *gorm.DB, mail mailer.Mailer) (*service.ReminderScheduler, error) {
+	config := service.DefaultReminderSchedulerConfig()
+	var err error
+
+	if config.PollInterval, err = envDuration("REMINDER_POLL_INTERVAL", config.PollInterval); err != nil {
+		return nil, err
+	}
+	if config.MaxAttempts, err = envInt("REMINDER_MAX_ATTEMPTS", config.MaxAttempts); err != nil {
+		return nil, err
+	}
+
+	channels := map[string]service.ReminderChannel{
+		models.ReminderChannelEmail:   service.NewEmailReminderChannel(mail),
+		models.ReminderChannelWebhook: service.NewWebhookReminderChannel(service.NewWebhookClient(10*time.Second), []byte(os.Getenv("REMINDER_WEBHOOK_SECRET"))),
+		models.ReminderChannelInApp:   service.NewInAppReminderChannel(repository.NewPostgresNotificationRepository(db)),
+	}
+	return service.NewReminderScheduler(repository.NewPostgresReminderRepository(db), channels, config), nil
+}
+
+// dataExportSigningKey returns DATA_EXPORT_SIGNING_KEY. Without one, download
+// links are signed with a random key, so they only work on the instance that
+// created them and until it restarts.
+func dataExportSigningKey() ([]byte, error) {
+	if key := os.Getenv("DATA_EXPORT_SIGNING_KEY"); key != "" {
+		return []byte(key), nil
+	}
+
+	log.Println("DATA_EXPORT_SIGNING_KEY is not set: data export links only work on this instance until it restarts")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
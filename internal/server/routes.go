@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 	"todo-list-api/internal/controller"
 	"todo-list-api/internal/middleware"
+	"todo-list-api/internal/models"
 	"todo-list-api/internal/repository"
 	"todo-list-api/internal/service"
 
@@ -15,10 +17,15 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// recentMFAMaxAge is how long after a login with a second factor the user may
+// manage their two-factor authentication settings
+const recentMFAMaxAge = 15 * time.Minute
+
 func (s *Server) RegisterRoutes() http.Handler {
 	r := chi.NewRouter()
 
-	// Global middleware (CORS only)
+	// Global middleware
+	r.Use(middleware.ClientIPMiddleware(s.trustedProxies))
 	r.Use(middleware.CorsMiddleware)
 
 	// Static files (no API key required)
@@ -35,68 +42,328 @@ func (s *Server) RegisterRoutes() http.Handler {
 	})
 	r.Handle("/docs/*", httpSwagger.WrapHandler)
 
-	// Routes that require API key
-	r.Group(func(r chi.Router) {
-		r.Use(middleware.ApiKeyMiddleware(s.apiKey))
+	// Public keys for verifying our tokens (no API key required)
+	r.Get("/.well-known/jwks.json", s.jwksHandler)
 
-		// Health check
-		r.Get("/health", s.healthHandler)
+	// Health check (no API key required)
+	r.Get("/health", s.healthHandler)
 
-		// API routes
-		r.Route("/api", func(r chi.Router) {
-			s.registerAuthRoutes(r)
-			s.registerTodoRoutes(r)
-		})
+	// API routes. Each group requires a client key unless it opts out.
+	r.Route("/api", func(r chi.Router) {
+		s.registerAuthRoutes(r)
+		s.registerProfileRoutes(r)
+		s.registerTodoRoutes(r)
+		s.registerTagRoutes(r)
+		s.registerProjectRoutes(r)
+		s.registerWorkflowRoutes(r)
+		s.registerNotificationRoutes(r)
+		s.registerAdminRoutes(r)
 	})
 
 	return r
 }
 
 func (s *Server) registerTodoRoutes(r chi.Router) {
-	// Initialize layers: Repository -> Service -> Controller
-	todoRepo := repository.NewPostgresTodosRepository(s.db.GetDB())
-	todoService := service.NewTodoService(todoRepo)
-	todoController := controller.NewTodoController(todoService)
+	todoController := controller.NewTodoController(s.newTodoService())
+	checklistController := controller.NewChecklistController(service.NewChecklistService(
+		repository.NewPostgresTodosRepository(s.db.GetDB()),
+		repository.NewPostgresChecklistRepository(s.db.GetDB()),
+	))
+	reminderController := controller.NewReminderController(service.NewReminderService(
+		repository.NewPostgresTodosRepository(s.db.GetDB()),
+		repository.NewPostgresReminderRepository(s.db.GetDB()),
+	))
 
 	r.Route("/todos", func(r chi.Router) {
-		// Apply authentication middleware to all todo routes
-		r.Use(middleware.AuthMiddleware(s.jwt))
+		s.useTodoMiddleware(r)
 
 		// Collection routes: /api/todos
 		r.Get("/", todoController.GetTodos)
 		r.Post("/", todoController.CreateTodo)
+		r.Get("/search", todoController.SearchTodos)
+		r.Get("/trash", todoController.GetTrash)
 
 		// Individual item routes: /api/todos/{id}
 		r.Route("/{id}", func(r chi.Router) {
 			r.Get("/", todoController.GetTodoByID)
 			r.Put("/", todoController.UpdateTodo)
+			r.Patch("/", todoController.PatchTodo)
 			r.Delete("/", todoController.DeleteTodo)
+			r.Post("/restore", todoController.RestoreTodo)
+			r.Post("/snooze", todoController.SnoozeTodo)
+			r.Delete("/snooze", todoController.UnsnoozeTodo)
+			r.Post("/transition", todoController.TransitionTodo)
+
+			r.Route("/subtasks", func(r chi.Router) {
+				r.Get("/", todoController.GetSubtasks)
+				r.Post("/", todoController.CreateSubtask)
+				r.Put("/{subtaskId}", todoController.AttachSubtask)
+				r.Delete("/{subtaskId}", todoController.DetachSubtask)
+			})
+
+			r.Route("/checklist", func(r chi.Router) {
+				r.Get("/", checklistController.GetChecklist)
+				r.Post("/", checklistController.AddItem)
+				r.Patch("/{itemId}", checklistController.UpdateItem)
+				r.Delete("/{itemId}", checklistController.DeleteItem)
+			})
+
+			r.Route("/reminders", func(r chi.Router) {
+				r.Get("/", reminderController.GetReminders)
+				r.Post("/", reminderController.CreateReminder)
+				r.Delete("/{reminderId}", reminderController.DeleteReminder)
+			})
 		})
 	})
 }
 
+// registerTagRoutes registers the tag routes. Tags are part of the todo data,
+// so they need the same client key, authentication and scopes as todos.
+func (s *Server) registerTagRoutes(r chi.Router) {
+	tagController := controller.NewTagController(service.NewTagService(repository.NewPostgresTagRepository(s.db.GetDB())))
+
+	r.Route("/tags", func(r chi.Router) {
+		s.useTodoMiddleware(r)
+
+		r.Get("/", tagController.ListTags)
+		r.Post("/", tagController.CreateTag)
+		r.Patch("/{id}", tagController.UpdateTag)
+		r.Delete("/{id}", tagController.DeleteTag)
+		r.Post("/{id}/merge", tagController.MergeTag)
+	})
+}
+
+// registerProjectRoutes registers the project routes, which share the
+// middleware of the todo routes
+func (s *Server) registerProjectRoutes(r chi.Router) {
+	projectController := controller.NewProjectController(
+		service.NewProjectService(repository.NewPostgresProjectRepository(s.db.GetDB())),
+		s.newTodoService(),
+	)
+	workflowController := controller.NewWorkflowController(s.newWorkflowService())
+
+	r.Route("/projects", func(r chi.Router) {
+		s.useTodoMiddleware(r)
+
+		r.Get("/", projectController.ListProjects)
+		r.Post("/", projectController.CreateProject)
+		r.Get("/inbox/todos", projectController.GetInboxTodos)
+
+		r.Route("/{id}", func(r chi.Router) {
+			r.Get("/", projectController.GetProject)
+			r.Patch("/", projectController.UpdateProject)
+			r.Delete("/", projectController.DeleteProject)
+			r.Get("/todos", projectController.GetProjectTodos)
+			r.Get("/workflow", workflowController.GetProjectWorkflow)
+			r.Put("/workflow", workflowController.SetProjectWorkflow)
+			r.Delete("/workflow", workflowController.DeleteProjectWorkflow)
+		})
+	})
+}
+
+// registerWorkflowRoutes registers the routes of the user's workflow, which
+// share the middleware of the todo routes
+func (s *Server) registerWorkflowRoutes(r chi.Router) {
+	workflowController := controller.NewWorkflowController(s.newWorkflowService())
+
+	r.Route("/workflow", func(r chi.Router) {
+		s.useTodoMiddleware(r)
+
+		r.Get("/", workflowController.GetWorkflow)
+		r.Put("/", workflowController.SetWorkflow)
+		r.Delete("/", workflowController.DeleteWorkflow)
+	})
+}
+
+// registerNotificationRoutes registers the in-app notification routes, which
+// share the middleware of the todo routes
+func (s *Server) registerNotificationRoutes(r chi.Router) {
+	notificationController := controller.NewNotificationController(
+		service.NewNotificationService(repository.NewPostgresNotificationRepository(s.db.GetDB())),
+	)
+
+	r.Route("/notifications", func(r chi.Router) {
+		s.useTodoMiddleware(r)
+
+		r.Get("/", notificationController.ListNotifications)
+		r.Post("/{id}/read", notificationController.MarkRead)
+	})
+}
+
+// useTodoMiddleware applies the middleware shared by the todo data routes
+func (s *Server) useTodoMiddleware(r chi.Router) {
+	r.Use(middleware.ApiKeyMiddleware(s.clientKeys))
+
+	// Apply authentication middleware to all todo routes
+	r.Use(middleware.AuthMiddleware(s.jwt, s.sessions, s.tokens))
+	r.Use(s.apiRateLimit())
+	r.Use(middleware.RequireReadWriteScope(models.ScopeTodosRead, models.ScopeTodosWrite))
+	if s.auth.EmailVerificationPolicy == service.EmailVerificationReadOnly {
+		r.Use(middleware.ReadOnlyUntilVerified)
+	}
+}
+
 func (s *Server) registerAuthRoutes(r chi.Router) {
-	// Initialize layers: Repository -> Service -> Controller
-	authRepo := repository.NewPostgresAuthRepository(s.db.GetDB())
-	authService := service.NewAuthService(authRepo, s.jwt)
-	authController := controller.NewAuthController(authService)
+	authController := controller.NewAuthController(s.newAuthService())
+	tokenController := controller.NewPersonalAccessTokenController(s.tokens)
 
 	r.Route("/auth", func(r chi.Router) {
-		// Public auth routes (no authentication required)
+		// Every auth route shares the strict limit, per client IP
+		r.Use(middleware.RateLimitMiddleware(s.rateLimits.store, "auth", s.rateLimits.auth, true))
+
+		// Logging in and out of accounts works without an API key
 		r.Post("/register", authController.Register)
 		r.Post("/login", authController.Login)
 		r.Post("/refresh", authController.Refresh)
+		r.Post("/mfa/verify", authController.VerifyMFA)
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.ApiKeyMiddleware(s.clientKeys))
+
+			// Public auth routes (no authentication required)
+			r.Post("/password/forgot", authController.ForgotPassword)
+			r.Post("/password/reset", authController.ResetPassword)
+			r.Post("/verify", authController.VerifyEmail)
+			r.Post("/verify/resend", authController.ResendVerification)
+		})
 
 		// Protected auth routes (authentication required)
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.AuthMiddleware(s.jwt))
-			// Add any protected auth routes here if needed
-			// r.Post("/logout", authController.Logout)
-			// r.Get("/profile", authController.GetProfile)
+			r.Use(middleware.ApiKeyMiddleware(s.clientKeys))
+			r.Use(middleware.AuthMiddleware(s.jwt, s.sessions, s.tokens))
+			r.Use(middleware.RequireScope(models.ScopeAccountAdmin))
+			r.Post("/logout", authController.Logout)
+			r.Post("/logout-all", authController.LogoutAll)
+			r.Get("/sessions", authController.GetSessions)
+			r.Delete("/sessions/{id}", authController.RevokeSession)
+			r.Post("/mfa/enroll", authController.EnrollMFA)
+			r.Post("/mfa/confirm", authController.ConfirmMFA)
+			r.Get("/tokens", tokenController.ListTokens)
+			r.Post("/tokens", tokenController.CreateToken)
+			r.Delete("/tokens/{id}", tokenController.RevokeToken)
+
+			// Changing an enabled second factor requires having just used it
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequireRecentMFA(recentMFAMaxAge))
+				r.Post("/mfa/recovery-codes", authController.RegenerateRecoveryCodes)
+				r.Delete("/mfa", authController.DisableMFA)
+			})
+		})
+	})
+}
+
+func (s *Server) registerProfileRoutes(r chi.Router) {
+	profileController := controller.NewProfileController(s.newAuthService())
+	exportController := controller.NewDataExportController(s.exports)
+
+	r.Route("/me", func(r chi.Router) {
+		r.Use(middleware.ApiKeyMiddleware(s.clientKeys))
+		r.Use(middleware.AuthMiddleware(s.jwt, s.sessions, s.tokens))
+		r.Use(middleware.RequireScope(models.ScopeAccountAdmin))
+		r.Use(s.apiRateLimit())
+
+		r.Get("/", profileController.GetProfile)
+		r.Patch("/", profileController.UpdateProfile)
+		r.Post("/export", exportController.RequestExport)
+		r.Get("/export/{id}", exportController.GetExport)
+
+		// Routes checking the password also get the strict auth limit, per user
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RateLimitMiddleware(s.rateLimits.store, "auth", s.rateLimits.auth, true))
+			r.Post("/password", profileController.ChangePassword)
+			r.Post("/email", profileController.ChangeEmail)
+			r.Delete("/", profileController.DeleteAccount)
+		})
+	})
+
+	// Download links are signed, so they work without a key or a login
+	r.With(s.apiRateLimit()).Get("/exports/{id}", exportController.DownloadExport)
+}
+
+func (s *Server) registerAdminRoutes(r chi.Router) {
+	adminService := service.NewAdminService(
+		repository.NewPostgresUserRepository(s.db.GetDB()),
+		s.newAuthService(),
+		s.newTodoService(),
+	)
+	adminController := controller.NewAdminController(adminService)
+	clientKeyController := controller.NewClientKeyController(s.clientKeys)
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(middleware.ApiKeyMiddleware(s.clientKeys))
+
+		// Client keys are managed with the root API key
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireRootApiKey)
+			r.Use(s.apiRateLimit())
+			r.Get("/client-keys", clientKeyController.ListKeys)
+			r.Post("/client-keys", clientKeyController.CreateKey)
+			r.Delete("/client-keys/{id}", clientKeyController.RevokeKey)
+		})
+
+		// Accounts are managed by logged in admins
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(s.jwt, s.sessions, s.tokens))
+			r.Use(middleware.RequireRole(models.RoleAdmin))
+			r.Use(s.apiRateLimit())
+
+			r.With(middleware.RequirePermission(models.PermissionUsersRead)).Get("/users", adminController.ListUsers)
+			r.With(middleware.RequirePermission(models.PermissionUsersRead)).Get("/users/{id}", adminController.GetUser)
+			r.With(middleware.RequirePermission(models.PermissionTodosReadAll)).Get("/users/{id}/todos", adminController.GetUserTodos)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission(models.PermissionUsersManage))
+				r.Post("/users/{id}/disable", adminController.DisableUser)
+				r.Post("/users/{id}/enable", adminController.EnableUser)
+				r.Post("/users/{id}/password-reset", adminController.ForcePasswordReset)
+			})
 		})
 	})
 }
 
+// apiRateLimit limits the clients of the API routes it is used on. It is
+// used after authentication, so that clients are limited per user or key.
+func (s *Server) apiRateLimit() func(http.Handler) http.Handler {
+	return middleware.RateLimitMiddleware(s.rateLimits.store, "api", s.rateLimits.api, false)
+}
+
+// newAuthService wires the AuthService over the server's database
+func (s *Server) newAuthService() service.AuthService {
+	db := s.db.GetDB()
+	return service.NewAuthService(
+		repository.NewPostgresAuthRepository(db),
+		repository.NewPostgresRefreshTokenRepository(db),
+		repository.NewPostgresSessionRepository(db),
+		repository.NewPostgresPasswordResetRepository(db),
+		repository.NewPostgresEmailVerificationRepository(db),
+		repository.NewPostgresMFARepository(db),
+		s.sessions,
+		s.logins,
+		s.mailer,
+		s.audit,
+		s.jwt,
+		s.auth,
+	)
+}
+
+// newTodoService wires the TodoService over the server's database
+func (s *Server) newTodoService() service.TodoService {
+	return service.NewTodoService(
+		repository.NewPostgresTodosRepository(s.db.GetDB()),
+		repository.NewPostgresTagRepository(s.db.GetDB()),
+		repository.NewPostgresProjectRepository(s.db.GetDB()),
+		repository.NewPostgresAuthRepository(s.db.GetDB()),
+		repository.NewPostgresWorkflowRepository(s.db.GetDB()),
+	)
+}
+
+// newWorkflowService wires the WorkflowService over the server's database
+func (s *Server) newWorkflowService() service.WorkflowService {
+	return service.NewWorkflowService(
+		repository.NewPostgresWorkflowRepository(s.db.GetDB()),
+		repository.NewPostgresProjectRepository(s.db.GetDB()),
+	)
+}
+
 func (s *Server) HelloWorldHandler(w http.ResponseWriter, r *http.Request) {
 	workDir, _ := os.Getwd()
 	htmlPath := filepath.Join(workDir, "web", "index.html")
@@ -118,12 +385,31 @@ func (s *Server) HelloWorldHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// @Summary JSON Web Key Set
+// @Description Public keys that verify the access tokens issued by this API, identified by kid. Empty when tokens are signed with a shared secret.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (s *Server) jwksHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := json.Marshal(s.jwt.JWKS())
+	if err != nil {
+		http.Error(w, "Failed to marshal key set", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	// Keys change rarely; verifiers refetch when they see an unknown kid
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if _, err := w.Write(resp); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
 // @Summary Health check
 // @Description Check the health status of the API and database
 // @Tags health
 // @Accept json
 // @Produce json
-// @Security ApiKeyAuth
 // @Success 200 {object} map[string]interface{}
 // @Failure 500 {object} map[string]string
 // @Router /health [get]
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,34 +11,153 @@ import (
 
 	_ "github.com/joho/godotenv/autoload"
 
+	"todo-list-api/internal/audit"
 	"todo-list-api/internal/database"
+	"todo-list-api/internal/mailer"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/service"
 	"todo-list-api/internal/utils"
+	httputils "todo-list-api/internal/utils/http"
 )
 
 type Server struct {
-	port   int
-	apiKey string
-	db     database.Service
-	jwt    *utils.JWT
+	port       int
+	db         database.Service
+	jwt        *utils.JWT
+	sessions   *service.SessionDenylist
+	logins     *service.LoginThrottle
+	tokens     service.PersonalAccessTokenService
+	clientKeys service.ClientKeyService
+	rateLimits *rateLimits
+	exports    service.DataExportService
+	mailer     mailer.Mailer
+	audit      audit.Logger
+	auth       service.AuthConfig
+	// trustedProxies are the reverse proxies whose X-Forwarded-For is believed
+	trustedProxies httputils.TrustedProxies
 }
 
-func NewServer() *http.Server {
+// NewServer creates the HTTP server and starts the background jobs. The
+// reminder scheduler and the data retention job are returned so that they can
+// be stopped on shutdown.
+func NewServer() (*http.Server, *service.ReminderScheduler, *service.DataRetention) {
 	port, _ := strconv.Atoi(os.Getenv("PORT"))
+	// API_KEY is the root client key, which issues the other client keys
 	apiKey := os.Getenv("API_KEY")
+	if apiKey == "" {
+		log.Println("API_KEY is not set: only client keys issued earlier are accepted")
+	}
 
-	// Initialize JWT secret once
+	// Tokens are signed with the asymmetric keys when configured, otherwise
+	// with JWT_SECRET. Both may be set while migrating away from JWT_SECRET.
 	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET environment variable must be set")
+	jwtKeys, err := utils.LoadKeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+	if jwtSecret == "" && jwtKeys == nil {
+		log.Fatal("JWT_SECRET or JWT_SIGNING_KEY_FILE environment variable must be set")
+	}
+
+	mail, err := mailer.NewFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure mailer: %v", err)
+	}
+
+	verificationPolicy, err := service.ParseEmailVerificationPolicy(os.Getenv("EMAIL_VERIFICATION_POLICY"))
+	if err != nil {
+		log.Fatalf("Invalid EMAIL_VERIFICATION_POLICY: %v", err)
+	}
+
+	trustedProxies, err := httputils.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
+	db := database.New()
+
+	loginThrottle, err := loginThrottleFromEnv(db.GetDB())
+	if err != nil {
+		log.Fatalf("Failed to configure login throttling: %v", err)
+	}
+
+	limits, err := rateLimitsFromEnv(db.GetDB())
+	if err != nil {
+		log.Fatalf("Failed to configure rate limiting: %v", err)
+	}
+
+	retention, err := dataRetentionFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure data retention: %v", err)
+	}
+
+	exportKey, err := dataExportSigningKey()
+	if err != nil {
+		log.Fatalf("Failed to configure data exports: %v", err)
+	}
+
+	reminders, err := reminderSchedulerFromEnv(db.GetDB(), mail)
+	if err != nil {
+		log.Fatalf("Failed to configure reminders: %v", err)
+	}
+
+	// ADMIN_EMAIL names the account that becomes the first admin
+	if adminEmail := os.Getenv("ADMIN_EMAIL"); adminEmail != "" {
+		promoted, err := repository.NewPostgresUserRepository(db.GetDB()).PromoteFirstAdmin(context.Background(), adminEmail)
+		if err != nil {
+			log.Fatalf("Failed to bootstrap admin: %v", err)
+		}
+		if promoted {
+			log.Printf("Promoted %s to admin", adminEmail)
+		}
 	}
 
 	NewServer := &Server{
-		port:   port,
-		apiKey: apiKey,
-		db:     database.New(),
-		jwt:    &utils.JWT{Secret: jwtSecret},
+		port:     port,
+		db:       db,
+		jwt:      &utils.JWT{Secret: jwtSecret, Keys: jwtKeys},
+		sessions: service.NewSessionDenylist(repository.NewPostgresSessionRepository(db.GetDB())),
+		logins:   loginThrottle,
+		tokens: service.NewPersonalAccessTokenService(
+			repository.NewPostgresPersonalAccessTokenRepository(db.GetDB()),
+			repository.NewPostgresAuthRepository(db.GetDB()),
+		),
+		rateLimits: limits,
+		clientKeys: service.NewClientKeyService(repository.NewPostgresClientKeyRepository(db.GetDB()), apiKey),
+		exports: service.NewDataExportService(
+			repository.NewPostgresDataExportRepository(db.GetDB()),
+			repository.NewPostgresAuthRepository(db.GetDB()),
+			repository.NewPostgresTodosRepository(db.GetDB()),
+			repository.NewPostgresSessionRepository(db.GetDB()),
+			repository.NewPostgresAuditEventRepository(db.GetDB()),
+			exportKey,
+		),
+		mailer: mail,
+		audit: audit.NewMultiLogger(
+			audit.NewLogLogger(),
+			audit.NewStoreLogger(repository.NewPostgresAuditEventRepository(db.GetDB())),
+		),
+		auth: service.AuthConfig{
+			AppURL:                  os.Getenv("APP_URL"),
+			EmailVerificationPolicy: verificationPolicy,
+			MFAIssuer:               os.Getenv("MFA_ISSUER"),
+		},
+		trustedProxies: trustedProxies,
 	}
 
+	// Deleted accounts, old trash and expired exports are purged in the
+	// background until the server shuts down
+	purger := service.NewDataRetention(
+		repository.NewPostgresUserRepository(db.GetDB()),
+		repository.NewPostgresTodosRepository(db.GetDB()),
+		repository.NewPostgresDataExportRepository(db.GetDB()),
+		retention,
+	)
+	purger.Start()
+
+	// Due reminders are delivered in the background until the server shuts down
+	reminders.Start()
+
 	// Declare Server config
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", NewServer.port),
@@ -47,5 +167,5 @@ func NewServer() *http.Server {
 		WriteTimeout: 30 * time.Second,
 	}
 
-	return server
+	return server, reminders, purger
 }
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// AdminService defines the interface for account administration operations
+type AdminService interface {
+	ListUsers(ctx context.Context, req *models.ListUsersRequest) (*models.UserPage, error)
+	GetUser(ctx context.Context, id uint) (*models.User, error)
+	// DisableUser blocks the account and ends its sessions. Admins cannot disable themselves.
+	DisableUser(ctx context.Context, adminID uint, id uint) error
+	EnableUser(ctx context.Context, id uint) error
+	// ForcePasswordReset ends the user's sessions, blocks logging in until the
+	// password is reset and emails them a reset link
+	ForcePasswordReset(ctx context.Context, id uint) error
+	// GetUserTodos lists the todos of any user, for support
+	GetUserTodos(ctx context.Context, id uint, req *models.ListTodosRequest) (*models.TodoPage, error)
+}
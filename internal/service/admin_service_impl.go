@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type adminServiceImpl struct {
+	userRepo    repository.UserRepository
+	authService AuthService
+	todoService TodoService
+}
+
+// NewAdminService creates a new instance of AdminService
+func NewAdminService(userRepo repository.UserRepository, authService AuthService, todoService TodoService) AdminService {
+	return &adminServiceImpl{
+		userRepo:    userRepo,
+		authService: authService,
+		todoService: todoService,
+	}
+}
+
+func (s *adminServiceImpl) ListUsers(ctx context.Context, req *models.ListUsersRequest) (*models.UserPage, error) {
+	if req.Limit == 0 {
+		req.Limit = models.DefaultUserPageSize
+	}
+
+	users, total, err := s.userRepo.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if users == nil {
+		users = []models.User{}
+	}
+
+	return &models.UserPage{Items: users, TotalCount: total}, nil
+}
+
+func (s *adminServiceImpl) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	return s.authService.GetUserByID(ctx, id)
+}
+
+func (s *adminServiceImpl) DisableUser(ctx context.Context, adminID uint, id uint) error {
+	if id == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if id == adminID {
+		return errors.New("cannot disable your own account")
+	}
+
+	now := time.Now().UTC()
+	if err := s.userRepo.SetDisabledAt(ctx, uint64(id), &now); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	// Ending the sessions makes the user's access tokens stop working now
+	// rather than when they expire
+	return s.authService.LogoutAll(ctx, id)
+}
+
+func (s *adminServiceImpl) EnableUser(ctx context.Context, id uint) error {
+	if id == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if err := s.userRepo.SetDisabledAt(ctx, uint64(id), nil); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *adminServiceImpl) ForcePasswordReset(ctx context.Context, id uint) error {
+	user, err := s.authService.GetUserByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.RequirePasswordReset(ctx, user.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if err := s.authService.LogoutAll(ctx, id); err != nil {
+		return err
+	}
+
+	return s.authService.ForgotPassword(ctx, &models.ForgotPasswordRequest{Email: user.Email})
+}
+
+func (s *adminServiceImpl) GetUserTodos(ctx context.Context, id uint, req *models.ListTodosRequest) (*models.TodoPage, error) {
+	if _, err := s.authService.GetUserByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.todoService.GetTodos(ctx, id, req)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todo-list-api/internal/audit"
+	"todo-list-api/internal/mailer"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/service/mocks"
+	"todo-list-api/internal/utils"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AdminServiceTestSuite struct {
+	suite.Suite
+	mockUserRepo          *mocks.MockUserRepository
+	mockAuthRepo          *mocks.MockAuthRepository
+	mockRefreshTokenRepo  *mocks.MockRefreshTokenRepository
+	mockSessionRepo       *mocks.MockSessionRepository
+	mockPasswordResetRepo *mocks.MockPasswordResetRepository
+	mailer                *mailer.MemoryMailer
+	service               AdminService
+	ctx                   context.Context
+	user                  *models.User
+}
+
+func (suite *AdminServiceTestSuite) SetupTest() {
+	suite.mockUserRepo = new(mocks.MockUserRepository)
+	suite.mockAuthRepo = new(mocks.MockAuthRepository)
+	suite.mockRefreshTokenRepo = new(mocks.MockRefreshTokenRepository)
+	suite.mockSessionRepo = new(mocks.MockSessionRepository)
+	suite.mockPasswordResetRepo = new(mocks.MockPasswordResetRepository)
+	suite.mailer = mailer.NewMemoryMailer()
+	suite.ctx = context.Background()
+	suite.user = &models.User{ID: 2, Email: "user@example.com", Role: models.RoleUser, EmailVerified: true}
+
+	authService := NewAuthService(
+		suite.mockAuthRepo,
+		suite.mockRefreshTokenRepo,
+		suite.mockSessionRepo,
+		suite.mockPasswordResetRepo,
+		new(mocks.MockEmailVerificationRepository),
+		new(mocks.MockMFARepository),
+		NewSessionDenylist(suite.mockSessionRepo),
+		NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), DefaultLoginThrottleConfig()),
+		suite.mailer,
+		audit.NewMemoryLogger(),
+		&utils.JWT{Secret: "test-secret"},
+		AuthConfig{AppURL: "https://app.example.com"},
+	)
+	suite.service = NewAdminService(suite.mockUserRepo, authService, NewTodoService(new(mocks.MockTodoRepository), new(mocks.MockTagRepository), new(mocks.MockProjectRepository), new(mocks.MockAuthRepository), new(mocks.MockWorkflowRepository)))
+}
+
+// expectLogoutAll expects every session of the suite's user to be revoked
+func (suite *AdminServiceTestSuite) expectLogoutAll() {
+	suite.mockSessionRepo.On("ListActiveByUserID", suite.ctx, suite.user.ID).
+		Return([]models.Session{{ID: "session-1", UserID: suite.user.ID}}, nil)
+	suite.mockSessionRepo.On("RevokeAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeAllForUser", suite.ctx, suite.user.ID).Return(nil)
+}
+
+func (suite *AdminServiceTestSuite) TestDisableUser_RevokesSessions() {
+	suite.mockUserRepo.On("SetDisabledAt", suite.ctx, suite.user.ID, mock.AnythingOfType("*time.Time")).Return(nil)
+	suite.expectLogoutAll()
+
+	err := suite.service.DisableUser(suite.ctx, 1, uint(suite.user.ID))
+
+	suite.Require().NoError(err)
+	suite.mockSessionRepo.AssertExpectations(suite.T())
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+}
+
+func (suite *AdminServiceTestSuite) TestDisableUser_Self() {
+	err := suite.service.DisableUser(suite.ctx, 1, 1)
+
+	suite.EqualError(err, "cannot disable your own account")
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "SetDisabledAt", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AdminServiceTestSuite) TestDisableUser_NotFound() {
+	suite.mockUserRepo.On("SetDisabledAt", suite.ctx, uint64(9), mock.AnythingOfType("*time.Time")).Return(gorm.ErrRecordNotFound)
+
+	err := suite.service.DisableUser(suite.ctx, 1, 9)
+
+	suite.EqualError(err, "user not found")
+}
+
+func (suite *AdminServiceTestSuite) TestForcePasswordReset() {
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(suite.user.ID)).Return(suite.user, nil)
+	suite.mockUserRepo.On("RequirePasswordReset", suite.ctx, suite.user.ID).Return(nil)
+	suite.expectLogoutAll()
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+	suite.mockPasswordResetRepo.On("InvalidateAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockPasswordResetRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.PasswordResetToken")).
+		Return(&models.PasswordResetToken{}, nil)
+
+	err := suite.service.ForcePasswordReset(suite.ctx, uint(suite.user.ID))
+
+	suite.Require().NoError(err)
+	suite.mockUserRepo.AssertExpectations(suite.T())
+	msg, ok := suite.mailer.Wait(time.Second)
+	suite.Require().True(ok)
+	suite.Equal(suite.user.Email, msg.To)
+	suite.Contains(msg.Body, "https://app.example.com/reset-password?token=")
+}
+
+func (suite *AdminServiceTestSuite) TestListUsers_DefaultLimit() {
+	req := &models.ListUsersRequest{Search: "example"}
+	suite.mockUserRepo.On("List", suite.ctx, mock.MatchedBy(func(r *models.ListUsersRequest) bool {
+		return r.Limit == models.DefaultUserPageSize
+	})).Return(nil, int64(0), nil)
+
+	page, err := suite.service.ListUsers(suite.ctx, req)
+
+	suite.Require().NoError(err)
+	suite.NotNil(page.Items)
+	suite.Equal(int64(0), page.TotalCount)
+}
+
+func TestAdminServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminServiceTestSuite))
+}
@@ -0,0 +1,38 @@
+package service
+
+import "fmt"
+
+// DefaultMFAIssuer is the account issuer shown by authenticator apps
+const DefaultMFAIssuer = "Todo List API"
+
+// EmailVerificationPolicy decides what users with an unverified email may do
+type EmailVerificationPolicy string
+
+const (
+	// EmailVerificationBlockLogin refuses to log in unverified users
+	EmailVerificationBlockLogin EmailVerificationPolicy = "block"
+	// EmailVerificationReadOnly lets unverified users log in with read-only access
+	EmailVerificationReadOnly EmailVerificationPolicy = "read-only"
+)
+
+// ParseEmailVerificationPolicy parses a policy name, defaulting to blocking login
+func ParseEmailVerificationPolicy(value string) (EmailVerificationPolicy, error) {
+	switch policy := EmailVerificationPolicy(value); policy {
+	case "":
+		return EmailVerificationBlockLogin, nil
+	case EmailVerificationBlockLogin, EmailVerificationReadOnly:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown email verification policy %q", value)
+	}
+}
+
+// AuthConfig holds the settings of AuthService
+type AuthConfig struct {
+	// AppURL is the base URL of the web client, used to build the links sent by email
+	AppURL string
+	// EmailVerificationPolicy applies to users who have not verified their email
+	EmailVerificationPolicy EmailVerificationPolicy
+	// MFAIssuer names the service in authenticator apps
+	MFAIssuer string
+}
@@ -7,9 +7,58 @@ import (
 
 // AuthService defines the interface for authentication business logic operations
 type AuthService interface {
+	// Register creates the user and emails a link to verify their address
 	Register(ctx context.Context, req *models.CreateUserRequest) (*models.User, error)
-	Login(ctx context.Context, req *models.LoginUserRequest) (*models.Token, error)
-	RefreshToken(ctx context.Context, req *models.RefreshTokenRequest) (*models.Token, error)
+	// Login verifies the credentials and starts a new session for the client.
+	// Unverified users are refused when the policy is EmailVerificationBlockLogin.
+	// If the user has two-factor authentication enabled, only an MFA token is
+	// returned and the login is completed by VerifyMFA.
+	Login(ctx context.Context, req *models.LoginUserRequest, client *models.ClientInfo) (*models.Token, error)
+	// RefreshToken rotates a refresh token: the presented token is consumed and a
+	// new pair is issued. Presenting an already rotated token revokes its family.
+	RefreshToken(ctx context.Context, req *models.RefreshTokenRequest, client *models.ClientInfo) (*models.Token, error)
+	// Logout revokes the session (token family) of the given refresh token
+	Logout(ctx context.Context, userID uint, req *models.RefreshTokenRequest) error
+	// LogoutAll revokes every session of the user
+	LogoutAll(ctx context.Context, userID uint) error
+	// GetSessions lists the user's active sessions, flagging currentSessionID as current
+	GetSessions(ctx context.Context, userID uint, currentSessionID string) ([]models.Session, error)
+	// RevokeSession ends one of the user's sessions
+	RevokeSession(ctx context.Context, userID uint, sessionID string) error
+	// ForgotPassword emails a password reset link if an account exists for the
+	// email. It succeeds for unknown emails too, so accounts cannot be enumerated.
+	ForgotPassword(ctx context.Context, req *models.ForgotPasswordRequest) error
+	// ResetPassword sets a new password using a reset token and ends all sessions of the user
+	ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error
+	// VerifyEmail marks the email of the token's user as verified, or completes
+	// an email change by switching the user to the verified address
+	VerifyEmail(ctx context.Context, req *models.VerifyEmailRequest) error
+	// ResendVerification emails a new verification link to an unverified account.
+	// Like ForgotPassword, it succeeds for unknown emails.
+	ResendVerification(ctx context.Context, req *models.ResendVerificationRequest) error
+	// EnrollMFA starts enrolling an authenticator app, replacing an unconfirmed enrollment
+	EnrollMFA(ctx context.Context, userID uint) (*models.MFAEnrollment, error)
+	// ConfirmMFA enables two-factor authentication with a first code and returns the recovery codes
+	ConfirmMFA(ctx context.Context, userID uint, req *models.ConfirmMFARequest) (*models.RecoveryCodes, error)
+	// VerifyMFA completes a login with the MFA token returned by Login and a
+	// TOTP or recovery code
+	VerifyMFA(ctx context.Context, req *models.VerifyMFARequest, client *models.ClientInfo) (*models.Token, error)
+	// RegenerateRecoveryCodes replaces the user's recovery codes
+	RegenerateRecoveryCodes(ctx context.Context, userID uint) (*models.RecoveryCodes, error)
+	// DisableMFA turns two-factor authentication off
+	DisableMFA(ctx context.Context, userID uint) error
+	// UpdateProfile changes the names and preferences of the user
+	UpdateProfile(ctx context.Context, userID uint, req *models.UpdateProfileRequest) (*models.User, error)
+	// ChangePassword replaces the password after checking the current one, and
+	// ends every session of the user but currentSessionID
+	ChangePassword(ctx context.Context, userID uint, currentSessionID string, req *models.ChangePasswordRequest) error
+	// ChangeEmail emails a verification link to the new address. The account
+	// keeps its email until the link is used.
+	ChangeEmail(ctx context.Context, userID uint, req *models.ChangeEmailRequest) error
+	// DeleteAccount deletes the user and their todos after checking the
+	// password. They are deleted permanently once the grace period of
+	// DataRetention is over.
+	DeleteAccount(ctx context.Context, userID uint, req *models.DeleteAccountRequest) error
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByID(ctx context.Context, id uint) (*models.User, error)
 }
@@ -3,25 +3,82 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
 	"net/mail"
+	"strings"
+	"time"
+	"todo-list-api/internal/audit"
+	"todo-list-api/internal/mailer"
 	"todo-list-api/internal/models"
 	"todo-list-api/internal/repository"
 	"todo-list-api/internal/utils"
+	"unicode/utf8"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// Lifetimes of the links sent by email
+const (
+	PasswordResetTTL     = time.Hour
+	EmailVerificationTTL = 24 * time.Hour
+)
+
+// mailTimeout bounds the delivery of an email sent in the background
+const mailTimeout = 30 * time.Second
+
 type authServiceImpl struct {
-	authRepo repository.AuthRepository
-	jwtUtil  *utils.JWT
+	authRepo              repository.AuthRepository
+	refreshTokenRepo      repository.RefreshTokenRepository
+	sessionRepo           repository.SessionRepository
+	passwordResetRepo     repository.PasswordResetRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	mfaRepo               repository.MFARepository
+	sessionDenylist       *SessionDenylist
+	loginThrottle         *LoginThrottle
+	mailer                mailer.Mailer
+	auditLog              audit.Logger
+	jwtUtil               *utils.JWT
+	config                AuthConfig
 }
 
 // NewAuthService creates a new instance of AuthService
-func NewAuthService(authRepo repository.AuthRepository, jwtUtil *utils.JWT) AuthService {
+func NewAuthService(
+	authRepo repository.AuthRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
+	passwordResetRepo repository.PasswordResetRepository,
+	emailVerificationRepo repository.EmailVerificationRepository,
+	mfaRepo repository.MFARepository,
+	sessionDenylist *SessionDenylist,
+	loginThrottle *LoginThrottle,
+	mailer mailer.Mailer,
+	auditLog audit.Logger,
+	jwtUtil *utils.JWT,
+	config AuthConfig,
+) AuthService {
+	config.AppURL = strings.TrimRight(config.AppURL, "/")
+	if config.EmailVerificationPolicy == "" {
+		config.EmailVerificationPolicy = EmailVerificationBlockLogin
+	}
+	if config.MFAIssuer == "" {
+		config.MFAIssuer = DefaultMFAIssuer
+	}
+
 	return &authServiceImpl{
-		authRepo: authRepo,
-		jwtUtil:  jwtUtil,
+		authRepo:              authRepo,
+		refreshTokenRepo:      refreshTokenRepo,
+		sessionRepo:           sessionRepo,
+		passwordResetRepo:     passwordResetRepo,
+		emailVerificationRepo: emailVerificationRepo,
+		mfaRepo:               mfaRepo,
+		sessionDenylist:       sessionDenylist,
+		loginThrottle:         loginThrottle,
+		mailer:                mailer,
+		auditLog:              auditLog,
+		jwtUtil:               jwtUtil,
+		config:                config,
 	}
 }
 
@@ -40,16 +97,8 @@ func (s *authServiceImpl) Register(ctx context.Context, req *models.CreateUserRe
 		return nil, errors.New("invalid email format")
 	}
 
-	if req.Password == "" {
-		return nil, errors.New("password is required")
-	}
-
-	if len([]rune(req.Password)) < 8 {
-		return nil, errors.New("password must be at least 8 characters long")
-	}
-
-	if len([]rune(req.Password)) > 128 {
-		return nil, errors.New("password must be less than 128 characters")
+	if err := validatePassword(req.Password); err != nil {
+		return nil, err
 	}
 
 	// Hash password
@@ -64,6 +113,7 @@ func (s *authServiceImpl) Register(ctx context.Context, req *models.CreateUserRe
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
 		Password:  string(hashedPassword),
+		Role:      models.RoleUser,
 	}
 
 	createdUser, err := s.authRepo.CreateUser(ctx, user)
@@ -71,10 +121,15 @@ func (s *authServiceImpl) Register(ctx context.Context, req *models.CreateUserRe
 		return nil, errors.New("error creating user")
 	}
 
+	// The account exists even if the email cannot be sent; the user can ask for a new one
+	if err := s.sendVerificationEmail(ctx, createdUser); err != nil {
+		log.Printf("Failed to create email verification for user %d: %v", createdUser.ID, err)
+	}
+
 	return createdUser, nil
 }
 
-func (s *authServiceImpl) Login(ctx context.Context, req *models.LoginUserRequest) (*models.Token, error) {
+func (s *authServiceImpl) Login(ctx context.Context, req *models.LoginUserRequest, client *models.ClientInfo) (*models.Token, error) {
 	// Validate request
 	if req == nil {
 		return nil, errors.New("request cannot be nil")
@@ -84,30 +139,69 @@ func (s *authServiceImpl) Login(ctx context.Context, req *models.LoginUserReques
 		return nil, errors.New("email and password are required")
 	}
 
+	var ip string
+	if client != nil {
+		ip = client.IPAddress
+	}
+
+	// Locked out emails and IPs are refused before the password is even checked
+	retryAfter, err := s.loginThrottle.Check(ctx, req.Email, ip)
+	if err != nil {
+		return nil, errors.New("error retrieving user")
+	}
+	if retryAfter > 0 {
+		return nil, &LoginThrottledError{RetryAfter: retryAfter}
+	}
+
 	// Get user by email
 	user, err := s.authRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("invalid email or password")
+			return nil, s.loginFailed(ctx, nil, req.Email, ip)
 		}
 		return nil, errors.New("error retrieving user")
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, s.loginFailed(ctx, user, req.Email, ip)
+	}
+
+	if err := s.loginThrottle.Reset(ctx, req.Email); err != nil {
+		log.Printf("Failed to reset login attempts of user %d: %v", user.ID, err)
+	}
+
+	if err := checkAccountStatus(user); err != nil {
+		return nil, err
+	}
+
+	if !user.EmailVerified && s.config.EmailVerificationPolicy == EmailVerificationBlockLogin {
+		return nil, errors.New("email not verified")
 	}
 
-	// Generate token
-	token, err := s.jwtUtil.CreateToken(user)
+	// With two-factor authentication the login only completes at /mfa/verify
+	mfaEnabled, err := s.isMFAEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, errors.New("error retrieving user")
+	}
+	if mfaEnabled {
+		mfaToken, err := s.jwtUtil.CreateMFAToken(user)
+		if err != nil {
+			return nil, errors.New("error generating token")
+		}
+		return &models.Token{MFARequired: true, MFAToken: mfaToken}, nil
+	}
+
+	// Every login starts a new session, which is also the refresh token family
+	session, err := s.createSession(ctx, user, client, utils.AuthMethodPassword)
 	if err != nil {
 		return nil, errors.New("error generating token")
 	}
 
-	return token, nil
+	return s.issueTokens(ctx, user, session)
 }
 
-func (s *authServiceImpl) RefreshToken(ctx context.Context, req *models.RefreshTokenRequest) (*models.Token, error) {
+func (s *authServiceImpl) RefreshToken(ctx context.Context, req *models.RefreshTokenRequest, client *models.ClientInfo) (*models.Token, error) {
 	// Validate request
 	if req == nil {
 		return nil, errors.New("request cannot be nil")
@@ -117,21 +211,341 @@ func (s *authServiceImpl) RefreshToken(ctx context.Context, req *models.RefreshT
 		return nil, errors.New("refresh token is required")
 	}
 
-	// Refresh the access token using the refresh token
-	tokenPair, err := s.jwtUtil.RefreshAccessToken(req.RefreshToken)
+	claims, err := s.jwtUtil.ValidateRefreshToken(req.RefreshToken)
 	if err != nil {
 		return nil, errors.New("invalid or expired refresh token")
 	}
 
-	// Convert to the existing Token model for backward compatibility
-	token := &models.Token{
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
+	stored, err := s.refreshTokenRepo.GetByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired refresh token")
+		}
+		return nil, errors.New("error refreshing token")
+	}
+
+	if stored.RevokedAt != nil || stored.UserID != claims.UserID {
+		return nil, errors.New("invalid or expired refresh token")
+	}
+
+	// A token that was already rotated is being replayed: either the client or
+	// an attacker holds a stolen copy, so the whole session is revoked.
+	rotated := false
+	if stored.RotatedAt == nil {
+		rotated, err = s.refreshTokenRepo.MarkRotated(ctx, stored.ID)
+		if err != nil {
+			return nil, errors.New("error refreshing token")
+		}
+	}
+	if !rotated {
+		log.Printf("Refresh token reuse detected for user %d, revoking session", stored.UserID)
+		if err := s.revokeSession(ctx, stored.FamilyID); err != nil {
+			log.Printf("Failed to revoke session: %v", err)
+		}
+		return nil, errors.New("invalid or expired refresh token")
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, uint(stored.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired refresh token")
+		}
+		return nil, errors.New("error refreshing token")
+	}
+	if user.Disabled() {
+		return nil, errors.New("invalid or expired refresh token")
+	}
+
+	// The new tokens keep the authentication methods of the session's login.
+	// Families issued before sessions existed have no session row.
+	session, err := s.sessionRepo.GetByID(ctx, stored.FamilyID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("error refreshing token")
+		}
+		session = &models.Session{ID: stored.FamilyID}
+	}
+
+	token, err := s.issueTokens(ctx, user, session)
+	if err != nil {
+		return nil, errors.New("error refreshing token")
+	}
+
+	if err := s.sessionRepo.Touch(ctx, stored.FamilyID, client, time.Now().Add(utils.RefreshTokenTTL)); err != nil {
+		log.Printf("Failed to update session %s: %v", stored.FamilyID, err)
 	}
 
 	return token, nil
 }
 
+func (s *authServiceImpl) Logout(ctx context.Context, userID uint, req *models.RefreshTokenRequest) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if req == nil || req.RefreshToken == "" {
+		return errors.New("refresh token is required")
+	}
+
+	stored, err := s.refreshTokenRepo.GetByHash(ctx, utils.HashToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired refresh token")
+		}
+		return err
+	}
+
+	if stored.UserID != uint64(userID) {
+		return errors.New("invalid or expired refresh token")
+	}
+
+	return s.revokeSession(ctx, stored.FamilyID)
+}
+
+func (s *authServiceImpl) LogoutAll(ctx context.Context, userID uint) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, uint64(userID))
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionRepo.RevokeAllForUser(ctx, uint64(userID)); err != nil {
+		return err
+	}
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, uint64(userID)); err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		s.sessionDenylist.Revoke(session.ID)
+	}
+
+	return nil
+}
+
+func (s *authServiceImpl) GetSessions(ctx context.Context, userID uint, currentSessionID string) ([]models.Session, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, uint64(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+func (s *authServiceImpl) RevokeSession(ctx context.Context, userID uint, sessionID string) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("session not found")
+		}
+		return err
+	}
+
+	// Other users' sessions are reported exactly like missing ones
+	if session.UserID != uint64(userID) || session.RevokedAt != nil {
+		return errors.New("session not found")
+	}
+
+	return s.revokeSession(ctx, session.ID)
+}
+
+func (s *authServiceImpl) ForgotPassword(ctx context.Context, req *models.ForgotPasswordRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Email == "" {
+		return errors.New("email is required")
+	}
+
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		return errors.New("invalid email format")
+	}
+
+	user, err := s.authRepo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		// Unknown emails succeed silently so the endpoint cannot be used to
+		// find out which addresses have an account
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Only the most recently requested link stays valid
+	if err := s.passwordResetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	resetToken := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().UTC().Add(PasswordResetTTL),
+	}
+	if _, err := s.passwordResetRepo.Create(ctx, resetToken); err != nil {
+		return err
+	}
+
+	// Delivery happens in the background: waiting for the mail server would
+	// make the response time reveal that the account exists.
+	s.sendMail(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("We received a request to reset the password of your account.\n\n%s\n\n"+
+			"It expires in %d minutes and can only be used once. If you did not ask for a password reset, "+
+			"you can ignore this email.\n",
+			s.tokenInstructions("reset your password", "/reset-password", token), int(PasswordResetTTL.Minutes())),
+	})
+
+	return nil
+}
+
+func (s *authServiceImpl) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Token == "" {
+		return errors.New("reset token is required")
+	}
+
+	if err := validatePassword(req.Password); err != nil {
+		return err
+	}
+
+	resetToken, err := s.passwordResetRepo.GetByHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired reset token")
+		}
+		return err
+	}
+
+	if resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return errors.New("invalid or expired reset token")
+	}
+
+	// Consuming the token first guarantees it is redeemed at most once
+	used, err := s.passwordResetRepo.MarkUsed(ctx, resetToken.ID)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errors.New("invalid or expired reset token")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("error processing password")
+	}
+
+	if err := s.authRepo.UpdatePassword(ctx, resetToken.UserID, string(hashedPassword)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired reset token")
+		}
+		return err
+	}
+
+	if err := s.passwordResetRepo.InvalidateAllForUser(ctx, resetToken.UserID); err != nil {
+		log.Printf("Failed to invalidate password reset tokens of user %d: %v", resetToken.UserID, err)
+	}
+
+	// Whoever knew the old password must not stay logged in
+	return s.LogoutAll(ctx, uint(resetToken.UserID))
+}
+
+func (s *authServiceImpl) VerifyEmail(ctx context.Context, req *models.VerifyEmailRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Token == "" {
+		return errors.New("verification token is required")
+	}
+
+	verificationToken, err := s.emailVerificationRepo.GetByHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired verification token")
+		}
+		return err
+	}
+
+	if verificationToken.UsedAt != nil || time.Now().After(verificationToken.ExpiresAt) {
+		return errors.New("invalid or expired verification token")
+	}
+
+	used, err := s.emailVerificationRepo.MarkUsed(ctx, verificationToken.ID)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errors.New("invalid or expired verification token")
+	}
+
+	if verificationToken.Email != "" {
+		return s.completeEmailChange(ctx, verificationToken)
+	}
+
+	if err := s.authRepo.MarkEmailVerified(ctx, verificationToken.UserID, time.Now().UTC()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired verification token")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *authServiceImpl) ResendVerification(ctx context.Context, req *models.ResendVerificationRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Email == "" {
+		return errors.New("email is required")
+	}
+
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		return errors.New("invalid email format")
+	}
+
+	user, err := s.authRepo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		// Like ForgotPassword, unknown emails are not reported
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	return s.sendVerificationEmail(ctx, user)
+}
+
 func (s *authServiceImpl) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	if email == "" {
 		return nil, errors.New("email is required")
@@ -163,3 +577,197 @@ func (s *authServiceImpl) GetUserByID(ctx context.Context, id uint) (*models.Use
 
 	return user, nil
 }
+
+// createSession starts a new login session for the user, who authenticated with authMethods
+func (s *authServiceImpl) createSession(ctx context.Context, user *models.User, client *models.ClientInfo, authMethods ...string) (*models.Session, error) {
+	sessionID, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	session := &models.Session{
+		ID:              sessionID,
+		UserID:          user.ID,
+		LastUsedAt:      now,
+		ExpiresAt:       now.Add(utils.RefreshTokenTTL),
+		AuthMethods:     strings.Join(authMethods, ","),
+		AuthenticatedAt: now,
+	}
+	if client != nil {
+		session.UserAgent = truncate(client.UserAgent, 512)
+		session.IPAddress = truncate(client.IPAddress, 64)
+	}
+
+	return s.sessionRepo.Create(ctx, session)
+}
+
+// revokeSession ends a session: its refresh tokens stop working immediately
+// and its access tokens are rejected by the session denylist.
+func (s *authServiceImpl) revokeSession(ctx context.Context, sessionID string) error {
+	if err := s.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, sessionID); err != nil {
+		return err
+	}
+
+	s.sessionDenylist.Revoke(sessionID)
+	return nil
+}
+
+// issueTokens creates a token pair for the user's session and persists the refresh token
+func (s *authServiceImpl) issueTokens(ctx context.Context, user *models.User, session *models.Session) (*models.Token, error) {
+	tokenPair, err := s.jwtUtil.CreateTokenPair(user, session)
+	if err != nil {
+		return nil, errors.New("error generating token")
+	}
+
+	refreshToken := &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  session.ID,
+		TokenHash: utils.HashToken(tokenPair.RefreshToken),
+		ExpiresAt: tokenPair.RefreshExpiresAt,
+	}
+	if _, err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, errors.New("error generating token")
+	}
+
+	// Convert to the existing Token model for backward compatibility
+	return &models.Token{
+		AccessToken:  tokenPair.AccessToken,
+		RefreshToken: tokenPair.RefreshToken,
+	}, nil
+}
+
+// sendMail delivers msg in the background, logging failures
+func (s *authServiceImpl) sendMail(msg mailer.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			log.Printf("Failed to send %q email: %v", msg.Subject, err)
+		}
+	}()
+}
+
+// sendVerificationEmail issues a new email verification token for the user
+// and emails it. Previously issued tokens stop working.
+func (s *authServiceImpl) sendVerificationEmail(ctx context.Context, user *models.User) error {
+	token, err := s.createVerificationToken(ctx, user.ID, "")
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Welcome! Please confirm that this is your email address.\n\n%s\n\n"+
+			"It expires in %d hours. If you did not create an account, you can ignore this email.\n",
+			s.tokenInstructions("verify your email", "/verify-email", token), int(EmailVerificationTTL.Hours())),
+	})
+
+	return nil
+}
+
+// createVerificationToken replaces the outstanding verification tokens of the
+// user, including pending email changes, with a new one. email is the new
+// address of an email change, or empty to verify the current address.
+func (s *authServiceImpl) createVerificationToken(ctx context.Context, userID uint64, email string) (string, error) {
+	if err := s.emailVerificationRepo.InvalidateAllForUser(ctx, userID); err != nil {
+		return "", err
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	verificationToken := &models.EmailVerificationToken{
+		UserID:    userID,
+		Email:     email,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().UTC().Add(EmailVerificationTTL),
+	}
+	if _, err := s.emailVerificationRepo.Create(ctx, verificationToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// tokenInstructions tells the user how to use an emailed token: a link to the
+// web client, or the raw token to post to the API when no client is configured.
+func (s *authServiceImpl) tokenInstructions(action, path, token string) string {
+	if s.config.AppURL == "" {
+		return fmt.Sprintf("Use this token to %s: %s", action, token)
+	}
+	return fmt.Sprintf("Open this link to %s: %s%s?token=%s", action, s.config.AppURL, path, token)
+}
+
+// loginFailed counts a failed login and returns the error to answer it with.
+// user is nil when no account has the email. Unknown emails are counted too,
+// so responses do not tell them apart from wrong passwords.
+func (s *authServiceImpl) loginFailed(ctx context.Context, user *models.User, email, ip string) error {
+	lockout, err := s.loginThrottle.RecordFailure(ctx, email, ip)
+	if err != nil {
+		log.Printf("Failed to record failed login: %v", err)
+		return errors.New("invalid email or password")
+	}
+	if lockout == 0 {
+		return errors.New("invalid email or password")
+	}
+
+	if user != nil {
+		s.auditLog.Record(ctx, audit.Event{
+			Type:    audit.EventAccountLocked,
+			Time:    time.Now().UTC(),
+			UserID:  user.ID,
+			Email:   user.Email,
+			IP:      ip,
+			Details: map[string]string{"lockout": lockout.String()},
+		})
+	}
+	return &LoginThrottledError{RetryAfter: lockout}
+}
+
+// checkAccountStatus refuses logins to accounts an administrator disabled or
+// required a password reset for
+func checkAccountStatus(user *models.User) error {
+	if user.Disabled() {
+		return errors.New("account disabled")
+	}
+	if user.PasswordResetRequired {
+		return errors.New("password reset required")
+	}
+	return nil
+}
+
+// validatePassword checks the password policy
+func validatePassword(password string) error {
+	if password == "" {
+		return errors.New("password is required")
+	}
+
+	if len([]rune(password)) < 8 {
+		return errors.New("password must be at least 8 characters long")
+	}
+
+	if len([]rune(password)) > 128 {
+		return errors.New("password must be less than 128 characters")
+	}
+
+	return nil
+}
+
+// truncate shortens s to at most max bytes without splitting a UTF-8 sequence
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
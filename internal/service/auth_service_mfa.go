@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// Two-factor authentication settings
+const (
+	recoveryCodeCount = 10
+	// maxMFAAttempts consecutive wrong codes lock the second step for mfaLockout
+	maxMFAAttempts = 5
+	mfaLockout     = 15 * time.Minute
+)
+
+func (s *authServiceImpl) EnrollMFA(ctx context.Context, userID uint) (*models.MFAEnrollment, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := s.isMFAEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, errors.New("mfa already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.SaveCredential(ctx, &models.MFACredential{UserID: user.ID, Secret: secret}); err != nil {
+		return nil, err
+	}
+
+	return &models.MFAEnrollment{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.config.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+func (s *authServiceImpl) ConfirmMFA(ctx context.Context, userID uint, req *models.ConfirmMFARequest) (*models.RecoveryCodes, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if req == nil || req.Code == "" {
+		return nil, errors.New("mfa code is required")
+	}
+
+	credential, err := s.mfaRepo.GetCredential(ctx, uint64(userID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("mfa enrollment not started")
+		}
+		return nil, err
+	}
+	if credential.ConfirmedAt != nil {
+		return nil, errors.New("mfa already enabled")
+	}
+
+	step, ok := utils.ValidateTOTP(credential.Secret, req.Code, time.Now())
+	if !ok {
+		return nil, errors.New("invalid mfa code")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.Confirm(ctx, credential.UserID, step, hashes); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("mfa already enabled")
+		}
+		return nil, err
+	}
+
+	return &models.RecoveryCodes{Codes: codes}, nil
+}
+
+func (s *authServiceImpl) VerifyMFA(ctx context.Context, req *models.VerifyMFARequest, client *models.ClientInfo) (*models.Token, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	if req.MFAToken == "" {
+		return nil, errors.New("mfa token is required")
+	}
+
+	if req.Code == "" && req.RecoveryCode == "" {
+		return nil, errors.New("mfa code is required")
+	}
+
+	claims, err := s.jwtUtil.ValidateMFAToken(req.MFAToken)
+	if err != nil {
+		return nil, errors.New("invalid or expired mfa token")
+	}
+
+	credential, err := s.mfaRepo.GetCredential(ctx, claims.UserID)
+	if err != nil {
+		// Two-factor authentication was disabled since the password step
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired mfa token")
+		}
+		return nil, errors.New("error verifying mfa code")
+	}
+	if credential.ConfirmedAt == nil {
+		return nil, errors.New("invalid or expired mfa token")
+	}
+
+	if credential.LockedUntil != nil && time.Now().Before(*credential.LockedUntil) {
+		return nil, errors.New("too many failed attempts")
+	}
+
+	valid, err := s.checkSecondFactor(ctx, credential, req)
+	if err != nil {
+		return nil, errors.New("error verifying mfa code")
+	}
+	if !valid {
+		lockedUntil := time.Now().UTC().Add(mfaLockout)
+		if err := s.mfaRepo.RecordFailedAttempt(ctx, credential.UserID, maxMFAAttempts, lockedUntil); err != nil {
+			log.Printf("Failed to record MFA attempt of user %d: %v", credential.UserID, err)
+		}
+		return nil, errors.New("invalid mfa code")
+	}
+
+	if credential.FailedAttempts > 0 || credential.LockedUntil != nil {
+		if err := s.mfaRepo.ResetFailedAttempts(ctx, credential.UserID); err != nil {
+			log.Printf("Failed to reset MFA attempts of user %d: %v", credential.UserID, err)
+		}
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, uint(claims.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid or expired mfa token")
+		}
+		return nil, errors.New("error retrieving user")
+	}
+	if err := checkAccountStatus(user); err != nil {
+		return nil, err
+	}
+
+	session, err := s.createSession(ctx, user, client, utils.AuthMethodPassword, utils.AuthMethodOTP)
+	if err != nil {
+		return nil, errors.New("error generating token")
+	}
+
+	return s.issueTokens(ctx, user, session)
+}
+
+func (s *authServiceImpl) RegenerateRecoveryCodes(ctx context.Context, userID uint) (*models.RecoveryCodes, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	enabled, err := s.isMFAEnabled(ctx, uint64(userID))
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, errors.New("mfa not enabled")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, uint64(userID), hashes); err != nil {
+		return nil, err
+	}
+
+	return &models.RecoveryCodes{Codes: codes}, nil
+}
+
+func (s *authServiceImpl) DisableMFA(ctx context.Context, userID uint) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	enabled, err := s.isMFAEnabled(ctx, uint64(userID))
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return errors.New("mfa not enabled")
+	}
+
+	return s.mfaRepo.Delete(ctx, uint64(userID))
+}
+
+// isMFAEnabled reports whether the user has a confirmed TOTP credential
+func (s *authServiceImpl) isMFAEnabled(ctx context.Context, userID uint64) (bool, error) {
+	credential, err := s.mfaRepo.GetCredential(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return credential.ConfirmedAt != nil, nil
+}
+
+// checkSecondFactor verifies the TOTP or recovery code of req. Each TOTP code
+// and recovery code is accepted only once.
+func (s *authServiceImpl) checkSecondFactor(ctx context.Context, credential *models.MFACredential, req *models.VerifyMFARequest) (bool, error) {
+	if req.Code != "" {
+		step, ok := utils.ValidateTOTP(credential.Secret, req.Code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		return s.mfaRepo.UseStep(ctx, credential.UserID, step)
+	}
+
+	return s.mfaRepo.UseRecoveryCode(ctx, credential.UserID, utils.HashToken(normalizeRecoveryCode(req.RecoveryCode)))
+}
+
+// generateRecoveryCodes returns new recovery codes and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		secret, err := utils.GenerateTOTPSecret()
+		if err != nil {
+			return nil, nil, err
+		}
+		// 10 base32 characters (50 bits), shown as two groups of five
+		code := strings.ToLower(secret[:10])
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = utils.HashToken(code)
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode ignores case, dashes and spaces the user may type
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
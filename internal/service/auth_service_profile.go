@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"time"
+	"todo-list-api/internal/mailer"
+	"todo-list-api/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func (s *authServiceImpl) UpdateProfile(ctx context.Context, userID uint, req *models.UpdateProfileRequest) (*models.User, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FirstName != nil {
+		user.FirstName = *req.FirstName
+	}
+	if req.LastName != nil {
+		user.LastName = *req.LastName
+	}
+	if prefs := req.Preferences; prefs != nil {
+		if prefs.Timezone != nil {
+			if _, err := time.LoadLocation(*prefs.Timezone); err != nil {
+				return nil, errors.New("invalid timezone")
+			}
+			user.Preferences.Timezone = *prefs.Timezone
+		}
+		if prefs.WeekStart != nil {
+			if *prefs.WeekStart != "" && *prefs.WeekStart != "monday" && *prefs.WeekStart != "sunday" {
+				return nil, errors.New("invalid week start")
+			}
+			user.Preferences.WeekStart = *prefs.WeekStart
+		}
+	}
+
+	if err := s.authRepo.UpdateProfile(ctx, user); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *authServiceImpl) ChangePassword(ctx context.Context, userID uint, currentSessionID string, req *models.ChangePasswordRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.CurrentPassword == "" {
+		return errors.New("current password is required")
+	}
+
+	if err := validatePassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkPassword(user, req.CurrentPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("error processing password")
+	}
+
+	if err := s.authRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	if err := s.passwordResetRepo.InvalidateAllForUser(ctx, user.ID); err != nil {
+		log.Printf("Failed to invalidate password reset tokens of user %d: %v", user.ID, err)
+	}
+
+	// Like a reset, but the client changing the password stays logged in
+	sessions, err := s.sessionRepo.ListActiveByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if err := s.revokeSession(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *authServiceImpl) ChangeEmail(ctx context.Context, userID uint, req *models.ChangeEmailRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Email == "" {
+		return errors.New("email is required")
+	}
+
+	if _, err := mail.ParseAddress(req.Email); err != nil {
+		return errors.New("invalid email format")
+	}
+
+	if req.Password == "" {
+		return errors.New("password is required")
+	}
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkPassword(user, req.Password); err != nil {
+		return err
+	}
+
+	if req.Email == user.Email {
+		return errors.New("email unchanged")
+	}
+
+	if err := s.checkEmailAvailable(ctx, req.Email); err != nil {
+		return err
+	}
+
+	token, err := s.createVerificationToken(ctx, user.ID, req.Email)
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(mailer.Message{
+		To:      req.Email,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf("Please confirm that this is the new email address of your account.\n\n%s\n\n"+
+			"It expires in %d hours. If you did not ask for this change, you can ignore this email.\n",
+			s.tokenInstructions("confirm your new email address", "/verify-email", token), int(EmailVerificationTTL.Hours())),
+	})
+
+	// Warn the current address, in case someone else is taking over the account
+	s.sendMail(mailer.Message{
+		To:      user.Email,
+		Subject: "Your email address is being changed",
+		Body: fmt.Sprintf("A change of your account's email address to %s was requested. It takes effect "+
+			"once the new address is confirmed.\n\nIf you did not ask for this change, change your password.\n", req.Email),
+	})
+
+	return nil
+}
+
+func (s *authServiceImpl) DeleteAccount(ctx context.Context, userID uint, req *models.DeleteAccountRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if req.Password == "" {
+		return errors.New("password is required")
+	}
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkPassword(user, req.Password); err != nil {
+		return err
+	}
+
+	// Sessions end first, so that a failure leaves an account that can retry
+	if err := s.LogoutAll(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.authRepo.DeleteUser(ctx, user.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// completeEmailChange switches the user of a consumed verification token to
+// the new address it verified
+func (s *authServiceImpl) completeEmailChange(ctx context.Context, token *models.EmailVerificationToken) error {
+	// Another account may have taken the address since the change was requested
+	if err := s.checkEmailAvailable(ctx, token.Email); err != nil {
+		return err
+	}
+
+	if err := s.authRepo.UpdateEmail(ctx, token.UserID, token.Email, time.Now().UTC()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("invalid or expired verification token")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// checkEmailAvailable fails when an account already has the email
+func (s *authServiceImpl) checkEmailAvailable(ctx context.Context, email string) error {
+	_, err := s.authRepo.GetUserByEmail(ctx, email)
+	if err == nil {
+		return errors.New("email already in use")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// checkPassword compares password with the user's password hash
+func checkPassword(user *models.User, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return errors.New("incorrect password")
+	}
+	return nil
+}
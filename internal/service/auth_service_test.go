@@ -0,0 +1,920 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+	"todo-list-api/internal/audit"
+	"todo-list-api/internal/mailer"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/service/mocks"
+	"todo-list-api/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type AuthServiceTestSuite struct {
+	suite.Suite
+	mockAuthRepo          *mocks.MockAuthRepository
+	mockRefreshTokenRepo  *mocks.MockRefreshTokenRepository
+	mockSessionRepo       *mocks.MockSessionRepository
+	mockPasswordResetRepo *mocks.MockPasswordResetRepository
+	mockVerificationRepo  *mocks.MockEmailVerificationRepository
+	mockMFARepo           *mocks.MockMFARepository
+	mailer                *mailer.MemoryMailer
+	sessionDenylist       *SessionDenylist
+	loginThrottle         *LoginThrottle
+	auditLog              *audit.MemoryLogger
+	jwtUtil               *utils.JWT
+	service               AuthService
+	ctx                   context.Context
+	user                  *models.User
+}
+
+func (suite *AuthServiceTestSuite) SetupTest() {
+	suite.mockAuthRepo = new(mocks.MockAuthRepository)
+	suite.mockRefreshTokenRepo = new(mocks.MockRefreshTokenRepository)
+	suite.mockSessionRepo = new(mocks.MockSessionRepository)
+	suite.mockPasswordResetRepo = new(mocks.MockPasswordResetRepository)
+	suite.mockVerificationRepo = new(mocks.MockEmailVerificationRepository)
+	suite.mockMFARepo = new(mocks.MockMFARepository)
+	suite.mailer = mailer.NewMemoryMailer()
+	suite.sessionDenylist = NewSessionDenylist(suite.mockSessionRepo)
+	suite.loginThrottle = NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), DefaultLoginThrottleConfig())
+	suite.auditLog = audit.NewMemoryLogger()
+	suite.jwtUtil = &utils.JWT{Secret: "test-secret"}
+	suite.service = suite.newService(EmailVerificationBlockLogin)
+	suite.ctx = context.Background()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	suite.user = &models.User{ID: 1, Email: "user@example.com", Password: string(hashedPassword), EmailVerified: true}
+}
+
+// newService creates an AuthService over the suite's mocks with the given verification policy
+func (suite *AuthServiceTestSuite) newService(policy EmailVerificationPolicy) AuthService {
+	return NewAuthService(
+		suite.mockAuthRepo,
+		suite.mockRefreshTokenRepo,
+		suite.mockSessionRepo,
+		suite.mockPasswordResetRepo,
+		suite.mockVerificationRepo,
+		suite.mockMFARepo,
+		suite.sessionDenylist,
+		suite.loginThrottle,
+		suite.mailer,
+		suite.auditLog,
+		suite.jwtUtil,
+		AuthConfig{AppURL: "https://app.example.com", EmailVerificationPolicy: policy},
+	)
+}
+
+// mfaCredential returns an enabled TOTP credential of the suite's user
+func (suite *AuthServiceTestSuite) mfaCredential() *models.MFACredential {
+	secret, err := utils.GenerateTOTPSecret()
+	suite.Require().NoError(err)
+
+	confirmedAt := time.Now().Add(-time.Hour)
+	return &models.MFACredential{UserID: suite.user.ID, Secret: secret, ConfirmedAt: &confirmedAt}
+}
+
+// emailedToken waits for the next email and extracts the token from its link
+func (suite *AuthServiceTestSuite) emailedToken(path string) (mailer.Message, string) {
+	msg, ok := suite.mailer.Wait(time.Second)
+	suite.Require().True(ok, "no email was sent")
+
+	link := regexp.MustCompile(regexp.QuoteMeta(path) + `\?token=(\S+)`).FindStringSubmatch(msg.Body)
+	suite.Require().Len(link, 2, "email does not contain a %s link", path)
+	return msg, link[1]
+}
+
+// issueRefreshToken returns a signed refresh token and its stored record
+func (suite *AuthServiceTestSuite) issueRefreshToken(familyID string) (string, *models.RefreshToken) {
+	pair, err := suite.jwtUtil.CreateTokenPair(suite.user, &models.Session{ID: familyID})
+	suite.Require().NoError(err)
+
+	return pair.RefreshToken, &models.RefreshToken{
+		ID:        10,
+		UserID:    suite.user.ID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(pair.RefreshToken),
+		ExpiresAt: pair.RefreshExpiresAt,
+	}
+}
+
+// TestLogin_CreatesSession tests that login starts a session and stores the hashed refresh token in its family
+func (suite *AuthServiceTestSuite) TestLogin_CreatesSession() {
+	// Arrange
+	req := &models.LoginUserRequest{Email: suite.user.Email, Password: "password123"}
+	client := &models.ClientInfo{UserAgent: "curl/8.0", IPAddress: "203.0.113.7"}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockSessionRepo.On("Create", suite.ctx, mock.MatchedBy(func(session *models.Session) bool {
+		return session.UserID == suite.user.ID && session.UserAgent == "curl/8.0" && session.IPAddress == "203.0.113.7"
+	})).Return(&models.Session{ID: "session-1", UserID: suite.user.ID}, nil)
+	suite.mockRefreshTokenRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.RefreshToken")).
+		Return(&models.RefreshToken{}, nil)
+
+	// Act
+	token, err := suite.service.Login(suite.ctx, req, client)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	stored := suite.mockRefreshTokenRepo.Calls[0].Arguments.Get(1).(*models.RefreshToken)
+	assert.Equal(suite.T(), utils.HashToken(token.RefreshToken), stored.TokenHash)
+	assert.Equal(suite.T(), "session-1", stored.FamilyID)
+
+	claims, err := suite.jwtUtil.ValidateAccessToken(token.AccessToken)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "session-1", claims.SessionID)
+}
+
+// TestRefreshToken_Rotates tests that a refresh issues a new refresh token in the same family
+func (suite *AuthServiceTestSuite) TestRefreshToken_Rotates() {
+	// Arrange
+	refreshToken, stored := suite.issueRefreshToken("family-1")
+
+	suite.mockRefreshTokenRepo.On("GetByHash", suite.ctx, stored.TokenHash).Return(stored, nil)
+	suite.mockRefreshTokenRepo.On("MarkRotated", suite.ctx, stored.ID).Return(true, nil)
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(suite.user.ID)).Return(suite.user, nil)
+	suite.mockRefreshTokenRepo.On("Create", suite.ctx, mock.MatchedBy(func(token *models.RefreshToken) bool {
+		return token.FamilyID == "family-1" && token.TokenHash != stored.TokenHash
+	})).Return(&models.RefreshToken{}, nil)
+	suite.mockSessionRepo.On("GetByID", suite.ctx, "family-1").
+		Return(&models.Session{ID: "family-1", AuthMethods: "pwd,otp", AuthenticatedAt: time.Now().Add(-time.Hour)}, nil)
+	suite.mockSessionRepo.On("Touch", suite.ctx, "family-1", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	token, err := suite.service.RefreshToken(suite.ctx, &models.RefreshTokenRequest{RefreshToken: refreshToken}, nil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotEqual(suite.T(), refreshToken, token.RefreshToken)
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+
+	// The authentication methods of the login survive the refresh
+	claims, err := suite.jwtUtil.ValidateAccessToken(token.AccessToken)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"pwd", "otp"}, claims.AuthMethods)
+}
+
+// TestRefreshToken_ReuseRevokesFamily tests that replaying a rotated token revokes its family
+func (suite *AuthServiceTestSuite) TestRefreshToken_ReuseRevokesFamily() {
+	// Arrange
+	refreshToken, stored := suite.issueRefreshToken("family-1")
+	rotatedAt := time.Now().Add(-time.Minute)
+	stored.RotatedAt = &rotatedAt
+
+	suite.mockRefreshTokenRepo.On("GetByHash", suite.ctx, stored.TokenHash).Return(stored, nil)
+	suite.mockSessionRepo.On("Revoke", suite.ctx, "family-1").Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeFamily", suite.ctx, "family-1").Return(nil)
+
+	// Act
+	token, err := suite.service.RefreshToken(suite.ctx, &models.RefreshTokenRequest{RefreshToken: refreshToken}, nil)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), token)
+	assert.Equal(suite.T(), "invalid or expired refresh token", err.Error())
+	suite.mockRefreshTokenRepo.AssertCalled(suite.T(), "RevokeFamily", suite.ctx, "family-1")
+	suite.mockRefreshTokenRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+
+	revoked, err := suite.sessionDenylist.IsSessionRevoked(suite.ctx, "family-1")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), revoked)
+}
+
+// TestRefreshToken_Revoked tests that a revoked token cannot be used
+func (suite *AuthServiceTestSuite) TestRefreshToken_Revoked() {
+	// Arrange
+	refreshToken, stored := suite.issueRefreshToken("family-1")
+	revokedAt := time.Now().Add(-time.Minute)
+	stored.RevokedAt = &revokedAt
+
+	suite.mockRefreshTokenRepo.On("GetByHash", suite.ctx, stored.TokenHash).Return(stored, nil)
+
+	// Act
+	token, err := suite.service.RefreshToken(suite.ctx, &models.RefreshTokenRequest{RefreshToken: refreshToken}, nil)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), token)
+	suite.mockRefreshTokenRepo.AssertNotCalled(suite.T(), "MarkRotated", mock.Anything, mock.Anything)
+}
+
+// TestLogout_OtherUsersToken tests that a user cannot revoke another user's session
+func (suite *AuthServiceTestSuite) TestLogout_OtherUsersToken() {
+	// Arrange
+	refreshToken, stored := suite.issueRefreshToken("family-1")
+
+	suite.mockRefreshTokenRepo.On("GetByHash", suite.ctx, stored.TokenHash).Return(stored, nil)
+
+	// Act
+	err := suite.service.Logout(suite.ctx, 2, &models.RefreshTokenRequest{RefreshToken: refreshToken})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	suite.mockRefreshTokenRepo.AssertNotCalled(suite.T(), "RevokeFamily", mock.Anything, mock.Anything)
+}
+
+// TestRevokeSession_Success tests that revoking a session denies its access tokens
+func (suite *AuthServiceTestSuite) TestRevokeSession_Success() {
+	// Arrange
+	session := &models.Session{ID: "session-1", UserID: suite.user.ID}
+
+	suite.mockSessionRepo.On("GetByID", suite.ctx, "session-1").Return(session, nil)
+	suite.mockSessionRepo.On("Revoke", suite.ctx, "session-1").Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeFamily", suite.ctx, "session-1").Return(nil)
+
+	// Act
+	err := suite.service.RevokeSession(suite.ctx, uint(suite.user.ID), "session-1")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	revoked, _ := suite.sessionDenylist.IsSessionRevoked(suite.ctx, "session-1")
+	assert.True(suite.T(), revoked)
+	suite.mockSessionRepo.AssertNotCalled(suite.T(), "IsRevoked", mock.Anything, mock.Anything)
+}
+
+// TestRevokeSession_OtherUser tests that another user's session is reported as not found
+func (suite *AuthServiceTestSuite) TestRevokeSession_OtherUser() {
+	// Arrange
+	session := &models.Session{ID: "session-1", UserID: 2}
+
+	suite.mockSessionRepo.On("GetByID", suite.ctx, "session-1").Return(session, nil)
+
+	// Act
+	err := suite.service.RevokeSession(suite.ctx, uint(suite.user.ID), "session-1")
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "session not found", err.Error())
+	suite.mockSessionRepo.AssertNotCalled(suite.T(), "Revoke", mock.Anything, mock.Anything)
+}
+
+// TestSessionDenylist_CachesLookups tests that active sessions are looked up once per cache lifetime
+func (suite *AuthServiceTestSuite) TestSessionDenylist_CachesLookups() {
+	// Arrange
+	suite.mockSessionRepo.On("IsRevoked", suite.ctx, "session-1").Return(false, nil).Once()
+
+	// Act
+	first, err1 := suite.sessionDenylist.IsSessionRevoked(suite.ctx, "session-1")
+	second, err2 := suite.sessionDenylist.IsSessionRevoked(suite.ctx, "session-1")
+
+	// Assert
+	assert.NoError(suite.T(), err1)
+	assert.NoError(suite.T(), err2)
+	assert.False(suite.T(), first)
+	assert.False(suite.T(), second)
+	suite.mockSessionRepo.AssertNumberOfCalls(suite.T(), "IsRevoked", 1)
+}
+
+// TestForgotPassword_UnknownEmail tests that unknown emails succeed without sending anything
+func (suite *AuthServiceTestSuite) TestForgotPassword_UnknownEmail() {
+	// Arrange
+	req := &models.ForgotPasswordRequest{Email: "nobody@example.com"}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, req.Email).Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	err := suite.service.ForgotPassword(suite.ctx, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockPasswordResetRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	assert.Empty(suite.T(), suite.mailer.Messages())
+}
+
+// TestForgotPassword_SendsResetLink tests that the emailed token matches the stored hash
+func (suite *AuthServiceTestSuite) TestForgotPassword_SendsResetLink() {
+	// Arrange
+	req := &models.ForgotPasswordRequest{Email: suite.user.Email}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, req.Email).Return(suite.user, nil)
+	suite.mockPasswordResetRepo.On("InvalidateAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockPasswordResetRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.PasswordResetToken")).
+		Return(&models.PasswordResetToken{}, nil)
+
+	// Act
+	err := suite.service.ForgotPassword(suite.ctx, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	stored := suite.mockPasswordResetRepo.Calls[1].Arguments.Get(1).(*models.PasswordResetToken)
+	assert.Equal(suite.T(), suite.user.ID, stored.UserID)
+	assert.WithinDuration(suite.T(), time.Now().Add(PasswordResetTTL), stored.ExpiresAt, time.Minute)
+
+	msg, token := suite.emailedToken("https://app.example.com/reset-password")
+	assert.Equal(suite.T(), suite.user.Email, msg.To)
+	assert.Equal(suite.T(), stored.TokenHash, utils.HashToken(token))
+}
+
+// TestResetPassword_Success tests that a reset updates the password, consumes the token and ends all sessions
+func (suite *AuthServiceTestSuite) TestResetPassword_Success() {
+	// Arrange
+	req := &models.ResetPasswordRequest{Token: "reset-token", Password: "new-password"}
+	resetToken := &models.PasswordResetToken{ID: 5, UserID: suite.user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	session := models.Session{ID: "session-1", UserID: suite.user.ID}
+
+	suite.mockPasswordResetRepo.On("GetByHash", suite.ctx, utils.HashToken("reset-token")).Return(resetToken, nil)
+	suite.mockPasswordResetRepo.On("MarkUsed", suite.ctx, uint64(5)).Return(true, nil)
+	suite.mockAuthRepo.On("UpdatePassword", suite.ctx, suite.user.ID, mock.AnythingOfType("string")).Return(nil)
+	suite.mockPasswordResetRepo.On("InvalidateAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockSessionRepo.On("ListActiveByUserID", suite.ctx, suite.user.ID).Return([]models.Session{session}, nil)
+	suite.mockSessionRepo.On("RevokeAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeAllForUser", suite.ctx, suite.user.ID).Return(nil)
+
+	// Act
+	err := suite.service.ResetPassword(suite.ctx, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	hash := suite.mockAuthRepo.Calls[0].Arguments.String(2)
+	assert.NoError(suite.T(), bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")))
+	suite.mockSessionRepo.AssertExpectations(suite.T())
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+
+	revoked, err := suite.sessionDenylist.IsSessionRevoked(suite.ctx, "session-1")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), revoked)
+}
+
+// TestResetPassword_UsedToken tests that a token cannot be redeemed twice
+func (suite *AuthServiceTestSuite) TestResetPassword_UsedToken() {
+	// Arrange
+	req := &models.ResetPasswordRequest{Token: "reset-token", Password: "new-password"}
+	usedAt := time.Now().Add(-time.Minute)
+	resetToken := &models.PasswordResetToken{ID: 5, UserID: suite.user.ID, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+
+	suite.mockPasswordResetRepo.On("GetByHash", suite.ctx, utils.HashToken("reset-token")).Return(resetToken, nil)
+
+	// Act
+	err := suite.service.ResetPassword(suite.ctx, req)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "invalid or expired reset token", err.Error())
+	suite.mockAuthRepo.AssertNotCalled(suite.T(), "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestResetPassword_ExpiredToken tests that expired tokens are rejected
+func (suite *AuthServiceTestSuite) TestResetPassword_ExpiredToken() {
+	// Arrange
+	req := &models.ResetPasswordRequest{Token: "reset-token", Password: "new-password"}
+	resetToken := &models.PasswordResetToken{ID: 5, UserID: suite.user.ID, ExpiresAt: time.Now().Add(-time.Minute)}
+
+	suite.mockPasswordResetRepo.On("GetByHash", suite.ctx, utils.HashToken("reset-token")).Return(resetToken, nil)
+
+	// Act
+	err := suite.service.ResetPassword(suite.ctx, req)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "invalid or expired reset token", err.Error())
+	suite.mockPasswordResetRepo.AssertNotCalled(suite.T(), "MarkUsed", mock.Anything, mock.Anything)
+}
+
+// TestRegister_SendsVerificationEmail tests that registration emails a verification link matching the stored hash
+func (suite *AuthServiceTestSuite) TestRegister_SendsVerificationEmail() {
+	// Arrange
+	req := &models.CreateUserRequest{Email: "new@example.com", Password: "password123"}
+	created := &models.User{ID: 2, Email: req.Email}
+
+	suite.mockAuthRepo.On("CreateUser", suite.ctx, mock.AnythingOfType("*models.User")).Return(created, nil)
+	suite.mockVerificationRepo.On("InvalidateAllForUser", suite.ctx, created.ID).Return(nil)
+	suite.mockVerificationRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.EmailVerificationToken")).
+		Return(&models.EmailVerificationToken{}, nil)
+
+	// Act
+	user, err := suite.service.Register(suite.ctx, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), user.EmailVerified)
+	stored := suite.mockVerificationRepo.Calls[1].Arguments.Get(1).(*models.EmailVerificationToken)
+	assert.Equal(suite.T(), created.ID, stored.UserID)
+
+	msg, token := suite.emailedToken("https://app.example.com/verify-email")
+	assert.Equal(suite.T(), req.Email, msg.To)
+	assert.Equal(suite.T(), stored.TokenHash, utils.HashToken(token))
+}
+
+// TestLogin_UnverifiedBlocked tests that unverified users cannot log in under the blocking policy
+func (suite *AuthServiceTestSuite) TestLogin_UnverifiedBlocked() {
+	// Arrange
+	suite.user.EmailVerified = false
+	req := &models.LoginUserRequest{Email: suite.user.Email, Password: "password123"}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+
+	// Act
+	token, err := suite.service.Login(suite.ctx, req, nil)
+
+	// Assert
+	assert.Nil(suite.T(), token)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "email not verified", err.Error())
+	suite.mockSessionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestLogin_UnverifiedReadOnly tests that the read-only policy logs unverified users in with an unverified claim
+func (suite *AuthServiceTestSuite) TestLogin_UnverifiedReadOnly() {
+	// Arrange
+	suite.user.EmailVerified = false
+	service := suite.newService(EmailVerificationReadOnly)
+	req := &models.LoginUserRequest{Email: suite.user.Email, Password: "password123"}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockSessionRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.Session")).
+		Return(&models.Session{ID: "session-1", UserID: suite.user.ID}, nil)
+	suite.mockRefreshTokenRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.RefreshToken")).
+		Return(&models.RefreshToken{}, nil)
+
+	// Act
+	token, err := service.Login(suite.ctx, req, nil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	claims, err := suite.jwtUtil.ValidateAccessToken(token.AccessToken)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), claims.EmailVerified)
+}
+
+// TestLogin_RoleClaims tests that access tokens carry the user's role and its permissions
+func (suite *AuthServiceTestSuite) TestLogin_RoleClaims() {
+	// Arrange
+	suite.user.Role = models.RoleAdmin
+	req := &models.LoginUserRequest{Email: suite.user.Email, Password: "password123"}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockSessionRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.Session")).
+		Return(&models.Session{ID: "session-1", UserID: suite.user.ID}, nil)
+	suite.mockRefreshTokenRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.RefreshToken")).
+		Return(&models.RefreshToken{}, nil)
+
+	// Act
+	token, err := suite.service.Login(suite.ctx, req, nil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	claims, err := suite.jwtUtil.ValidateAccessToken(token.AccessToken)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), claims.HasRole(models.RoleAdmin))
+	assert.True(suite.T(), claims.HasPermission(models.PermissionUsersManage))
+}
+
+// TestLogin_LocksAfterFailures tests that repeated wrong passwords lock the account and record an audit event
+func (suite *AuthServiceTestSuite) TestLogin_LocksAfterFailures() {
+	// Arrange
+	wrong := &models.LoginUserRequest{Email: suite.user.Email, Password: "wrong-password"}
+	client := &models.ClientInfo{IPAddress: "203.0.113.7"}
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+
+	// Act
+	var err error
+	for i := 0; i < DefaultLoginThrottleConfig().MaxFailures; i++ {
+		_, err = suite.service.Login(suite.ctx, wrong, client)
+	}
+
+	// Assert
+	var throttled *LoginThrottledError
+	suite.Require().ErrorAs(err, &throttled)
+	assert.Equal(suite.T(), time.Minute, throttled.RetryAfter)
+
+	events := suite.auditLog.Events()
+	suite.Require().Len(events, 1)
+	assert.Equal(suite.T(), audit.EventAccountLocked, events[0].Type)
+	assert.Equal(suite.T(), suite.user.ID, events[0].UserID)
+	assert.Equal(suite.T(), "203.0.113.7", events[0].IP)
+
+	// Even the right password is refused while locked
+	_, err = suite.service.Login(suite.ctx, &models.LoginUserRequest{Email: suite.user.Email, Password: "password123"}, client)
+	suite.Require().ErrorAs(err, &throttled)
+	suite.mockSessionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestLogin_AccountStatus tests that disabled accounts and accounts with a forced password reset cannot log in
+func (suite *AuthServiceTestSuite) TestLogin_AccountStatus() {
+	disabledAt := time.Now()
+	cases := map[string]func(user *models.User){
+		"account disabled":        func(user *models.User) { user.DisabledAt = &disabledAt },
+		"password reset required": func(user *models.User) { user.PasswordResetRequired = true },
+	}
+
+	for expected, setup := range cases {
+		suite.Run(expected, func() {
+			// Arrange
+			suite.SetupTest()
+			setup(suite.user)
+			req := &models.LoginUserRequest{Email: suite.user.Email, Password: "password123"}
+			suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+
+			// Act
+			token, err := suite.service.Login(suite.ctx, req, nil)
+
+			// Assert
+			assert.Nil(suite.T(), token)
+			assert.EqualError(suite.T(), err, expected)
+			suite.mockSessionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// TestVerifyEmail_Success tests that a valid token verifies the user's email
+func (suite *AuthServiceTestSuite) TestVerifyEmail_Success() {
+	// Arrange
+	req := &models.VerifyEmailRequest{Token: "verify-token"}
+	verificationToken := &models.EmailVerificationToken{ID: 7, UserID: suite.user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	suite.mockVerificationRepo.On("GetByHash", suite.ctx, utils.HashToken("verify-token")).Return(verificationToken, nil)
+	suite.mockVerificationRepo.On("MarkUsed", suite.ctx, uint64(7)).Return(true, nil)
+	suite.mockAuthRepo.On("MarkEmailVerified", suite.ctx, suite.user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Act
+	err := suite.service.VerifyEmail(suite.ctx, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockAuthRepo.AssertExpectations(suite.T())
+}
+
+// TestVerifyEmail_TokenAlreadyUsed tests that a token consumed concurrently is rejected
+func (suite *AuthServiceTestSuite) TestVerifyEmail_TokenAlreadyUsed() {
+	// Arrange
+	req := &models.VerifyEmailRequest{Token: "verify-token"}
+	verificationToken := &models.EmailVerificationToken{ID: 7, UserID: suite.user.ID, ExpiresAt: time.Now().Add(time.Hour)}
+
+	suite.mockVerificationRepo.On("GetByHash", suite.ctx, utils.HashToken("verify-token")).Return(verificationToken, nil)
+	suite.mockVerificationRepo.On("MarkUsed", suite.ctx, uint64(7)).Return(false, nil)
+
+	// Act
+	err := suite.service.VerifyEmail(suite.ctx, req)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "invalid or expired verification token", err.Error())
+	suite.mockAuthRepo.AssertNotCalled(suite.T(), "MarkEmailVerified", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestResendVerification_AlreadyVerified tests that verified accounts get no new email
+func (suite *AuthServiceTestSuite) TestResendVerification_AlreadyVerified() {
+	// Arrange
+	req := &models.ResendVerificationRequest{Email: suite.user.Email}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+
+	// Act
+	err := suite.service.ResendVerification(suite.ctx, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockVerificationRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+	assert.Empty(suite.T(), suite.mailer.Messages())
+}
+
+// TestLogin_MFARequired tests that users with two-factor authentication only get an MFA token
+func (suite *AuthServiceTestSuite) TestLogin_MFARequired() {
+	// Arrange
+	confirmedAt := time.Now().Add(-time.Hour)
+	req := &models.LoginUserRequest{Email: suite.user.Email, Password: "password123"}
+
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, suite.user.Email).Return(suite.user, nil)
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).
+		Return(&models.MFACredential{UserID: suite.user.ID, ConfirmedAt: &confirmedAt}, nil)
+
+	// Act
+	token, err := suite.service.Login(suite.ctx, req, nil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), token.MFARequired)
+	assert.Empty(suite.T(), token.AccessToken)
+	assert.Empty(suite.T(), token.RefreshToken)
+
+	claims, err := suite.jwtUtil.ValidateMFAToken(token.MFAToken)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.user.ID, claims.UserID)
+	_, err = suite.jwtUtil.ValidateAccessToken(token.MFAToken)
+	assert.Error(suite.T(), err, "an MFA token must not be usable as an access token")
+	suite.mockSessionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestConfirmMFA_Success tests that a valid first code enables MFA and stores hashed recovery codes
+func (suite *AuthServiceTestSuite) TestConfirmMFA_Success() {
+	// Arrange
+	secret, _ := utils.GenerateTOTPSecret()
+	step := utils.TOTPStep(time.Now())
+	code, _ := utils.TOTPCode(secret, step)
+
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).
+		Return(&models.MFACredential{UserID: suite.user.ID, Secret: secret}, nil)
+	suite.mockMFARepo.On("Confirm", suite.ctx, suite.user.ID, step, mock.Anything).Return(nil)
+
+	// Act
+	codes, err := suite.service.ConfirmMFA(suite.ctx, uint(suite.user.ID), &models.ConfirmMFARequest{Code: code})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), codes.Codes, recoveryCodeCount)
+	hashes := suite.mockMFARepo.Calls[1].Arguments.Get(3).([]string)
+	assert.Equal(suite.T(), utils.HashToken(normalizeRecoveryCode(codes.Codes[0])), hashes[0])
+}
+
+// TestConfirmMFA_InvalidCode tests that a wrong first code does not enable MFA
+func (suite *AuthServiceTestSuite) TestConfirmMFA_InvalidCode() {
+	// Arrange
+	secret, _ := utils.GenerateTOTPSecret()
+
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).
+		Return(&models.MFACredential{UserID: suite.user.ID, Secret: secret}, nil)
+
+	// Act
+	codes, err := suite.service.ConfirmMFA(suite.ctx, uint(suite.user.ID), &models.ConfirmMFARequest{Code: "abcdef"})
+
+	// Assert
+	assert.Nil(suite.T(), codes)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "invalid mfa code", err.Error())
+	suite.mockMFARepo.AssertNotCalled(suite.T(), "Confirm", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestVerifyMFA_Success tests that a valid code completes the login with an otp authentication method
+func (suite *AuthServiceTestSuite) TestVerifyMFA_Success() {
+	// Arrange
+	credential := suite.mfaCredential()
+	step := utils.TOTPStep(time.Now())
+	code, _ := utils.TOTPCode(credential.Secret, step)
+	mfaToken, _ := suite.jwtUtil.CreateMFAToken(suite.user)
+
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).Return(credential, nil)
+	suite.mockMFARepo.On("UseStep", suite.ctx, suite.user.ID, step).Return(true, nil)
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(suite.user.ID)).Return(suite.user, nil)
+	suite.mockSessionRepo.On("Create", suite.ctx, mock.MatchedBy(func(session *models.Session) bool {
+		return session.AuthMethods == "pwd,otp"
+	})).Return(&models.Session{ID: "session-1", AuthMethods: "pwd,otp", AuthenticatedAt: time.Now()}, nil)
+	suite.mockRefreshTokenRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.RefreshToken")).
+		Return(&models.RefreshToken{}, nil)
+
+	// Act
+	token, err := suite.service.VerifyMFA(suite.ctx, &models.VerifyMFARequest{MFAToken: mfaToken, Code: code}, nil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	claims, err := suite.jwtUtil.ValidateAccessToken(token.AccessToken)
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), claims.HasAuthMethod(utils.AuthMethodOTP))
+	assert.NotNil(suite.T(), claims.AuthTime)
+}
+
+// TestVerifyMFA_ReplayedCode tests that a code cannot be used twice and counts as a failed attempt
+func (suite *AuthServiceTestSuite) TestVerifyMFA_ReplayedCode() {
+	// Arrange
+	credential := suite.mfaCredential()
+	step := utils.TOTPStep(time.Now())
+	code, _ := utils.TOTPCode(credential.Secret, step)
+	mfaToken, _ := suite.jwtUtil.CreateMFAToken(suite.user)
+
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).Return(credential, nil)
+	suite.mockMFARepo.On("UseStep", suite.ctx, suite.user.ID, step).Return(false, nil)
+	suite.mockMFARepo.On("RecordFailedAttempt", suite.ctx, suite.user.ID, maxMFAAttempts, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Act
+	token, err := suite.service.VerifyMFA(suite.ctx, &models.VerifyMFARequest{MFAToken: mfaToken, Code: code}, nil)
+
+	// Assert
+	assert.Nil(suite.T(), token)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "invalid mfa code", err.Error())
+	suite.mockMFARepo.AssertExpectations(suite.T())
+	suite.mockSessionRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestVerifyMFA_RecoveryCode tests that recovery codes are matched regardless of case and dashes
+func (suite *AuthServiceTestSuite) TestVerifyMFA_RecoveryCode() {
+	// Arrange
+	mfaToken, _ := suite.jwtUtil.CreateMFAToken(suite.user)
+
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).Return(suite.mfaCredential(), nil)
+	suite.mockMFARepo.On("UseRecoveryCode", suite.ctx, suite.user.ID, utils.HashToken("abcdefghij")).Return(true, nil)
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(suite.user.ID)).Return(suite.user, nil)
+	suite.mockSessionRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.Session")).
+		Return(&models.Session{ID: "session-1"}, nil)
+	suite.mockRefreshTokenRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.RefreshToken")).
+		Return(&models.RefreshToken{}, nil)
+
+	// Act
+	token, err := suite.service.VerifyMFA(suite.ctx, &models.VerifyMFARequest{MFAToken: mfaToken, RecoveryCode: "ABCDE-FGHIJ"}, nil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), token.AccessToken)
+}
+
+// TestVerifyMFA_Locked tests that no code is checked while the second step is locked
+func (suite *AuthServiceTestSuite) TestVerifyMFA_Locked() {
+	// Arrange
+	credential := suite.mfaCredential()
+	lockedUntil := time.Now().Add(time.Minute)
+	credential.LockedUntil = &lockedUntil
+	code, _ := utils.TOTPCode(credential.Secret, utils.TOTPStep(time.Now()))
+	mfaToken, _ := suite.jwtUtil.CreateMFAToken(suite.user)
+
+	suite.mockMFARepo.On("GetCredential", suite.ctx, suite.user.ID).Return(credential, nil)
+
+	// Act
+	token, err := suite.service.VerifyMFA(suite.ctx, &models.VerifyMFARequest{MFAToken: mfaToken, Code: code}, nil)
+
+	// Assert
+	assert.Nil(suite.T(), token)
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), "too many failed attempts", err.Error())
+	suite.mockMFARepo.AssertNotCalled(suite.T(), "UseStep", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUpdateProfile_PartialUpdate tests that only the given fields of the profile change
+func (suite *AuthServiceTestSuite) TestUpdateProfile_PartialUpdate() {
+	// Arrange
+	suite.user.FirstName = "Ada"
+	suite.user.Preferences = models.UserPreferences{WeekStart: "sunday"}
+	lastName, timezone := "Lovelace", "Europe/London"
+	req := &models.UpdateProfileRequest{
+		LastName:    &lastName,
+		Preferences: &models.UpdatePreferencesRequest{Timezone: &timezone},
+	}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+	suite.mockAuthRepo.On("UpdateProfile", suite.ctx, suite.user).Return(nil)
+
+	// Act
+	user, err := suite.service.UpdateProfile(suite.ctx, 1, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Ada", user.FirstName)
+	assert.Equal(suite.T(), "Lovelace", user.LastName)
+	assert.Equal(suite.T(), models.UserPreferences{Timezone: "Europe/London", WeekStart: "sunday"}, user.Preferences)
+}
+
+// TestUpdateProfile_InvalidTimezone tests that unknown time zones are rejected
+func (suite *AuthServiceTestSuite) TestUpdateProfile_InvalidTimezone() {
+	// Arrange
+	timezone := "Mars/Olympus_Mons"
+	req := &models.UpdateProfileRequest{Preferences: &models.UpdatePreferencesRequest{Timezone: &timezone}}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+
+	// Act
+	_, err := suite.service.UpdateProfile(suite.ctx, 1, req)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "invalid timezone")
+	suite.mockAuthRepo.AssertNotCalled(suite.T(), "UpdateProfile", mock.Anything, mock.Anything)
+}
+
+// TestChangePassword_KeepsCurrentSession tests that changing the password ends every other session
+func (suite *AuthServiceTestSuite) TestChangePassword_KeepsCurrentSession() {
+	// Arrange
+	req := &models.ChangePasswordRequest{CurrentPassword: "password123", NewPassword: "new-password"}
+	sessions := []models.Session{{ID: "current", UserID: suite.user.ID}, {ID: "other", UserID: suite.user.ID}}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+	suite.mockAuthRepo.On("UpdatePassword", suite.ctx, suite.user.ID, mock.AnythingOfType("string")).Return(nil)
+	suite.mockPasswordResetRepo.On("InvalidateAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockSessionRepo.On("ListActiveByUserID", suite.ctx, suite.user.ID).Return(sessions, nil)
+	suite.mockSessionRepo.On("Revoke", suite.ctx, "other").Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeFamily", suite.ctx, "other").Return(nil)
+
+	// Act
+	err := suite.service.ChangePassword(suite.ctx, 1, "current", req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	hash := suite.mockAuthRepo.Calls[1].Arguments.String(2)
+	assert.NoError(suite.T(), bcrypt.CompareHashAndPassword([]byte(hash), []byte("new-password")))
+	suite.mockSessionRepo.AssertNotCalled(suite.T(), "Revoke", suite.ctx, "current")
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+}
+
+// TestChangePassword_IncorrectPassword tests that the current password must be given
+func (suite *AuthServiceTestSuite) TestChangePassword_IncorrectPassword() {
+	// Arrange
+	req := &models.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password"}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+
+	// Act
+	err := suite.service.ChangePassword(suite.ctx, 1, "current", req)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "incorrect password")
+	suite.mockAuthRepo.AssertNotCalled(suite.T(), "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestChangeEmail_VerifiesNewAddress tests that the email changes once the new address is verified
+func (suite *AuthServiceTestSuite) TestChangeEmail_VerifiesNewAddress() {
+	// Arrange
+	req := &models.ChangeEmailRequest{Email: "new@example.com", Password: "password123"}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, "new@example.com").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockVerificationRepo.On("InvalidateAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockVerificationRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.EmailVerificationToken")).
+		Return(&models.EmailVerificationToken{}, nil)
+
+	// Act
+	err := suite.service.ChangeEmail(suite.ctx, 1, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	stored := suite.mockVerificationRepo.Calls[1].Arguments.Get(1).(*models.EmailVerificationToken)
+	assert.Equal(suite.T(), "new@example.com", stored.Email)
+
+	// The link goes to the new address, and the current one is warned
+	var recipients []string
+	var token string
+	for i := 0; i < 2; i++ {
+		msg, ok := suite.mailer.Wait(time.Second)
+		suite.Require().True(ok, "no email was sent")
+		recipients = append(recipients, msg.To)
+		if link := regexp.MustCompile(`/verify-email\?token=(\S+)`).FindStringSubmatch(msg.Body); link != nil {
+			assert.Equal(suite.T(), "new@example.com", msg.To)
+			token = link[1]
+		}
+	}
+	assert.ElementsMatch(suite.T(), []string{"new@example.com", suite.user.Email}, recipients)
+
+	// Verifying the token switches the account to the new address
+	stored.ID = 7
+	stored.ExpiresAt = time.Now().Add(time.Hour)
+	suite.mockVerificationRepo.On("GetByHash", suite.ctx, utils.HashToken(token)).Return(stored, nil)
+	suite.mockVerificationRepo.On("MarkUsed", suite.ctx, uint64(7)).Return(true, nil)
+	suite.mockAuthRepo.On("UpdateEmail", suite.ctx, suite.user.ID, "new@example.com", mock.AnythingOfType("time.Time")).Return(nil)
+
+	assert.NoError(suite.T(), suite.service.VerifyEmail(suite.ctx, &models.VerifyEmailRequest{Token: token}))
+	suite.mockAuthRepo.AssertCalled(suite.T(), "UpdateEmail", suite.ctx, suite.user.ID, "new@example.com", mock.AnythingOfType("time.Time"))
+	suite.mockAuthRepo.AssertNotCalled(suite.T(), "MarkEmailVerified", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestChangeEmail_InUse tests that the address of another account cannot be taken
+func (suite *AuthServiceTestSuite) TestChangeEmail_InUse() {
+	// Arrange
+	req := &models.ChangeEmailRequest{Email: "taken@example.com", Password: "password123"}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+	suite.mockAuthRepo.On("GetUserByEmail", suite.ctx, "taken@example.com").Return(&models.User{ID: 2}, nil)
+
+	// Act
+	err := suite.service.ChangeEmail(suite.ctx, 1, req)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "email already in use")
+	suite.mockVerificationRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestDeleteAccount_Success tests that deleting the account ends its sessions and deletes the user
+func (suite *AuthServiceTestSuite) TestDeleteAccount_Success() {
+	// Arrange
+	req := &models.DeleteAccountRequest{Password: "password123"}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+	suite.mockSessionRepo.On("ListActiveByUserID", suite.ctx, suite.user.ID).Return([]models.Session{}, nil)
+	suite.mockSessionRepo.On("RevokeAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeAllForUser", suite.ctx, suite.user.ID).Return(nil)
+	suite.mockAuthRepo.On("DeleteUser", suite.ctx, suite.user.ID).Return(nil)
+
+	// Act
+	err := suite.service.DeleteAccount(suite.ctx, 1, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockAuthRepo.AssertExpectations(suite.T())
+	suite.mockSessionRepo.AssertExpectations(suite.T())
+}
+
+// TestDeleteAccount_IncorrectPassword tests that the account is kept without the right password
+func (suite *AuthServiceTestSuite) TestDeleteAccount_IncorrectPassword() {
+	// Arrange
+	req := &models.DeleteAccountRequest{Password: "wrong-password"}
+
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+
+	// Act
+	err := suite.service.DeleteAccount(suite.ctx, 1, req)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "incorrect password")
+	suite.mockAuthRepo.AssertNotCalled(suite.T(), "DeleteUser", mock.Anything, mock.Anything)
+}
+
+// TestAuthServiceSuite runs the test suite
+func TestAuthServiceSuite(t *testing.T) {
+	suite.Run(t, new(AuthServiceTestSuite))
+}
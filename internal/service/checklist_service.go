@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// ChecklistService defines the interface for checklist business logic
+// operations. All operations act on a todo owned by userID.
+type ChecklistService interface {
+	GetChecklist(ctx context.Context, userID uint, todoID uint) ([]models.ChecklistItem, error)
+	AddItem(ctx context.Context, userID uint, todoID uint, req *models.CreateChecklistItemRequest) (*models.ChecklistItem, error)
+	UpdateItem(ctx context.Context, userID uint, todoID uint, id uint, req *models.UpdateChecklistItemRequest) (*models.ChecklistItem, error)
+	DeleteItem(ctx context.Context, userID uint, todoID uint, id uint) error
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+type checklistServiceImpl struct {
+	todoRepo      repository.TodoRepository
+	checklistRepo repository.ChecklistRepository
+}
+
+// NewChecklistService creates a new instance of ChecklistService
+func NewChecklistService(todoRepo repository.TodoRepository, checklistRepo repository.ChecklistRepository) ChecklistService {
+	return &checklistServiceImpl{
+		todoRepo:      todoRepo,
+		checklistRepo: checklistRepo,
+	}
+}
+
+func (s *checklistServiceImpl) GetChecklist(ctx context.Context, userID uint, todoID uint) ([]models.ChecklistItem, error) {
+	if err := s.checkTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	return s.checklistRepo.ListByTodoID(ctx, todoID)
+}
+
+func (s *checklistServiceImpl) AddItem(ctx context.Context, userID uint, todoID uint, req *models.CreateChecklistItemRequest) (*models.ChecklistItem, error) {
+	if err := s.checkTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, errors.New("checklist item title is required")
+	}
+
+	items, err := s.checklistRepo.ListByTodoID(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) >= models.MaxChecklistItems {
+		return nil, errors.New("checklist is full")
+	}
+
+	// New items go to the end of the checklist unless placed explicitly
+	position := 0
+	if len(items) > 0 {
+		position = items[len(items)-1].Position + 1
+	}
+	if req.Position != nil {
+		position = *req.Position
+	}
+
+	now := time.Now().UTC()
+	return s.checklistRepo.Create(ctx, &models.ChecklistItem{
+		TodoID:    todoID,
+		Title:     title,
+		Position:  position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (s *checklistServiceImpl) UpdateItem(ctx context.Context, userID uint, todoID uint, id uint, req *models.UpdateChecklistItemRequest) (*models.ChecklistItem, error) {
+	if err := s.checkTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.checklistRepo.GetByID(ctx, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, errors.New("checklist item not found")
+	}
+
+	if req.Title != nil {
+		item.Title = strings.TrimSpace(*req.Title)
+		if item.Title == "" {
+			return nil, errors.New("checklist item title is required")
+		}
+	}
+	if req.Checked != nil {
+		item.Checked = *req.Checked
+	}
+	if req.Position != nil {
+		item.Position = *req.Position
+	}
+	item.UpdatedAt = time.Now().UTC()
+
+	return s.checklistRepo.Update(ctx, item)
+}
+
+func (s *checklistServiceImpl) DeleteItem(ctx context.Context, userID uint, todoID uint, id uint) error {
+	if err := s.checkTodo(ctx, userID, todoID); err != nil {
+		return err
+	}
+
+	return s.checklistRepo.Delete(ctx, todoID, id)
+}
+
+// checkTodo checks that the todo exists and belongs to the user
+func (s *checklistServiceImpl) checkTodo(ctx context.Context, userID uint, todoID uint) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if todoID == 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	todo, err := s.todoRepo.GetByID(ctx, userID, todoID)
+	if err != nil {
+		return err
+	}
+	if todo == nil {
+		return errors.New("todo not found")
+	}
+	return nil
+}
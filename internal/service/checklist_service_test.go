@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChecklistServiceTestSuite struct {
+	suite.Suite
+	mockTodoRepo      *mocks.MockTodoRepository
+	mockChecklistRepo *mocks.MockChecklistRepository
+	service           ChecklistService
+	ctx               context.Context
+	userID            uint
+	todoID            uint
+}
+
+func (suite *ChecklistServiceTestSuite) SetupTest() {
+	suite.mockTodoRepo = new(mocks.MockTodoRepository)
+	suite.mockChecklistRepo = new(mocks.MockChecklistRepository)
+	suite.service = NewChecklistService(suite.mockTodoRepo, suite.mockChecklistRepo)
+	suite.ctx = context.Background()
+	suite.userID = 1
+	suite.todoID = 10
+}
+
+// expectTodo makes the todo visible to the user
+func (suite *ChecklistServiceTestSuite) expectTodo() {
+	suite.mockTodoRepo.On("GetByID", suite.ctx, suite.userID, suite.todoID).
+		Return(&models.Todo{ID: suite.todoID, UserID: uint64(suite.userID)}, nil)
+}
+
+// TestAddItem_AppendsToEnd tests that new items go after the last one
+func (suite *ChecklistServiceTestSuite) TestAddItem_AppendsToEnd() {
+	// Arrange
+	suite.expectTodo()
+	suite.mockChecklistRepo.On("ListByTodoID", suite.ctx, suite.todoID).
+		Return([]models.ChecklistItem{{ID: 1, Position: 0}, {ID: 2, Position: 4}}, nil)
+	suite.mockChecklistRepo.On("Create", suite.ctx, mock.MatchedBy(func(item *models.ChecklistItem) bool {
+		return item.TodoID == suite.todoID && item.Title == "Pack bags" && item.Position == 5
+	})).Return(&models.ChecklistItem{ID: 3, TodoID: suite.todoID, Title: "Pack bags", Position: 5}, nil)
+
+	// Act
+	item, err := suite.service.AddItem(suite.ctx, suite.userID, suite.todoID, &models.CreateChecklistItemRequest{Title: " Pack bags "})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, item.Position)
+	suite.mockChecklistRepo.AssertExpectations(suite.T())
+}
+
+// TestAddItem_Full tests that a checklist cannot grow past the limit
+func (suite *ChecklistServiceTestSuite) TestAddItem_Full() {
+	// Arrange
+	suite.expectTodo()
+	suite.mockChecklistRepo.On("ListByTodoID", suite.ctx, suite.todoID).
+		Return(make([]models.ChecklistItem, models.MaxChecklistItems), nil)
+
+	// Act
+	item, err := suite.service.AddItem(suite.ctx, suite.userID, suite.todoID, &models.CreateChecklistItemRequest{Title: "One more"})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "checklist is full")
+	assert.Nil(suite.T(), item)
+	suite.mockChecklistRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestAddItem_TodoNotOwned tests that another user's todo is treated as not found
+func (suite *ChecklistServiceTestSuite) TestAddItem_TodoNotOwned() {
+	// Arrange
+	suite.mockTodoRepo.On("GetByID", suite.ctx, uint(2), suite.todoID).Return(nil, nil)
+
+	// Act
+	item, err := suite.service.AddItem(suite.ctx, 2, suite.todoID, &models.CreateChecklistItemRequest{Title: "Sneaky"})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "todo not found")
+	assert.Nil(suite.T(), item)
+	suite.mockChecklistRepo.AssertNotCalled(suite.T(), "ListByTodoID", mock.Anything, mock.Anything)
+}
+
+// TestUpdateItem_OnlySetFields tests that only the fields in the request change
+func (suite *ChecklistServiceTestSuite) TestUpdateItem_OnlySetFields() {
+	// Arrange
+	suite.expectTodo()
+	checked := true
+	suite.mockChecklistRepo.On("GetByID", suite.ctx, suite.todoID, uint(3)).
+		Return(&models.ChecklistItem{ID: 3, TodoID: suite.todoID, Title: "Pack bags", Position: 2}, nil)
+	suite.mockChecklistRepo.On("Update", suite.ctx, mock.MatchedBy(func(item *models.ChecklistItem) bool {
+		return item.Title == "Pack bags" && item.Checked && item.Position == 2
+	})).Return(&models.ChecklistItem{ID: 3, TodoID: suite.todoID, Title: "Pack bags", Checked: true, Position: 2}, nil)
+
+	// Act
+	item, err := suite.service.UpdateItem(suite.ctx, suite.userID, suite.todoID, 3, &models.UpdateChecklistItemRequest{Checked: &checked})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), item.Checked)
+	suite.mockChecklistRepo.AssertExpectations(suite.T())
+}
+
+// TestUpdateItem_BlankTitle tests that a title of only spaces is rejected
+func (suite *ChecklistServiceTestSuite) TestUpdateItem_BlankTitle() {
+	// Arrange
+	suite.expectTodo()
+	title := "   "
+	suite.mockChecklistRepo.On("GetByID", suite.ctx, suite.todoID, uint(3)).
+		Return(&models.ChecklistItem{ID: 3, TodoID: suite.todoID, Title: "Pack bags"}, nil)
+
+	// Act
+	item, err := suite.service.UpdateItem(suite.ctx, suite.userID, suite.todoID, 3, &models.UpdateChecklistItemRequest{Title: &title})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "checklist item title is required")
+	assert.Nil(suite.T(), item)
+}
+
+// TestChecklistServiceSuite runs the test suite
+func TestChecklistServiceSuite(t *testing.T) {
+	suite.Run(t, new(ChecklistServiceTestSuite))
+}
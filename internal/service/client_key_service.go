@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// ClientKeyService defines the interface for client key business logic operations
+type ClientKeyService interface {
+	// CreateKey issues a key. The returned key is the only time its secret
+	// value is available.
+	CreateKey(ctx context.Context, req *models.CreateClientKeyRequest) (*models.CreatedClientKey, error)
+	ListKeys(ctx context.Context) ([]models.ClientKey, error)
+	RevokeKey(ctx context.Context, id uint64) error
+	// ValidateKey resolves the key sent by a client. origin is the request's
+	// Origin header, checked against the key's allowed origins.
+	ValidateKey(ctx context.Context, key string, origin string) (*models.ClientKey, error)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// clientKeyPrefixLength is how much of a key is kept in clear to recognize it
+const clientKeyPrefixLength = len(models.ClientKeyPrefix) + 4
+
+type clientKeyServiceImpl struct {
+	keyRepo     repository.ClientKeyRepository
+	rootKeyHash string
+}
+
+// NewClientKeyService creates a new instance of ClientKeyService. rootKey is
+// the key configured with API_KEY: it is always valid and is the one allowed
+// to manage the other keys. It may be empty.
+func NewClientKeyService(keyRepo repository.ClientKeyRepository, rootKey string) ClientKeyService {
+	s := &clientKeyServiceImpl{keyRepo: keyRepo}
+	if rootKey != "" {
+		s.rootKeyHash = utils.HashToken(rootKey)
+	}
+	return s
+}
+
+func (s *clientKeyServiceImpl) CreateKey(ctx context.Context, req *models.CreateClientKeyRequest) (*models.CreatedClientKey, error) {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiration must be in the future")
+	}
+
+	origins := make([]string, 0, len(req.AllowedOrigins))
+	for _, origin := range req.AllowedOrigins {
+		normalized, err := normalizeOrigin(origin)
+		if err != nil {
+			return nil, err
+		}
+		origins = append(origins, normalized)
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	key := models.ClientKeyPrefix + secret
+
+	record := &models.ClientKey{
+		Name:           strings.TrimSpace(req.Name),
+		Owner:          strings.TrimSpace(req.Owner),
+		KeyPrefix:      key[:clientKeyPrefixLength],
+		KeyHash:        utils.HashToken(key),
+		AllowedOrigins: origins,
+		ExpiresAt:      req.ExpiresAt,
+	}
+
+	created, err := s.keyRepo.Create(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreatedClientKey{ClientKey: *created, Key: key}, nil
+}
+
+func (s *clientKeyServiceImpl) ListKeys(ctx context.Context) ([]models.ClientKey, error) {
+	return s.keyRepo.List(ctx)
+}
+
+func (s *clientKeyServiceImpl) RevokeKey(ctx context.Context, id uint64) error {
+	if id == 0 {
+		return errors.New("invalid key ID")
+	}
+
+	revoked, err := s.keyRepo.Revoke(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("key not found")
+	}
+	return nil
+}
+
+func (s *clientKeyServiceImpl) ValidateKey(ctx context.Context, key string, origin string) (*models.ClientKey, error) {
+	if key == "" {
+		return nil, errors.New("invalid client key")
+	}
+
+	// Keys are only ever compared through their hashes, and the root key in
+	// constant time, so response times reveal nothing about valid keys
+	keyHash := utils.HashToken(key)
+	if s.rootKeyHash != "" && subtle.ConstantTimeCompare([]byte(keyHash), []byte(s.rootKeyHash)) == 1 {
+		return &models.ClientKey{Name: "API_KEY", Root: true}, nil
+	}
+
+	if !strings.HasPrefix(key, models.ClientKeyPrefix) {
+		return nil, errors.New("invalid client key")
+	}
+
+	record, err := s.keyRepo.GetByHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invalid client key")
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if record.RevokedAt != nil || (record.ExpiresAt != nil && now.After(*record.ExpiresAt)) {
+		return nil, errors.New("invalid client key")
+	}
+
+	if !record.AllowsOrigin(origin) {
+		return nil, errors.New("origin not allowed")
+	}
+
+	if err := s.keyRepo.TouchLastUsed(ctx, record.ID, now.UTC()); err != nil {
+		log.Printf("Failed to record use of client key %d: %v", record.ID, err)
+	}
+
+	return record, nil
+}
+
+// normalizeOrigin checks that origin is a scheme and host, as sent in the
+// Origin header, and lowercases it
+func normalizeOrigin(origin string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(origin))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+		(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", fmt.Errorf("invalid origin: %s", origin)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+	"todo-list-api/internal/utils"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ClientKeyServiceTestSuite struct {
+	suite.Suite
+	mockKeyRepo *mocks.MockClientKeyRepository
+	service     ClientKeyService
+	ctx         context.Context
+}
+
+func (suite *ClientKeyServiceTestSuite) SetupTest() {
+	suite.mockKeyRepo = new(mocks.MockClientKeyRepository)
+	suite.service = NewClientKeyService(suite.mockKeyRepo, "root-key")
+	suite.ctx = context.Background()
+}
+
+func (suite *ClientKeyServiceTestSuite) TestCreateKey_Success() {
+	req := &models.CreateClientKeyRequest{
+		Name:           "web",
+		Owner:          "frontend team",
+		AllowedOrigins: []string{"https://App.example.com/"},
+	}
+
+	var stored *models.ClientKey
+	call := suite.mockKeyRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.ClientKey"))
+	call.Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*models.ClientKey)
+		call.ReturnArguments = mock.Arguments{stored, nil}
+	})
+
+	result, err := suite.service.CreateKey(suite.ctx, req)
+
+	suite.Require().NoError(err)
+	suite.True(strings.HasPrefix(result.Key, models.ClientKeyPrefix))
+	suite.True(strings.HasPrefix(result.Key, stored.KeyPrefix))
+	suite.Equal(utils.HashToken(result.Key), stored.KeyHash)
+	suite.Equal([]string{"https://app.example.com"}, stored.AllowedOrigins)
+}
+
+func (suite *ClientKeyServiceTestSuite) TestCreateKey_InvalidOrigin() {
+	req := &models.CreateClientKeyRequest{Name: "web", Owner: "frontend team", AllowedOrigins: []string{"https://app.example.com/login"}}
+
+	result, err := suite.service.CreateKey(suite.ctx, req)
+
+	suite.Nil(result)
+	suite.EqualError(err, "invalid origin: https://app.example.com/login")
+	suite.mockKeyRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *ClientKeyServiceTestSuite) TestValidateKey_RootKey() {
+	key, err := suite.service.ValidateKey(suite.ctx, "root-key", "")
+
+	suite.Require().NoError(err)
+	suite.True(key.Root)
+	suite.mockKeyRepo.AssertNotCalled(suite.T(), "GetByHash", mock.Anything, mock.Anything)
+}
+
+func (suite *ClientKeyServiceTestSuite) TestValidateKey_StoredKey() {
+	apiKey := models.ClientKeyPrefix + "secret"
+	record := &models.ClientKey{ID: 3, KeyHash: utils.HashToken(apiKey), AllowedOrigins: []string{"https://app.example.com"}}
+	suite.mockKeyRepo.On("GetByHash", suite.ctx, utils.HashToken(apiKey)).Return(record, nil)
+	suite.mockKeyRepo.On("TouchLastUsed", suite.ctx, uint64(3), mock.AnythingOfType("time.Time")).Return(nil)
+
+	key, err := suite.service.ValidateKey(suite.ctx, apiKey, "https://app.example.com")
+
+	suite.Require().NoError(err)
+	suite.Equal(uint64(3), key.ID)
+	suite.False(key.Root)
+	suite.mockKeyRepo.AssertExpectations(suite.T())
+}
+
+func (suite *ClientKeyServiceTestSuite) TestValidateKey_OriginNotAllowed() {
+	apiKey := models.ClientKeyPrefix + "secret"
+	record := &models.ClientKey{ID: 3, AllowedOrigins: []string{"https://app.example.com"}}
+	suite.mockKeyRepo.On("GetByHash", suite.ctx, utils.HashToken(apiKey)).Return(record, nil)
+
+	for _, origin := range []string{"https://evil.example.com", ""} {
+		_, err := suite.service.ValidateKey(suite.ctx, apiKey, origin)
+		suite.EqualError(err, "origin not allowed")
+	}
+	suite.mockKeyRepo.AssertNotCalled(suite.T(), "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ClientKeyServiceTestSuite) TestValidateKey_Rejected() {
+	past := time.Now().Add(-time.Minute)
+	revoked := models.ClientKeyPrefix + "revoked"
+	expired := models.ClientKeyPrefix + "expired"
+	unknown := models.ClientKeyPrefix + "unknown"
+	suite.mockKeyRepo.On("GetByHash", suite.ctx, utils.HashToken(revoked)).Return(&models.ClientKey{ID: 1, RevokedAt: &past}, nil)
+	suite.mockKeyRepo.On("GetByHash", suite.ctx, utils.HashToken(expired)).Return(&models.ClientKey{ID: 2, ExpiresAt: &past}, nil)
+	suite.mockKeyRepo.On("GetByHash", suite.ctx, utils.HashToken(unknown)).Return(nil, gorm.ErrRecordNotFound)
+
+	for _, apiKey := range []string{revoked, expired, unknown, "", "root-key-typo"} {
+		key, err := suite.service.ValidateKey(suite.ctx, apiKey, "")
+		suite.Nil(key)
+		suite.EqualError(err, "invalid client key", apiKey)
+	}
+}
+
+func (suite *ClientKeyServiceTestSuite) TestRevokeKey_NotFound() {
+	suite.mockKeyRepo.On("Revoke", suite.ctx, uint64(9)).Return(false, nil)
+
+	err := suite.service.RevokeKey(suite.ctx, 9)
+
+	suite.EqualError(err, "key not found")
+}
+
+func TestClientKeyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientKeyServiceTestSuite))
+}
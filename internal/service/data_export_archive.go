@@ -0,0 +1,136 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+// dataExportContent is everything stored about a user, as written to data.json
+type dataExportContent struct {
+	ExportedAt  time.Time           `json:"exportedAt"`
+	Profile     *models.User        `json:"profile"`
+	Todos       []exportedTodo      `json:"todos"`
+	Sessions    []exportedSession   `json:"sessions"`
+	AuditEvents []models.AuditEvent `json:"auditEvents"`
+}
+
+// exportedTodo is a todo with the deletion time the API does not show
+type exportedTodo struct {
+	models.Todo
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// exportedSession is a session with the revocation time the API does not show
+type exportedSession struct {
+	models.Session
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+func newDataExportContent(user *models.User, todos []models.Todo, sessions []models.Session, events []models.AuditEvent, now time.Time) *dataExportContent {
+	content := &dataExportContent{
+		ExportedAt:  now,
+		Profile:     user,
+		Todos:       make([]exportedTodo, 0, len(todos)),
+		Sessions:    make([]exportedSession, 0, len(sessions)),
+		AuditEvents: events,
+	}
+	if content.AuditEvents == nil {
+		content.AuditEvents = []models.AuditEvent{}
+	}
+
+	for _, todo := range todos {
+		exported := exportedTodo{Todo: todo}
+		if todo.DeletedAt.Valid {
+			exported.DeletedAt = &todo.DeletedAt.Time
+		}
+		content.Todos = append(content.Todos, exported)
+	}
+	for _, session := range sessions {
+		content.Sessions = append(content.Sessions, exportedSession{Session: session, RevokedAt: session.RevokedAt})
+	}
+	return content
+}
+
+// writeDataExportArchive writes content as a zip archive holding data.json
+// and a CSV file per list
+func writeDataExportArchive(content *dataExportContent) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	data, err := archive.Create("data.json")
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(data)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(content); err != nil {
+		return nil, err
+	}
+
+	todos := [][]string{{"id", "title", "description", "priority", "tags", "completed", "dueDate", "createdAt", "updatedAt", "deletedAt", "status"}}
+	for _, todo := range content.Todos {
+		tags := make([]string, 0, len(todo.Tags))
+		for _, tag := range todo.Tags {
+			tags = append(tags, tag.Name)
+		}
+		todos = append(todos, []string{
+			strconv.FormatUint(uint64(todo.ID), 10), todo.Title, todo.Description, todo.Priority, strings.Join(tags, ", "),
+			strconv.FormatBool(todo.Completed), formatCSVTime(todo.DueDate), formatCSVTime(&todo.CreatedAt),
+			formatCSVTime(&todo.UpdatedAt), formatCSVTime(todo.DeletedAt), todo.Status,
+		})
+	}
+
+	sessions := [][]string{{"id", "userAgent", "ipAddress", "authMethods", "createdAt", "lastUsedAt", "expiresAt", "revokedAt"}}
+	for _, session := range content.Sessions {
+		sessions = append(sessions, []string{
+			session.ID, session.UserAgent, session.IPAddress, session.AuthMethods, formatCSVTime(&session.CreatedAt),
+			formatCSVTime(&session.LastUsedAt), formatCSVTime(&session.ExpiresAt), formatCSVTime(session.RevokedAt),
+		})
+	}
+
+	events := [][]string{{"type", "time", "email", "ip", "details"}}
+	for _, event := range content.AuditEvents {
+		details, err := json.Marshal(event.Details)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, []string{event.Type, formatCSVTime(&event.Time), event.Email, event.IP, string(details)})
+	}
+
+	files := []struct {
+		name    string
+		records [][]string
+	}{
+		{"todos.csv", todos},
+		{"sessions.csv", sessions},
+		{"audit_events.csv", events},
+	}
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if err := csv.NewWriter(w).WriteAll(file.records); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatCSVTime formats t as RFC 3339, or as an empty cell when it is nil
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
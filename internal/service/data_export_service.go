@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// DataExportService defines the interface for exporting the data of users
+type DataExportService interface {
+	// RequestExport starts building an archive of everything stored about the
+	// user in the background. The export already being built is returned
+	// instead, if there is one.
+	RequestExport(ctx context.Context, userID uint) (*models.DataExport, error)
+	// GetExport returns one of the user's exports, with a signed download link
+	// once it is ready
+	GetExport(ctx context.Context, userID uint, id string) (*models.DataExport, error)
+	// GetArchive returns the archive of the export a signed download link
+	// points to. The link is checked, not the user requesting it.
+	GetArchive(ctx context.Context, id string, expires int64, signature string) ([]byte, error)
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// Lifetimes of data exports
+const (
+	// DataExportTTL is how long an export is kept once requested
+	DataExportTTL = 7 * 24 * time.Hour
+	// DataExportLinkTTL is how long a download link works
+	DataExportLinkTTL = 15 * time.Minute
+)
+
+// dataExportTimeout bounds the building of an archive in the background. An
+// export still pending after it was left behind, e.g. by a restart, and is
+// treated as failed.
+const dataExportTimeout = 5 * time.Minute
+
+type dataExportServiceImpl struct {
+	exportRepo  repository.DataExportRepository
+	authRepo    repository.AuthRepository
+	todoRepo    repository.TodoRepository
+	sessionRepo repository.SessionRepository
+	auditRepo   repository.AuditEventRepository
+	signingKey  []byte
+}
+
+// NewDataExportService creates a new instance of DataExportService. Download
+// links are signed with signingKey.
+func NewDataExportService(
+	exportRepo repository.DataExportRepository,
+	authRepo repository.AuthRepository,
+	todoRepo repository.TodoRepository,
+	sessionRepo repository.SessionRepository,
+	auditRepo repository.AuditEventRepository,
+	signingKey []byte,
+) DataExportService {
+	return &dataExportServiceImpl{
+		exportRepo:  exportRepo,
+		authRepo:    authRepo,
+		todoRepo:    todoRepo,
+		sessionRepo: sessionRepo,
+		auditRepo:   auditRepo,
+		signingKey:  signingKey,
+	}
+}
+
+func (s *dataExportServiceImpl) RequestExport(ctx context.Context, userID uint) (*models.DataExport, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	now := time.Now().UTC()
+	pending, err := s.exportRepo.GetPendingByUserID(ctx, uint64(userID))
+	switch {
+	case err == nil && pending.CreatedAt.After(now.Add(-dataExportTimeout)):
+		return pending, nil
+	case err == nil:
+		// Its build was abandoned, so it would block new exports forever
+		if err := s.exportRepo.Fail(ctx, pending.ID, now); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	id, err := utils.GenerateRandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := s.exportRepo.Create(ctx, &models.DataExport{
+		ID:        id,
+		UserID:    uint64(userID),
+		Status:    models.DataExportPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(DataExportTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.build(export.ID, userID)
+
+	return export, nil
+}
+
+func (s *dataExportServiceImpl) GetExport(ctx context.Context, userID uint, id string) (*models.DataExport, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	export, err := s.exportRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("export not found")
+		}
+		return nil, err
+	}
+
+	// Other users' exports are reported exactly like missing ones
+	now := time.Now()
+	if export.UserID != uint64(userID) || now.After(export.ExpiresAt) {
+		return nil, errors.New("export not found")
+	}
+
+	if export.Status == models.DataExportReady {
+		expires := now.Add(DataExportLinkTTL)
+		if expires.After(export.ExpiresAt) {
+			expires = export.ExpiresAt
+		}
+		export.DownloadURL = s.downloadURL(export.ID, expires.Unix())
+	}
+
+	return export, nil
+}
+
+func (s *dataExportServiceImpl) GetArchive(ctx context.Context, id string, expires int64, signature string) ([]byte, error) {
+	if time.Now().Unix() > expires || !s.validSignature(id, expires, signature) {
+		return nil, errors.New("invalid or expired link")
+	}
+
+	export, err := s.exportRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("export not found")
+		}
+		return nil, err
+	}
+	if export.Status != models.DataExportReady || time.Now().After(export.ExpiresAt) {
+		return nil, errors.New("export not found")
+	}
+
+	return s.exportRepo.GetArchive(ctx, id)
+}
+
+// build creates the archive of a pending export and stores it. It runs in
+// the background, so it has a context of its own.
+func (s *dataExportServiceImpl) build(id string, userID uint) {
+	ctx, cancel := context.WithTimeout(context.Background(), dataExportTimeout)
+	defer cancel()
+
+	archive, err := s.buildArchive(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to build data export %s of user %d: %v", id, userID, err)
+		if err := s.exportRepo.Fail(ctx, id, time.Now().UTC()); err != nil {
+			log.Printf("Failed to mark data export %s as failed: %v", id, err)
+		}
+		return
+	}
+
+	if err := s.exportRepo.Complete(ctx, id, archive, time.Now().UTC()); err != nil {
+		log.Printf("Failed to store data export %s of user %d: %v", id, userID, err)
+	}
+}
+
+// buildArchive collects the data of the user and writes it as an archive
+func (s *dataExportServiceImpl) buildArchive(ctx context.Context, userID uint) ([]byte, error) {
+	user, err := s.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := s.todoRepo.ListAllForExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessionRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.auditRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeDataExportArchive(newDataExportContent(user, todos, sessions, events, time.Now().UTC()))
+}
+
+// downloadURL returns the signed download link of an export, relative to the API
+func (s *dataExportServiceImpl) downloadURL(id string, expires int64) string {
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(id, expires))
+	return fmt.Sprintf("/api/exports/%s?%s", url.PathEscape(id), query.Encode())
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of a download link
+func (s *dataExportServiceImpl) sign(id string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "%s:%d", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *dataExportServiceImpl) validSignature(id string, expires int64, signature string) bool {
+	expected, err := hex.DecodeString(s.sign(id, expires))
+	if err != nil {
+		return false
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(expected, actual)
+}
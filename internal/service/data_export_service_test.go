@@ -0,0 +1,269 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type DataExportServiceTestSuite struct {
+	suite.Suite
+	mockExportRepo  *mocks.MockDataExportRepository
+	mockAuthRepo    *mocks.MockAuthRepository
+	mockTodoRepo    *mocks.MockTodoRepository
+	mockSessionRepo *mocks.MockSessionRepository
+	mockAuditRepo   *mocks.MockAuditEventRepository
+	service         DataExportService
+	ctx             context.Context
+	user            *models.User
+}
+
+func (suite *DataExportServiceTestSuite) SetupTest() {
+	suite.mockExportRepo = new(mocks.MockDataExportRepository)
+	suite.mockAuthRepo = new(mocks.MockAuthRepository)
+	suite.mockTodoRepo = new(mocks.MockTodoRepository)
+	suite.mockSessionRepo = new(mocks.MockSessionRepository)
+	suite.mockAuditRepo = new(mocks.MockAuditEventRepository)
+	suite.service = NewDataExportService(
+		suite.mockExportRepo,
+		suite.mockAuthRepo,
+		suite.mockTodoRepo,
+		suite.mockSessionRepo,
+		suite.mockAuditRepo,
+		[]byte("test-signing-key"),
+	)
+	suite.ctx = context.Background()
+	suite.user = &models.User{ID: 1, Email: "user@example.com", FirstName: "Ada"}
+}
+
+// readyExport returns a ready export of the suite's user
+func (suite *DataExportServiceTestSuite) readyExport() *models.DataExport {
+	return &models.DataExport{
+		ID:        "export-1",
+		UserID:    suite.user.ID,
+		Status:    models.DataExportReady,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+// downloadLink returns the expiry and signature of a download URL
+func (suite *DataExportServiceTestSuite) downloadLink(downloadURL string) (int64, string) {
+	link, err := url.Parse(downloadURL)
+	suite.Require().NoError(err)
+
+	expires, err := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	suite.Require().NoError(err)
+	return expires, link.Query().Get("signature")
+}
+
+// TestRequestExport_BuildsArchive tests that the archive holds the user's data, including deleted todos
+func (suite *DataExportServiceTestSuite) TestRequestExport_BuildsArchive() {
+	// Arrange
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	todos := []models.Todo{
+		{ID: 1, UserID: 1, Title: "Kept"},
+		{ID: 2, UserID: 1, Title: "Deleted, with a comma", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}
+	events := []models.AuditEvent{{Type: "account.locked", UserID: 1, Details: map[string]string{"lockout": "1m0s"}}}
+
+	suite.mockExportRepo.On("GetPendingByUserID", suite.ctx, uint64(1)).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockExportRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.DataExport")).
+		Return(&models.DataExport{ID: "export-1", UserID: 1, Status: models.DataExportPending}, nil)
+	suite.mockAuthRepo.On("GetUserByID", mock.Anything, uint(1)).Return(suite.user, nil)
+	suite.mockTodoRepo.On("ListAllForExport", mock.Anything, uint(1)).Return(todos, nil)
+	suite.mockSessionRepo.On("ListByUserID", mock.Anything, uint64(1)).Return([]models.Session{{ID: "session-1"}}, nil)
+	suite.mockAuditRepo.On("ListByUserID", mock.Anything, uint64(1)).Return(events, nil)
+
+	archives := make(chan []byte, 1)
+	suite.mockExportRepo.On("Complete", mock.Anything, "export-1", mock.Anything, mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { archives <- args.Get(2).([]byte) }).
+		Return(nil)
+
+	// Act
+	export, err := suite.service.RequestExport(suite.ctx, 1)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), models.DataExportPending, export.Status)
+
+	var archive []byte
+	select {
+	case archive = <-archives:
+	case <-time.After(time.Second):
+		suite.FailNow("the export was not completed")
+	}
+
+	created := suite.mockExportRepo.Calls[1].Arguments.Get(1).(*models.DataExport)
+	assert.Equal(suite.T(), uint64(1), created.UserID)
+	assert.WithinDuration(suite.T(), time.Now().Add(DataExportTTL), created.ExpiresAt, time.Minute)
+
+	files := map[string]string{}
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	suite.Require().NoError(err)
+	for _, file := range reader.File {
+		f, err := file.Open()
+		suite.Require().NoError(err)
+		content, err := io.ReadAll(f)
+		suite.Require().NoError(err)
+		files[file.Name] = string(content)
+	}
+
+	var data struct {
+		Profile struct {
+			Email string `json:"email"`
+		} `json:"profile"`
+		Todos []struct {
+			ID        uint       `json:"id"`
+			DeletedAt *time.Time `json:"deletedAt"`
+		} `json:"todos"`
+		Sessions    []json.RawMessage   `json:"sessions"`
+		AuditEvents []models.AuditEvent `json:"auditEvents"`
+	}
+	suite.Require().NoError(json.Unmarshal([]byte(files["data.json"]), &data))
+	assert.Equal(suite.T(), "user@example.com", data.Profile.Email)
+	suite.Require().Len(data.Todos, 2)
+	assert.Nil(suite.T(), data.Todos[0].DeletedAt)
+	assert.True(suite.T(), deletedAt.Equal(*data.Todos[1].DeletedAt))
+	assert.Len(suite.T(), data.Sessions, 1)
+	assert.Equal(suite.T(), "account.locked", data.AuditEvents[0].Type)
+
+	rows, err := csv.NewReader(strings.NewReader(files["todos.csv"])).ReadAll()
+	suite.Require().NoError(err)
+	suite.Require().Len(rows, 3)
+	assert.Equal(suite.T(), "Deleted, with a comma", rows[2][1])
+	assert.Equal(suite.T(), "2024-03-01T12:00:00Z", rows[2][9])
+	assert.Contains(suite.T(), files, "sessions.csv")
+	assert.Contains(suite.T(), files, "audit_events.csv")
+}
+
+// TestRequestExport_Pending tests that no second export is built while one is pending
+func (suite *DataExportServiceTestSuite) TestRequestExport_Pending() {
+	// Arrange
+	pending := &models.DataExport{ID: "export-1", UserID: 1, Status: models.DataExportPending, CreatedAt: time.Now().Add(-time.Minute)}
+	suite.mockExportRepo.On("GetPendingByUserID", suite.ctx, uint64(1)).Return(pending, nil)
+
+	// Act
+	export, err := suite.service.RequestExport(suite.ctx, 1)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), pending, export)
+	suite.mockExportRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestRequestExport_StalePending tests that an export whose build was abandoned is failed and replaced
+func (suite *DataExportServiceTestSuite) TestRequestExport_StalePending() {
+	// Arrange
+	pending := &models.DataExport{ID: "export-1", UserID: 1, Status: models.DataExportPending, CreatedAt: time.Now().Add(-time.Hour)}
+	suite.mockExportRepo.On("GetPendingByUserID", suite.ctx, uint64(1)).Return(pending, nil)
+	suite.mockExportRepo.On("Fail", suite.ctx, "export-1", mock.AnythingOfType("time.Time")).Return(nil)
+	suite.mockExportRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.DataExport")).
+		Return(&models.DataExport{ID: "export-2", UserID: 1, Status: models.DataExportPending}, nil)
+	// The new export is built in the background; its build is not under test
+	built := make(chan struct{})
+	suite.mockAuthRepo.On("GetUserByID", mock.Anything, uint(1)).Return(nil, assert.AnError)
+	suite.mockExportRepo.On("Fail", mock.Anything, "export-2", mock.AnythingOfType("time.Time")).
+		Run(func(mock.Arguments) { close(built) }).
+		Return(nil)
+
+	// Act
+	export, err := suite.service.RequestExport(suite.ctx, 1)
+
+	// Assert
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), "export-2", export.ID)
+	suite.mockExportRepo.AssertCalled(suite.T(), "Fail", suite.ctx, "export-1", mock.AnythingOfType("time.Time"))
+
+	select {
+	case <-built:
+	case <-time.After(time.Second):
+		suite.FailNow("the export was not built")
+	}
+}
+
+// TestGetArchive_SignedLink tests that the link of a ready export downloads its archive
+func (suite *DataExportServiceTestSuite) TestGetArchive_SignedLink() {
+	// Arrange
+	suite.mockExportRepo.On("GetByID", suite.ctx, "export-1").Return(suite.readyExport(), nil)
+	suite.mockExportRepo.On("GetArchive", suite.ctx, "export-1").Return([]byte("archive"), nil)
+
+	export, err := suite.service.GetExport(suite.ctx, 1, "export-1")
+	suite.Require().NoError(err)
+	suite.Require().True(strings.HasPrefix(export.DownloadURL, "/api/exports/export-1?"))
+	expires, signature := suite.downloadLink(export.DownloadURL)
+
+	// Act
+	archive, err := suite.service.GetArchive(suite.ctx, "export-1", expires, signature)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []byte("archive"), archive)
+	assert.WithinDuration(suite.T(), time.Now().Add(DataExportLinkTTL), time.Unix(expires, 0), time.Minute)
+}
+
+// TestGetArchive_TamperedLink tests that links with a changed expiry, export or signature are refused
+func (suite *DataExportServiceTestSuite) TestGetArchive_TamperedLink() {
+	// Arrange
+	suite.mockExportRepo.On("GetByID", suite.ctx, "export-1").Return(suite.readyExport(), nil)
+
+	export, err := suite.service.GetExport(suite.ctx, 1, "export-1")
+	suite.Require().NoError(err)
+	expires, signature := suite.downloadLink(export.DownloadURL)
+
+	// Act & Assert
+	_, err = suite.service.GetArchive(suite.ctx, "export-1", expires+3600, signature)
+	assert.EqualError(suite.T(), err, "invalid or expired link")
+
+	_, err = suite.service.GetArchive(suite.ctx, "export-2", expires, signature)
+	assert.EqualError(suite.T(), err, "invalid or expired link")
+
+	_, err = suite.service.GetArchive(suite.ctx, "export-1", expires, "00"+signature[2:])
+	assert.EqualError(suite.T(), err, "invalid or expired link")
+
+	suite.mockExportRepo.AssertNotCalled(suite.T(), "GetArchive", mock.Anything, mock.Anything)
+}
+
+// TestGetArchive_ExpiredLink tests that links stop working once they expire
+func (suite *DataExportServiceTestSuite) TestGetArchive_ExpiredLink() {
+	// Arrange
+	expires := time.Now().Add(-time.Minute).Unix()
+	signature := suite.service.(*dataExportServiceImpl).sign("export-1", expires)
+
+	// Act
+	_, err := suite.service.GetArchive(suite.ctx, "export-1", expires, signature)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "invalid or expired link")
+}
+
+// TestGetExport_OtherUser tests that the exports of other users are not found
+func (suite *DataExportServiceTestSuite) TestGetExport_OtherUser() {
+	// Arrange
+	suite.mockExportRepo.On("GetByID", suite.ctx, "export-1").Return(suite.readyExport(), nil)
+
+	// Act
+	_, err := suite.service.GetExport(suite.ctx, 2, "export-1")
+
+	// Assert
+	assert.EqualError(suite.T(), err, "export not found")
+}
+
+// TestDataExportServiceSuite runs the test suite
+func TestDataExportServiceSuite(t *testing.T) {
+	suite.Run(t, new(DataExportServiceTestSuite))
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"todo-list-api/internal/repository"
+)
+
+// DataRetentionConfig configures how long data is kept
+type DataRetentionConfig struct {
+	// AccountGracePeriod is how long deleted accounts are kept before they
+	// and their todos are deleted permanently
+	AccountGracePeriod time.Duration
+	// TrashRetention is how long deleted todos stay in the trash before they
+	// are deleted permanently
+	TrashRetention time.Duration
+	// Interval is how often the data is purged
+	Interval time.Duration
+}
+
+// DefaultDataRetentionConfig returns the retention used when nothing is configured
+func DefaultDataRetentionConfig() DataRetentionConfig {
+	return DataRetentionConfig{
+		AccountGracePeriod: 30 * 24 * time.Hour,
+		TrashRetention:     30 * 24 * time.Hour,
+		Interval:           time.Hour,
+	}
+}
+
+// DataRetention permanently deletes the data that is kept no longer: deleted
+// accounts once their grace period is over, todos that have been in the
+// trash for longer than the retention and expired data exports. It also fails
+// the data exports whose build was abandoned.
+type DataRetention struct {
+	userRepo   repository.UserRepository
+	todoRepo   repository.TodoRepository
+	exportRepo repository.DataExportRepository
+	config     DataRetentionConfig
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewDataRetention creates a new DataRetention
+func NewDataRetention(userRepo repository.UserRepository, todoRepo repository.TodoRepository, exportRepo repository.DataExportRepository, config DataRetentionConfig) *DataRetention {
+	return &DataRetention{
+		userRepo:   userRepo,
+		todoRepo:   todoRepo,
+		exportRepo: exportRepo,
+		config:     config,
+	}
+}
+
+// Start purges the data in the background every Interval until Stop is called
+func (r *DataRetention) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			if err := r.Purge(ctx, time.Now().UTC()); err != nil {
+				log.Printf("Failed to purge data: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the purging and waits until the purge in progress is over, or
+// until ctx is done
+func (r *DataRetention) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Purge deletes the data that is kept no longer at now. Every kind of data
+// is purged even if another one fails.
+func (r *DataRetention) Purge(ctx context.Context, now time.Time) error {
+	users, userErr := r.userRepo.PurgeDeleted(ctx, now.Add(-r.config.AccountGracePeriod))
+	if users > 0 {
+		log.Printf("Permanently deleted %d accounts", users)
+	}
+
+	todos, todoErr := r.todoRepo.PurgeTrash(ctx, now.Add(-r.config.TrashRetention))
+	if todos > 0 {
+		log.Printf("Permanently deleted %d todos from the trash", todos)
+	}
+
+	stale, staleErr := r.exportRepo.FailStale(ctx, now.Add(-dataExportTimeout), now)
+	if stale > 0 {
+		log.Printf("Marked %d abandoned data exports as failed", stale)
+	}
+
+	exports, exportErr := r.exportRepo.DeleteExpired(ctx, now)
+	if exports > 0 {
+		log.Printf("Deleted %d expired data exports", exports)
+	}
+
+	return errors.Join(userErr, todoErr, staleErr, exportErr)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DataRetentionTestSuite struct {
+	suite.Suite
+	mockUserRepo   *mocks.MockUserRepository
+	mockTodoRepo   *mocks.MockTodoRepository
+	mockExportRepo *mocks.MockDataExportRepository
+	retention      *DataRetention
+	ctx            context.Context
+	now            time.Time
+}
+
+func (suite *DataRetentionTestSuite) SetupTest() {
+	suite.mockUserRepo = new(mocks.MockUserRepository)
+	suite.mockTodoRepo = new(mocks.MockTodoRepository)
+	suite.mockExportRepo = new(mocks.MockDataExportRepository)
+	suite.retention = NewDataRetention(suite.mockUserRepo, suite.mockTodoRepo, suite.mockExportRepo, DataRetentionConfig{
+		AccountGracePeriod: 48 * time.Hour,
+		TrashRetention:     24 * time.Hour,
+		Interval:           time.Hour,
+	})
+	suite.ctx = context.Background()
+	suite.now = time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+}
+
+// TestPurge_Success tests that accounts are purged after the grace period, along with old trash and expired exports
+func (suite *DataRetentionTestSuite) TestPurge_Success() {
+	// Arrange
+	suite.mockUserRepo.On("PurgeDeleted", suite.ctx, suite.now.Add(-48*time.Hour)).Return(int64(1), nil)
+	suite.mockTodoRepo.On("PurgeTrash", suite.ctx, suite.now.Add(-24*time.Hour)).Return(int64(3), nil)
+	suite.mockExportRepo.On("FailStale", suite.ctx, suite.now.Add(-dataExportTimeout), suite.now).Return(int64(1), nil)
+	suite.mockExportRepo.On("DeleteExpired", suite.ctx, suite.now).Return(int64(2), nil)
+
+	// Act
+	err := suite.retention.Purge(suite.ctx, suite.now)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockTodoRepo.AssertExpectations(suite.T())
+	suite.mockExportRepo.AssertExpectations(suite.T())
+}
+
+// TestPurge_ContinuesAfterFailure tests that a failure to purge one kind of data does not keep the others
+func (suite *DataRetentionTestSuite) TestPurge_ContinuesAfterFailure() {
+	// Arrange
+	suite.mockUserRepo.On("PurgeDeleted", suite.ctx, suite.now.Add(-48*time.Hour)).Return(int64(0), assert.AnError)
+	suite.mockTodoRepo.On("PurgeTrash", suite.ctx, suite.now.Add(-24*time.Hour)).Return(int64(3), nil)
+	suite.mockExportRepo.On("FailStale", suite.ctx, suite.now.Add(-dataExportTimeout), suite.now).Return(int64(0), nil)
+	suite.mockExportRepo.On("DeleteExpired", suite.ctx, suite.now).Return(int64(2), nil)
+
+	// Act
+	err := suite.retention.Purge(suite.ctx, suite.now)
+
+	// Assert
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+	suite.mockTodoRepo.AssertExpectations(suite.T())
+	suite.mockExportRepo.AssertExpectations(suite.T())
+}
+
+// TestDataRetentionSuite runs the test suite
+func TestDataRetentionSuite(t *testing.T) {
+	suite.Run(t, new(DataRetentionTestSuite))
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// LoginThrottleConfig holds the limits on failed logins
+type LoginThrottleConfig struct {
+	// MaxFailures is how many failed logins an email may have before it is locked
+	MaxFailures int
+	// MaxIPFailures is how many failed logins a client IP may have before it is locked
+	MaxIPFailures int
+	// BaseLockout is the first lockout. Every further failure doubles it, up to MaxLockout.
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	// FailureWindow is how long failures are remembered after the last one
+	FailureWindow time.Duration
+}
+
+// DefaultLoginThrottleConfig returns the limits used unless configured otherwise
+func DefaultLoginThrottleConfig() LoginThrottleConfig {
+	return LoginThrottleConfig{
+		MaxFailures:   5,
+		MaxIPFailures: 20,
+		BaseLockout:   time.Minute,
+		MaxLockout:    time.Hour,
+		FailureWindow: time.Hour,
+	}
+}
+
+// LoginThrottledError is returned for logins refused because of too many
+// failures. RetryAfter tells when the next attempt may succeed.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many login attempts"
+}
+
+// LoginThrottle tracks failed logins per email and per client IP and locks
+// them out, for exponentially longer periods, once they fail too often
+type LoginThrottle struct {
+	attemptRepo repository.LoginAttemptRepository
+	config      LoginThrottleConfig
+}
+
+// NewLoginThrottle creates a new LoginThrottle storing its counts in attemptRepo
+func NewLoginThrottle(attemptRepo repository.LoginAttemptRepository, config LoginThrottleConfig) *LoginThrottle {
+	return &LoginThrottle{
+		attemptRepo: attemptRepo,
+		config:      config,
+	}
+}
+
+// Check returns how long logins for the email from the IP are still locked,
+// or zero if they are allowed
+func (t *LoginThrottle) Check(ctx context.Context, email, ip string) (time.Duration, error) {
+	var retryAfter time.Duration
+	for _, key := range t.keys(email, ip) {
+		attempt, err := t.attemptRepo.Get(ctx, key)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return 0, err
+		}
+		if attempt.LockedUntil != nil {
+			if remaining := time.Until(*attempt.LockedUntil); remaining > retryAfter {
+				retryAfter = remaining
+			}
+		}
+	}
+	return retryAfter, nil
+}
+
+// RecordFailure counts a failed login for the email and the IP and locks those
+// over their limit. It returns how long the email was locked for, if it was.
+func (t *LoginThrottle) RecordFailure(ctx context.Context, email, ip string) (time.Duration, error) {
+	now := time.Now().UTC()
+	var emailLockout time.Duration
+
+	for _, key := range t.keys(email, ip) {
+		attempt, err := t.attemptRepo.RecordFailure(ctx, key, now, t.config.FailureWindow)
+		if err != nil {
+			return 0, err
+		}
+
+		limit := t.config.MaxFailures
+		if strings.HasPrefix(key, "ip:") {
+			limit = t.config.MaxIPFailures
+		}
+		if attempt.Failures < limit {
+			continue
+		}
+
+		lockout := t.lockout(attempt.Failures - limit)
+		if err := t.attemptRepo.Lock(ctx, key, now.Add(lockout)); err != nil {
+			return 0, err
+		}
+		if strings.HasPrefix(key, "email:") {
+			emailLockout = lockout
+		}
+	}
+	return emailLockout, nil
+}
+
+// Reset forgets the failures of the email after a successful login. Those of
+// the IP are kept: a login to one account says nothing about guesses at others.
+func (t *LoginThrottle) Reset(ctx context.Context, email string) error {
+	return t.attemptRepo.Reset(ctx, emailKey(email))
+}
+
+// lockout returns the lockout after the given number of failures beyond the limit
+func (t *LoginThrottle) lockout(excess int) time.Duration {
+	lockout := t.config.BaseLockout
+	for i := 0; i < excess && lockout < t.config.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > t.config.MaxLockout {
+		lockout = t.config.MaxLockout
+	}
+	return lockout
+}
+
+func (t *LoginThrottle) keys(email, ip string) []string {
+	keys := []string{emailKey(email)}
+	if ip != "" {
+		keys = append(keys, "ip:"+utils.HashToken(ip))
+	}
+	return keys
+}
+
+// emailKey returns the key of the failures of an email. Subjects are hashed so
+// that keys have a fixed length whatever the client sends.
+func emailKey(email string) string {
+	return "email:" + utils.HashToken(strings.ToLower(strings.TrimSpace(email)))
+}
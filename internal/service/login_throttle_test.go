@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"todo-list-api/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoginThrottle() *LoginThrottle {
+	return NewLoginThrottle(repository.NewMemoryLoginAttemptRepository(), LoginThrottleConfig{
+		MaxFailures:   3,
+		MaxIPFailures: 5,
+		BaseLockout:   time.Minute,
+		MaxLockout:    5 * time.Minute,
+		FailureWindow: time.Hour,
+	})
+}
+
+func TestLoginThrottle_ExponentialBackoff(t *testing.T) {
+	throttle := newTestLoginThrottle()
+	ctx := context.Background()
+
+	var lockouts []time.Duration
+	for i := 0; i < 7; i++ {
+		lockout, err := throttle.RecordFailure(ctx, "User@Example.com", "")
+		require.NoError(t, err)
+		lockouts = append(lockouts, lockout)
+	}
+
+	assert.Equal(t, []time.Duration{0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}, lockouts)
+
+	// Emails are compared case-insensitively
+	retryAfter, err := throttle.Check(ctx, "user@example.com", "")
+	require.NoError(t, err)
+	assert.InDelta(t, 5*time.Minute, retryAfter, float64(time.Second))
+}
+
+func TestLoginThrottle_IPLimit(t *testing.T) {
+	throttle := newTestLoginThrottle()
+	ctx := context.Background()
+
+	// Spreading guesses over many accounts does not escape the IP limit
+	emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"}
+	for _, email := range emails {
+		lockout, err := throttle.RecordFailure(ctx, email, "203.0.113.7")
+		require.NoError(t, err)
+		assert.Zero(t, lockout)
+	}
+
+	retryAfter, err := throttle.Check(ctx, "f@example.com", "203.0.113.7")
+	require.NoError(t, err)
+	assert.Greater(t, retryAfter, time.Duration(0))
+
+	retryAfter, err = throttle.Check(ctx, "f@example.com", "198.51.100.1")
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+}
+
+func TestLoginThrottle_ResetOnSuccess(t *testing.T) {
+	throttle := newTestLoginThrottle()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := throttle.RecordFailure(ctx, "user@example.com", "")
+		require.NoError(t, err)
+	}
+	require.NoError(t, throttle.Reset(ctx, "user@example.com"))
+
+	lockout, err := throttle.RecordFailure(ctx, "user@example.com", "")
+	require.NoError(t, err)
+	assert.Zero(t, lockout)
+}
+
+func TestLoginThrottle_KeysHaveFixedLength(t *testing.T) {
+	throttle := newTestLoginThrottle()
+
+	// Keys fit the login_attempts key column whatever the client sends
+	longEmail := strings.Repeat("a", 400) + "@example.com"
+	for _, key := range throttle.keys(longEmail, strings.Repeat("f", 400)) {
+		assert.LessOrEqual(t, len(key), 70)
+	}
+	assert.Equal(t, emailKey("User@Example.com "), emailKey("user@example.com"))
+}
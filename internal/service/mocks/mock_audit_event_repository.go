@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditEventRepository) ListByUserID(ctx context.Context, userID uint64) ([]models.AuditEvent, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AuditEvent), args.Error(1)
+}
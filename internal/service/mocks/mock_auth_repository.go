@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepository) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAuthRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAuthRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAuthRepository) UpdatePassword(ctx context.Context, id uint64, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) MarkEmailVerified(ctx context.Context, id uint64, verifiedAt time.Time) error {
+	args := m.Called(ctx, id, verifiedAt)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) UpdateProfile(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) UpdateEmail(ctx context.Context, id uint64, email string, verifiedAt time.Time) error {
+	args := m.Called(ctx, id, email, verifiedAt)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) DeleteUser(ctx context.Context, id uint64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
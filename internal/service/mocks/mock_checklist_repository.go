@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockChecklistRepository struct {
+	mock.Mock
+}
+
+func (m *MockChecklistRepository) ListByTodoID(ctx context.Context, todoID uint) ([]models.ChecklistItem, error) {
+	args := m.Called(ctx, todoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockChecklistRepository) GetByID(ctx context.Context, todoID uint, id uint) (*models.ChecklistItem, error) {
+	args := m.Called(ctx, todoID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockChecklistRepository) Create(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	args := m.Called(ctx, item)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockChecklistRepository) Update(ctx context.Context, item *models.ChecklistItem) (*models.ChecklistItem, error) {
+	args := m.Called(ctx, item)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChecklistItem), args.Error(1)
+}
+
+func (m *MockChecklistRepository) Delete(ctx context.Context, todoID uint, id uint) error {
+	args := m.Called(ctx, todoID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockClientKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockClientKeyRepository) Create(ctx context.Context, key *models.ClientKey) (*models.ClientKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClientKey), args.Error(1)
+}
+
+func (m *MockClientKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.ClientKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClientKey), args.Error(1)
+}
+
+func (m *MockClientKeyRepository) List(ctx context.Context) ([]models.ClientKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClientKey), args.Error(1)
+}
+
+func (m *MockClientKeyRepository) Revoke(ctx context.Context, id uint64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClientKeyRepository) TouchLastUsed(ctx context.Context, id uint64, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
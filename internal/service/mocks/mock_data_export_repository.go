@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDataExportRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataExportRepository) Create(ctx context.Context, export *models.DataExport) (*models.DataExport, error) {
+	args := m.Called(ctx, export)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) GetByID(ctx context.Context, id string) (*models.DataExport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) GetPendingByUserID(ctx context.Context, userID uint64) (*models.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) GetArchive(ctx context.Context, id string) ([]byte, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockDataExportRepository) Complete(ctx context.Context, id string, archive []byte, completedAt time.Time) error {
+	args := m.Called(ctx, id, archive, completedAt)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) Fail(ctx context.Context, id string, completedAt time.Time) error {
+	args := m.Called(ctx, id, completedAt)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) FailStale(ctx context.Context, createdBefore time.Time, completedAt time.Time) (int64, error) {
+	args := m.Called(ctx, createdBefore, completedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockDataExportRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) GetCredential(ctx context.Context, userID uint64) (*models.MFACredential, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MFACredential), args.Error(1)
+}
+
+func (m *MockMFARepository) SaveCredential(ctx context.Context, credential *models.MFACredential) error {
+	args := m.Called(ctx, credential)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) Confirm(ctx context.Context, userID uint64, step int64, recoveryCodeHashes []string) error {
+	args := m.Called(ctx, userID, step, recoveryCodeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseStep(ctx context.Context, userID uint64, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) RecordFailedAttempt(ctx context.Context, userID uint64, maxAttempts int, lockedUntil time.Time) error {
+	args := m.Called(ctx, userID, maxAttempts, lockedUntil)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) ResetFailedAttempts(ctx context.Context, userID uint64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID uint64, codeHash string) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uint64, codeHashes []string) error {
+	args := m.Called(ctx, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) Delete(ctx context.Context, userID uint64) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) List(ctx context.Context, userID uint, unreadOnly bool, limit int) ([]models.Notification, error) {
+	args := m.Called(ctx, userID, unreadOnly, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *models.Notification) (*models.Notification, error) {
+	args := m.Called(ctx, notification)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, userID uint, id uint, readAt time.Time) (*models.Notification, error) {
+	args := m.Called(ctx, userID, id, readAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
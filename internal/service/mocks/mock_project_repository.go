@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) List(ctx context.Context, userID uint, includeArchived bool) ([]models.Project, error) {
+	args := m.Called(ctx, userID, includeArchived)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetByID(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, project *models.Project) (*models.Project, error) {
+	args := m.Called(ctx, project)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, project *models.Project) (*models.Project, error) {
+	args := m.Called(ctx, project)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, userID uint, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReminderRepository struct {
+	mock.Mock
+}
+
+func (m *MockReminderRepository) ListByTodoID(ctx context.Context, todoID uint) ([]models.Reminder, error) {
+	args := m.Called(ctx, todoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) Create(ctx context.Context, reminder *models.Reminder) (*models.Reminder, error) {
+	args := m.Called(ctx, reminder)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) Delete(ctx context.Context, todoID uint, id uint) error {
+	args := m.Called(ctx, todoID, id)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) ClaimDue(ctx context.Context, now time.Time, until time.Time, limit int) ([]models.Reminder, error) {
+	args := m.Called(ctx, now, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Reminder), args.Error(1)
+}
+
+func (m *MockReminderRepository) Release(ctx context.Context, ids []uint) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) MarkSent(ctx context.Context, id uint, sentAt time.Time) error {
+	args := m.Called(ctx, id, sentAt)
+	return args.Error(0)
+}
+
+func (m *MockReminderRepository) MarkFailed(ctx context.Context, id uint, lastError string, retryAt *time.Time) error {
+	args := m.Called(ctx, id, lastError, retryAt)
+	return args.Error(0)
+}
@@ -0,0 +1,86 @@
+package mocks
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) List(ctx context.Context, userID uint) ([]models.Tag, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetByID(ctx context.Context, userID uint, id uint) (*models.Tag, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) GetByName(ctx context.Context, userID uint, name string) (*models.Tag, error) {
+	args := m.Called(ctx, userID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByIDs(ctx context.Context, userID uint, ids []uint) ([]models.Tag, error) {
+	args := m.Called(ctx, userID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) FindByNames(ctx context.Context, userID uint, names []string) ([]models.Tag, error) {
+	args := m.Called(ctx, userID, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) EnsureNames(ctx context.Context, userID uint, names []string) ([]models.Tag, error) {
+	args := m.Called(ctx, userID, names)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Create(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Update(ctx context.Context, tag *models.Tag) (*models.Tag, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tag), args.Error(1)
+}
+
+func (m *MockTagRepository) Delete(ctx context.Context, userID uint, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) Merge(ctx context.Context, userID uint, sourceID uint, targetID uint) error {
+	args := m.Called(ctx, userID, sourceID, targetID)
+	return args.Error(0)
+}
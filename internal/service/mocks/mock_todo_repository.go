@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 	"todo-list-api/internal/models"
 
 	"github.com/stretchr/testify/mock"
@@ -19,36 +20,96 @@ func (m *MockTodoRepository) Create(ctx context.Context, todo *models.Todo) (*mo
 	return args.Get(0).(*models.Todo), args.Error(1)
 }
 
-func (m *MockTodoRepository) GetAll(ctx context.Context) ([]models.Todo, error) {
-	args := m.Called(ctx)
+func (m *MockTodoRepository) GetByID(ctx context.Context, userID uint, id uint) (*models.Todo, error) {
+	args := m.Called(ctx, userID, id)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Todo), args.Error(1)
-}
-
-func (m *MockTodoRepository) GetByID(ctx context.Context, id uint) (*models.Todo, error) {
-	// To implement
-	return nil, nil
+	return args.Get(0).(*models.Todo), args.Error(1)
 }
 
-func (m *MockTodoRepository) Update(ctx context.Context, id uint, todo *models.Todo) (*models.Todo, error) {
-	args := m.Called(ctx, id)
+func (m *MockTodoRepository) Update(ctx context.Context, userID uint, id uint, fromStatus string, todo *models.Todo) (*models.Todo, error) {
+	args := m.Called(ctx, userID, id, fromStatus, todo)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Todo), args.Error(1)
 }
 
-func (m *MockTodoRepository) Delete(ctx context.Context, id uint) error {
-	args := m.Called(ctx, id)
+func (m *MockTodoRepository) Delete(ctx context.Context, userID uint, id uint, subtasks string) error {
+	args := m.Called(ctx, userID, id, subtasks)
 	return args.Error(0)
 }
 
-func (m *MockTodoRepository) GetByUserID(ctx context.Context, userID uint) ([]models.Todo, error) {
+func (m *MockTodoRepository) ListAllForExport(ctx context.Context, userID uint) ([]models.Todo, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]models.Todo), args.Error(1)
 }
+
+func (m *MockTodoRepository) ListTrash(ctx context.Context, userID uint, limit int, offset int) (*models.TrashPage, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TrashPage), args.Error(1)
+}
+
+func (m *MockTodoRepository) Restore(ctx context.Context, userID uint, id uint) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockTodoRepository) DeletePermanently(ctx context.Context, userID uint, id uint, subtasks string) error {
+	args := m.Called(ctx, userID, id, subtasks)
+	return args.Error(0)
+}
+
+func (m *MockTodoRepository) ListSubtasks(ctx context.Context, userID uint, id uint) ([]models.Todo, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Todo), args.Error(1)
+}
+
+func (m *MockTodoRepository) Snooze(ctx context.Context, userID uint, id uint, until *time.Time) error {
+	args := m.Called(ctx, userID, id, until)
+	return args.Error(0)
+}
+
+func (m *MockTodoRepository) SetParent(ctx context.Context, userID uint, id uint, parentID *uint) error {
+	args := m.Called(ctx, userID, id, parentID)
+	return args.Error(0)
+}
+
+func (m *MockTodoRepository) CompleteSubtasks(ctx context.Context, userID uint, id uint, status string) ([]uint, error) {
+	args := m.Called(ctx, userID, id, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockTodoRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTodoRepository) List(ctx context.Context, userID uint, query models.TodoQuery) (*models.TodoPage, error) {
+	args := m.Called(ctx, userID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TodoPage), args.Error(1)
+}
+
+func (m *MockTodoRepository) Search(ctx context.Context, userID uint, query models.TodoSearchQuery) (*models.TodoSearchPage, error) {
+	args := m.Called(ctx, userID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TodoSearchPage), args.Error(1)
+}
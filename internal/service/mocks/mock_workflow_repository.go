@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkflowRepository) Get(ctx context.Context, userID uint, projectID *uint) (*models.Workflow, error) {
+	args := m.Called(ctx, userID, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) Save(ctx context.Context, workflow *models.Workflow) (*models.Workflow, error) {
+	args := m.Called(ctx, workflow)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workflow), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) Delete(ctx context.Context, userID uint, projectID *uint) error {
+	args := m.Called(ctx, userID, projectID)
+	return args.Error(0)
+}
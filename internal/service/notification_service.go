@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// NotificationService defines the interface for in-app notification business logic operations
+type NotificationService interface {
+	// ListNotifications returns the newest notifications of the user, only
+	// the unread ones when unreadOnly is set
+	ListNotifications(ctx context.Context, userID uint, unreadOnly bool) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID uint, id uint) (*models.Notification, error)
+}
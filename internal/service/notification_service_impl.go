@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+type notificationServiceImpl struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewNotificationService creates a new instance of NotificationService
+func NewNotificationService(notificationRepo repository.NotificationRepository) NotificationService {
+	return &notificationServiceImpl{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (s *notificationServiceImpl) ListNotifications(ctx context.Context, userID uint, unreadOnly bool) ([]models.Notification, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.notificationRepo.List(ctx, userID, unreadOnly, models.MaxNotificationsListed)
+}
+
+func (s *notificationServiceImpl) MarkRead(ctx context.Context, userID uint, id uint) (*models.Notification, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("notification not found")
+	}
+
+	return s.notificationRepo.MarkRead(ctx, userID, id, time.Now().UTC())
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/utils"
+)
+
+// PersonalAccessTokenService defines the interface for personal access token business logic operations
+type PersonalAccessTokenService interface {
+	// CreateToken mints a token for the user. The returned token is the only
+	// time its secret value is available.
+	CreateToken(ctx context.Context, userID uint, req *models.CreatePersonalAccessTokenRequest) (*models.CreatedPersonalAccessToken, error)
+	ListTokens(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error)
+	RevokeToken(ctx context.Context, userID uint, id uint64) error
+	// AuthenticateToken resolves a token presented by a client into the claims
+	// of its user and the scopes it was granted
+	AuthenticateToken(ctx context.Context, token string) (*utils.Claims, []string, error)
+}
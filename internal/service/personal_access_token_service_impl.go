@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type personalAccessTokenServiceImpl struct {
+	tokenRepo repository.PersonalAccessTokenRepository
+	authRepo  repository.AuthRepository
+}
+
+// NewPersonalAccessTokenService creates a new instance of PersonalAccessTokenService
+func NewPersonalAccessTokenService(
+	tokenRepo repository.PersonalAccessTokenRepository,
+	authRepo repository.AuthRepository,
+) PersonalAccessTokenService {
+	return &personalAccessTokenServiceImpl{
+		tokenRepo: tokenRepo,
+		authRepo:  authRepo,
+	}
+}
+
+func (s *personalAccessTokenServiceImpl) CreateToken(ctx context.Context, userID uint, req *models.CreatePersonalAccessTokenRequest) (*models.CreatedPersonalAccessToken, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiration must be in the future")
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	token := models.PersonalAccessTokenPrefix + secret
+
+	record := &models.PersonalAccessToken{
+		UserID:    uint64(userID),
+		Name:      strings.TrimSpace(req.Name),
+		TokenHash: utils.HashToken(token),
+		Scopes:    normalizeScopes(req.Scopes),
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	created, err := s.tokenRepo.Create(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreatedPersonalAccessToken{PersonalAccessToken: *created, Token: token}, nil
+}
+
+func (s *personalAccessTokenServiceImpl) ListTokens(ctx context.Context, userID uint) ([]models.PersonalAccessToken, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.tokenRepo.ListActiveByUserID(ctx, uint64(userID))
+}
+
+func (s *personalAccessTokenServiceImpl) RevokeToken(ctx context.Context, userID uint, id uint64) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return errors.New("invalid token ID")
+	}
+
+	revoked, err := s.tokenRepo.Revoke(ctx, uint64(userID), id)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("token not found")
+	}
+	return nil
+}
+
+func (s *personalAccessTokenServiceImpl) AuthenticateToken(ctx context.Context, token string) (*utils.Claims, []string, error) {
+	if !strings.HasPrefix(token, models.PersonalAccessTokenPrefix) {
+		return nil, nil, errors.New("invalid or expired token")
+	}
+
+	record, err := s.tokenRepo.GetByHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("invalid or expired token")
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if record.RevokedAt != nil || (record.ExpiresAt != nil && now.After(*record.ExpiresAt)) {
+		return nil, nil, errors.New("invalid or expired token")
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, uint(record.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errors.New("invalid or expired token")
+		}
+		return nil, nil, err
+	}
+	if user.Disabled() {
+		return nil, nil, errors.New("invalid or expired token")
+	}
+
+	if err := s.tokenRepo.TouchLastUsed(ctx, record.ID, now.UTC()); err != nil {
+		log.Printf("Failed to record use of personal access token %d: %v", record.ID, err)
+	}
+
+	claims := &utils.Claims{
+		UserID:        user.ID,
+		UserEmail:     user.Email,
+		TokenType:     "pat",
+		EmailVerified: user.EmailVerified,
+	}
+	return claims, record.Scopes, nil
+}
+
+// normalizeScopes removes duplicate scopes, keeping the order of AllScopes
+func normalizeScopes(scopes []string) []string {
+	var normalized []string
+	for _, scope := range models.AllScopes {
+		for _, requested := range scopes {
+			if requested == scope {
+				normalized = append(normalized, scope)
+				break
+			}
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+	"todo-list-api/internal/utils"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenServiceTestSuite struct {
+	suite.Suite
+	mockTokenRepo *mocks.MockPersonalAccessTokenRepository
+	mockAuthRepo  *mocks.MockAuthRepository
+	service       PersonalAccessTokenService
+	ctx           context.Context
+	user          *models.User
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) SetupTest() {
+	suite.mockTokenRepo = new(mocks.MockPersonalAccessTokenRepository)
+	suite.mockAuthRepo = new(mocks.MockAuthRepository)
+	suite.service = NewPersonalAccessTokenService(suite.mockTokenRepo, suite.mockAuthRepo)
+	suite.ctx = context.Background()
+	suite.user = &models.User{ID: 1, Email: "user@example.com", EmailVerified: true}
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestCreateToken_Success() {
+	req := &models.CreatePersonalAccessTokenRequest{
+		Name:   " ci ",
+		Scopes: []string{models.ScopeTodosWrite, models.ScopeTodosRead, models.ScopeTodosRead},
+	}
+
+	var stored *models.PersonalAccessToken
+	call := suite.mockTokenRepo.On("Create", suite.ctx, mock.AnythingOfType("*models.PersonalAccessToken"))
+	call.Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*models.PersonalAccessToken)
+		stored.ID = 7
+		call.ReturnArguments = mock.Arguments{stored, nil}
+	})
+
+	result, err := suite.service.CreateToken(suite.ctx, 1, req)
+
+	suite.Require().NoError(err)
+	suite.True(strings.HasPrefix(result.Token, models.PersonalAccessTokenPrefix))
+	suite.Equal(uint64(7), result.ID)
+	suite.Equal("ci", stored.Name)
+	suite.Equal([]string{models.ScopeTodosRead, models.ScopeTodosWrite}, stored.Scopes)
+	suite.Equal(utils.HashToken(result.Token), stored.TokenHash)
+	suite.NotContains(stored.TokenHash, result.Token)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestCreateToken_PastExpiration() {
+	past := time.Now().Add(-time.Minute)
+	req := &models.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{models.ScopeTodosRead}, ExpiresAt: &past}
+
+	result, err := suite.service.CreateToken(suite.ctx, 1, req)
+
+	suite.Nil(result)
+	suite.EqualError(err, "expiration must be in the future")
+	suite.mockTokenRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestAuthenticateToken_Success() {
+	token := models.PersonalAccessTokenPrefix + "secret"
+	record := &models.PersonalAccessToken{ID: 7, UserID: 1, TokenHash: utils.HashToken(token), Scopes: []string{models.ScopeTodosRead}}
+
+	suite.mockTokenRepo.On("GetByHash", suite.ctx, utils.HashToken(token)).Return(record, nil)
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, uint(1)).Return(suite.user, nil)
+	suite.mockTokenRepo.On("TouchLastUsed", suite.ctx, uint64(7), mock.AnythingOfType("time.Time")).Return(nil)
+
+	claims, scopes, err := suite.service.AuthenticateToken(suite.ctx, token)
+
+	suite.Require().NoError(err)
+	suite.Equal(uint64(1), claims.UserID)
+	suite.Equal("user@example.com", claims.UserEmail)
+	suite.Equal("pat", claims.TokenType)
+	suite.Equal([]string{models.ScopeTodosRead}, scopes)
+	suite.mockTokenRepo.AssertExpectations(suite.T())
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestAuthenticateToken_Rejected() {
+	past := time.Now().Add(-time.Minute)
+	cases := map[string]*models.PersonalAccessToken{
+		"revoked": {ID: 7, UserID: 1, RevokedAt: &past},
+		"expired": {ID: 7, UserID: 1, ExpiresAt: &past},
+	}
+
+	for name, record := range cases {
+		suite.Run(name, func() {
+			suite.SetupTest()
+			token := models.PersonalAccessTokenPrefix + name
+			suite.mockTokenRepo.On("GetByHash", suite.ctx, utils.HashToken(token)).Return(record, nil)
+
+			claims, _, err := suite.service.AuthenticateToken(suite.ctx, token)
+
+			suite.Nil(claims)
+			suite.EqualError(err, "invalid or expired token")
+			suite.mockTokenRepo.AssertNotCalled(suite.T(), "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestAuthenticateToken_Unknown() {
+	token := models.PersonalAccessTokenPrefix + "unknown"
+	suite.mockTokenRepo.On("GetByHash", suite.ctx, utils.HashToken(token)).Return(nil, gorm.ErrRecordNotFound)
+
+	_, _, err := suite.service.AuthenticateToken(suite.ctx, token)
+
+	suite.EqualError(err, "invalid or expired token")
+}
+
+func (suite *PersonalAccessTokenServiceTestSuite) TestRevokeToken_NotFound() {
+	suite.mockTokenRepo.On("Revoke", suite.ctx, uint64(1), uint64(9)).Return(false, nil)
+
+	err := suite.service.RevokeToken(suite.ctx, 1, 9)
+
+	suite.EqualError(err, "token not found")
+}
+
+func TestPersonalAccessTokenServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PersonalAccessTokenServiceTestSuite))
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// ProjectService defines the interface for project business logic operations.
+// All operations act on behalf of userID and only ever touch that user's projects.
+type ProjectService interface {
+	// ListProjects returns the projects in order, archived ones only when includeArchived is set
+	ListProjects(ctx context.Context, userID uint, includeArchived bool) ([]models.Project, error)
+	GetProject(ctx context.Context, userID uint, id uint) (*models.Project, error)
+	CreateProject(ctx context.Context, userID uint, req *models.CreateProjectRequest) (*models.Project, error)
+	UpdateProject(ctx context.Context, userID uint, id uint, req *models.UpdateProjectRequest) (*models.Project, error)
+	// DeleteProject deletes the project and moves its todos to the Inbox
+	DeleteProject(ctx context.Context, userID uint, id uint) error
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+type projectServiceImpl struct {
+	projectRepo repository.ProjectRepository
+}
+
+// NewProjectService creates a new instance of ProjectService
+func NewProjectService(projectRepo repository.ProjectRepository) ProjectService {
+	return &projectServiceImpl{
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *projectServiceImpl) ListProjects(ctx context.Context, userID uint, includeArchived bool) ([]models.Project, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.projectRepo.List(ctx, userID, includeArchived)
+}
+
+func (s *projectServiceImpl) GetProject(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("project not found")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errors.New("project not found")
+	}
+	return project, nil
+}
+
+func (s *projectServiceImpl) CreateProject(ctx context.Context, userID uint, req *models.CreateProjectRequest) (*models.Project, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("project name is required")
+	}
+
+	// New projects go to the end of the list unless placed explicitly
+	position := 0
+	if req.Position != nil {
+		position = *req.Position
+	} else {
+		projects, err := s.projectRepo.List(ctx, userID, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			if project.Position >= position {
+				position = project.Position + 1
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	return s.projectRepo.Create(ctx, &models.Project{
+		UserID:    uint64(userID),
+		Name:      name,
+		Color:     req.Color,
+		Icon:      strings.TrimSpace(req.Icon),
+		Position:  position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (s *projectServiceImpl) UpdateProject(ctx context.Context, userID uint, id uint, req *models.UpdateProjectRequest) (*models.Project, error) {
+	project, err := s.GetProject(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		project.Name = strings.TrimSpace(*req.Name)
+		if project.Name == "" {
+			return nil, errors.New("project name is required")
+		}
+	}
+	if req.Color != nil {
+		project.Color = *req.Color
+	}
+	if req.Icon != nil {
+		project.Icon = strings.TrimSpace(*req.Icon)
+	}
+	if req.Archived != nil {
+		project.Archived = *req.Archived
+	}
+	if req.Position != nil {
+		project.Position = *req.Position
+	}
+	project.UpdatedAt = time.Now().UTC()
+
+	return s.projectRepo.Update(ctx, project)
+}
+
+func (s *projectServiceImpl) DeleteProject(ctx context.Context, userID uint, id uint) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return errors.New("project not found")
+	}
+
+	return s.projectRepo.Delete(ctx, userID, id)
+}
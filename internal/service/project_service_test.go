@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProjectServiceTestSuite struct {
+	suite.Suite
+	mockProjectRepo *mocks.MockProjectRepository
+	service         ProjectService
+	ctx             context.Context
+	userID          uint
+}
+
+func (suite *ProjectServiceTestSuite) SetupTest() {
+	suite.mockProjectRepo = new(mocks.MockProjectRepository)
+	suite.service = NewProjectService(suite.mockProjectRepo)
+	suite.ctx = context.Background()
+	suite.userID = 1
+}
+
+// TestCreateProject_AppendsToEnd tests that new projects go after the last one, archived or not
+func (suite *ProjectServiceTestSuite) TestCreateProject_AppendsToEnd() {
+	// Arrange
+	suite.mockProjectRepo.On("List", suite.ctx, suite.userID, true).
+		Return([]models.Project{{ID: 1, Position: 0}, {ID: 2, Position: 3, Archived: true}}, nil)
+	suite.mockProjectRepo.On("Create", suite.ctx, mock.MatchedBy(func(project *models.Project) bool {
+		return project.UserID == uint64(suite.userID) && project.Name == "Home" && project.Position == 4
+	})).Return(&models.Project{ID: 3, Name: "Home", Position: 4}, nil)
+
+	// Act
+	project, err := suite.service.CreateProject(suite.ctx, suite.userID, &models.CreateProjectRequest{Name: " Home "})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, project.Position)
+	suite.mockProjectRepo.AssertExpectations(suite.T())
+}
+
+// TestCreateProject_BlankName tests that a name of only spaces is rejected
+func (suite *ProjectServiceTestSuite) TestCreateProject_BlankName() {
+	// Act
+	project, err := suite.service.CreateProject(suite.ctx, suite.userID, &models.CreateProjectRequest{Name: "   "})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "project name is required")
+	assert.Nil(suite.T(), project)
+}
+
+// TestUpdateProject_Archive tests that only the fields that are set change
+func (suite *ProjectServiceTestSuite) TestUpdateProject_Archive() {
+	// Arrange
+	archived := true
+	suite.mockProjectRepo.On("GetByID", suite.ctx, suite.userID, uint(2)).
+		Return(&models.Project{ID: 2, Name: "Work", Color: "#00ff00", Position: 1}, nil)
+	suite.mockProjectRepo.On("Update", suite.ctx, mock.MatchedBy(func(project *models.Project) bool {
+		return project.Archived && project.Name == "Work" && project.Color == "#00ff00" && project.Position == 1
+	})).Return(&models.Project{ID: 2, Name: "Work", Archived: true}, nil)
+
+	// Act
+	project, err := suite.service.UpdateProject(suite.ctx, suite.userID, 2, &models.UpdateProjectRequest{Archived: &archived})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), project.Archived)
+	suite.mockProjectRepo.AssertExpectations(suite.T())
+}
+
+// TestGetProject_NotFound tests that projects of other users are not found
+func (suite *ProjectServiceTestSuite) TestGetProject_NotFound() {
+	// Arrange
+	suite.mockProjectRepo.On("GetByID", suite.ctx, suite.userID, uint(9)).Return(nil, nil)
+
+	// Act
+	project, err := suite.service.GetProject(suite.ctx, suite.userID, 9)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "project not found")
+	assert.Nil(suite.T(), project)
+}
+
+// TestProjectServiceSuite runs the test suite
+func TestProjectServiceSuite(t *testing.T) {
+	suite.Run(t, new(ProjectServiceTestSuite))
+}
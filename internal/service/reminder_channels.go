@@ -0,0 +1,244 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+	"todo-list-api/internal/mailer"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+// ReminderChannel delivers fired reminders. Reminders are given with their
+// todo and its owner.
+type ReminderChannel interface {
+	Deliver(ctx context.Context, reminder *models.Reminder) error
+}
+
+// EmailReminderChannel emails reminders to the owner of the todo
+type EmailReminderChannel struct {
+	mailer mailer.Mailer
+}
+
+// NewEmailReminderChannel creates a new EmailReminderChannel
+func NewEmailReminderChannel(mailer mailer.Mailer) *EmailReminderChannel {
+	return &EmailReminderChannel{mailer: mailer}
+}
+
+func (c *EmailReminderChannel) Deliver(ctx context.Context, reminder *models.Reminder) error {
+	if reminder.Todo == nil {
+		return errors.New("reminder has no todo")
+	}
+	if reminder.Todo.User == nil {
+		return errors.New("todo has no owner")
+	}
+
+	title, body := reminderText(reminder.Todo)
+	return c.mailer.Send(ctx, mailer.Message{
+		To:      reminder.Todo.User.Email,
+		Subject: title,
+		Body:    body,
+	})
+}
+
+// WebhookReminderChannel posts reminders as JSON to the URL of the reminder.
+// With a secret, the body is signed in the X-Reminder-Signature header as
+// "sha256=" followed by the hex HMAC-SHA256 of the body.
+type WebhookReminderChannel struct {
+	client *http.Client
+	secret []byte
+}
+
+// NewWebhookReminderChannel creates a new WebhookReminderChannel. secret may be empty.
+func NewWebhookReminderChannel(client *http.Client, secret []byte) *WebhookReminderChannel {
+	return &WebhookReminderChannel{client: client, secret: secret}
+}
+
+// NewWebhookClient returns the HTTP client of webhooks, whose URLs are chosen
+// by users. It only connects to public addresses, checked once host names are
+// resolved, does not go through proxies and does not follow redirects.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: dialPublicOnly}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// nonPublicNetworks are the special-purpose ranges not covered by the net.IP
+// predicates used by dialPublicOnly
+var nonPublicNetworks = mustParseCIDRs(
+	"0.0.0.0/8",      // "this" network
+	"100.64.0.0/10",  // carrier-grade NAT
+	"192.0.0.0/24",   // IETF protocol assignments
+	"198.18.0.0/15",  // benchmarking
+	"240.0.0.0/4",    // reserved, and the broadcast address
+	"64:ff9b::/96",   // NAT64
+	"64:ff9b:1::/48", // local-use NAT64
+)
+
+// dialPublicOnly refuses connections to loopback, private, link-local,
+// multicast and other non-public addresses
+func dialPublicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address %s", address)
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return fmt.Errorf("address %s is not public", ip)
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return fmt.Errorf("address %s is not public", ip)
+		}
+	}
+	return nil
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// reminderWebhookPayload is the body posted by WebhookReminderChannel
+type reminderWebhookPayload struct {
+	Event      string              `json:"event"`
+	ReminderID uint                `json:"reminderId"`
+	Todo       reminderWebhookTodo `json:"todo"`
+	FiredAt    time.Time           `json:"firedAt"`
+}
+
+type reminderWebhookTodo struct {
+	ID      uint       `json:"id"`
+	Title   string     `json:"title"`
+	DueDate *time.Time `json:"dueDate"`
+}
+
+func (c *WebhookReminderChannel) Deliver(ctx context.Context, reminder *models.Reminder) error {
+	if reminder.Todo == nil {
+		return errors.New("reminder has no todo")
+	}
+	if reminder.WebhookURL == "" {
+		return errors.New("reminder has no webhook URL")
+	}
+
+	body, err := json.Marshal(reminderWebhookPayload{
+		Event:      "todo.reminder",
+		ReminderID: reminder.ID,
+		Todo: reminderWebhookTodo{
+			ID:      reminder.Todo.ID,
+			Title:   reminder.Todo.Title,
+			DueDate: reminder.Todo.DueDate,
+		},
+		FiredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reminder.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.secret) > 0 {
+		mac := hmac.New(sha256.New, c.secret)
+		mac.Write(body)
+		req.Header.Set("X-Reminder-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &reminderDeliveryError{fmt.Sprintf("webhook responded with status %d", resp.StatusCode)}
+	}
+	return nil
+}
+
+// reminderDeliveryError is a delivery failure whose message can be shown to
+// the owner of the reminder. The messages of other errors may describe the
+// network of the server, so they are only logged.
+type reminderDeliveryError struct {
+	message string
+}
+
+func (e *reminderDeliveryError) Error() string {
+	return e.message
+}
+
+// InAppReminderChannel turns reminders into notifications of the owner of the todo
+type InAppReminderChannel struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewInAppReminderChannel creates a new InAppReminderChannel
+func NewInAppReminderChannel(notificationRepo repository.NotificationRepository) *InAppReminderChannel {
+	return &InAppReminderChannel{notificationRepo: notificationRepo}
+}
+
+func (c *InAppReminderChannel) Deliver(ctx context.Context, reminder *models.Reminder) error {
+	if reminder.Todo == nil {
+		return errors.New("reminder has no todo")
+	}
+
+	title, body := reminderText(reminder.Todo)
+	_, err := c.notificationRepo.Create(ctx, &models.Notification{
+		UserID:    reminder.Todo.UserID,
+		TodoID:    &reminder.Todo.ID,
+		Title:     title,
+		Body:      body,
+		CreatedAt: time.Now().UTC(),
+	})
+	return err
+}
+
+// reminderText returns the title and body of a reminder of todo. The due
+// date is shown in the time zone of the owner when it is known.
+func reminderText(todo *models.Todo) (string, string) {
+	title := "Reminder: " + todo.Title
+	if todo.DueDate == nil {
+		return title, todo.Title
+	}
+
+	loc := time.UTC
+	if todo.User != nil && todo.User.Preferences.Timezone != "" {
+		if userLoc, err := time.LoadLocation(todo.User.Preferences.Timezone); err == nil {
+			loc = userLoc
+		}
+	}
+	return title, fmt.Sprintf("%s is due %s", todo.Title, todo.DueDate.In(loc).Format("Mon Jan 2, 2006 at 15:04 MST"))
+}
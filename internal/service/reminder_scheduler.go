@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+// ReminderSchedulerConfig configures the reminder scheduler
+type ReminderSchedulerConfig struct {
+	// PollInterval is how often due reminders are looked for
+	PollInterval time.Duration
+	// BatchSize bounds the reminders claimed at once
+	BatchSize int
+	// Lease is how long a claimed reminder is held back for its delivery. The
+	// reminders of a scheduler that dies while delivering are claimed again
+	// once it is over.
+	Lease time.Duration
+	// MaxAttempts bounds the delivery attempts of a reminder
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry. It doubles with every
+	// failed attempt, up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+}
+
+// DefaultReminderSchedulerConfig returns the configuration used when nothing is configured
+func DefaultReminderSchedulerConfig() ReminderSchedulerConfig {
+	return ReminderSchedulerConfig{
+		PollInterval:    30 * time.Second,
+		BatchSize:       50,
+		Lease:           5 * time.Minute,
+		MaxAttempts:     5,
+		RetryBackoff:    time.Minute,
+		MaxRetryBackoff: time.Hour,
+	}
+}
+
+// ReminderScheduler fires due reminders through their channel. Reminders are
+// claimed in the database, so several instances may run a scheduler without
+// delivering a reminder twice.
+type ReminderScheduler struct {
+	reminderRepo repository.ReminderRepository
+	channels     map[string]ReminderChannel
+	config       ReminderSchedulerConfig
+	cancel       context.CancelFunc
+	done         chan struct{}
+}
+
+// NewReminderScheduler creates a new ReminderScheduler delivering through
+// channels, keyed by models.ReminderChannel* values
+func NewReminderScheduler(reminderRepo repository.ReminderRepository, channels map[string]ReminderChannel, config ReminderSchedulerConfig) *ReminderScheduler {
+	return &ReminderScheduler{
+		reminderRepo: reminderRepo,
+		channels:     channels,
+		config:       config,
+	}
+}
+
+// Start fires reminders in the background every PollInterval until Stop is called
+func (s *ReminderScheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := s.Fire(ctx, time.Now().UTC()); err != nil {
+				log.Printf("Failed to fire reminders: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops the scheduler and waits until the delivery in progress is
+// over, or until ctx is done
+func (s *ReminderScheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Fire claims the reminders due at now and delivers them, returning how
+// many were delivered. When ctx is done, the delivery in progress is
+// finished and the other claimed reminders are released.
+func (s *ReminderScheduler) Fire(ctx context.Context, now time.Time) (int, error) {
+	reminders, err := s.reminderRepo.ClaimDue(ctx, now, now.Add(s.config.Lease), s.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Deliveries are not cut short by stopping the scheduler
+	deliveryCtx := context.WithoutCancel(ctx)
+
+	delivered := 0
+	for i := range reminders {
+		if ctx.Err() != nil {
+			ids := make([]uint, 0, len(reminders)-i)
+			for _, reminder := range reminders[i:] {
+				ids = append(ids, reminder.ID)
+			}
+			return delivered, s.reminderRepo.Release(deliveryCtx, ids)
+		}
+
+		ok, err := s.deliver(deliveryCtx, &reminders[i], now)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliver sends a claimed reminder through its channel and records the
+// outcome. A failed delivery is retried with backoff until MaxAttempts.
+func (s *ReminderScheduler) deliver(ctx context.Context, reminder *models.Reminder, now time.Time) (bool, error) {
+	// The todo was deleted once the reminder was claimed
+	if reminder.Todo == nil {
+		return false, s.reminderRepo.MarkFailed(ctx, reminder.ID, "todo was deleted", nil)
+	}
+
+	var err error
+	if channel, ok := s.channels[reminder.Channel]; ok {
+		err = channel.Deliver(ctx, reminder)
+	} else {
+		err = &reminderDeliveryError{fmt.Sprintf("channel %q is not configured", reminder.Channel)}
+	}
+
+	if err == nil {
+		return true, s.reminderRepo.MarkSent(ctx, reminder.ID, time.Now().UTC())
+	}
+
+	log.Printf("Failed to deliver reminder %d (attempt %d): %v", reminder.ID, reminder.Attempts, err)
+
+	var retryAt *time.Time
+	if reminder.Attempts < s.config.MaxAttempts {
+		at := now.Add(s.backoff(reminder.Attempts))
+		retryAt = &at
+	}
+	return false, s.reminderRepo.MarkFailed(ctx, reminder.ID, deliveryFailure(err), retryAt)
+}
+
+// deliveryFailure returns the error of a failed delivery as shown to the owner
+// of the reminder
+func deliveryFailure(err error) string {
+	var deliveryErr *reminderDeliveryError
+	if errors.As(err, &deliveryErr) {
+		return truncateError(deliveryErr, 500)
+	}
+	return "delivery failed"
+}
+
+// backoff returns the delay before retrying a reminder that failed attempts times
+func (s *ReminderScheduler) backoff(attempts int) time.Duration {
+	delay := s.config.RetryBackoff
+	for i := 1; i < attempts && delay < s.config.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.config.MaxRetryBackoff)
+}
+
+// truncateError returns the message of err, cut to at most n bytes of valid UTF-8
+func truncateError(err error, n int) string {
+	message := err.Error()
+	if len(message) > n {
+		message = strings.ToValidUTF8(message[:n], "")
+	}
+	return message
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-list-api/internal/mailer"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReminderChannel records the reminders it delivers, failing with err when set
+type fakeReminderChannel struct {
+	err       error
+	delivered []uint
+}
+
+func (c *fakeReminderChannel) Deliver(ctx context.Context, reminder *models.Reminder) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.delivered = append(c.delivered, reminder.ID)
+	return nil
+}
+
+func newTestReminderScheduler(repo *mocks.MockReminderRepository, channel ReminderChannel) *ReminderScheduler {
+	return NewReminderScheduler(repo, map[string]ReminderChannel{models.ReminderChannelInApp: channel}, ReminderSchedulerConfig{
+		PollInterval:    time.Minute,
+		BatchSize:       10,
+		Lease:           5 * time.Minute,
+		MaxAttempts:     3,
+		RetryBackoff:    time.Minute,
+		MaxRetryBackoff: 10 * time.Minute,
+	})
+}
+
+func TestReminderScheduler_DeliversDueReminders(t *testing.T) {
+	repo := new(mocks.MockReminderRepository)
+	channel := &fakeReminderChannel{}
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+	repo.On("ClaimDue", mock.Anything, now, now.Add(5*time.Minute), 10).Return([]models.Reminder{
+		{ID: 1, Channel: models.ReminderChannelInApp, Attempts: 1, Todo: &models.Todo{}},
+		{ID: 2, Channel: models.ReminderChannelInApp, Attempts: 1, Todo: &models.Todo{}},
+	}, nil)
+	repo.On("MarkSent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	delivered, err := newTestReminderScheduler(repo, channel).Fire(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	assert.Equal(t, []uint{1, 2}, channel.delivered)
+	repo.AssertNumberOfCalls(t, "MarkSent", 2)
+}
+
+func TestReminderScheduler_RetriesWithBackoff(t *testing.T) {
+	repo := new(mocks.MockReminderRepository)
+	channel := &fakeReminderChannel{err: errors.New("connection refused")}
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+	repo.On("ClaimDue", mock.Anything, now, mock.Anything, 10).Return([]models.Reminder{
+		{ID: 1, Channel: models.ReminderChannelInApp, Attempts: 1, Todo: &models.Todo{}},
+		{ID: 2, Channel: models.ReminderChannelInApp, Attempts: 2, Todo: &models.Todo{}},
+		{ID: 3, Channel: models.ReminderChannelInApp, Attempts: 3, Todo: &models.Todo{}},
+	}, nil)
+	// Errors are logged; the reminder only records that delivery failed
+	repo.On("MarkFailed", mock.Anything, uint(1), "delivery failed", mock.MatchedBy(func(retryAt *time.Time) bool {
+		return retryAt != nil && retryAt.Equal(now.Add(time.Minute))
+	})).Return(nil)
+	repo.On("MarkFailed", mock.Anything, uint(2), "delivery failed", mock.MatchedBy(func(retryAt *time.Time) bool {
+		return retryAt != nil && retryAt.Equal(now.Add(2*time.Minute))
+	})).Return(nil)
+	repo.On("MarkFailed", mock.Anything, uint(3), "delivery failed", (*time.Time)(nil)).Return(nil)
+
+	delivered, err := newTestReminderScheduler(repo, channel).Fire(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReminderScheduler_UnknownChannel(t *testing.T) {
+	repo := new(mocks.MockReminderRepository)
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+	repo.On("ClaimDue", mock.Anything, now, mock.Anything, 10).Return([]models.Reminder{
+		{ID: 1, Channel: models.ReminderChannelWebhook, Attempts: 1, Todo: &models.Todo{}},
+	}, nil)
+	repo.On("MarkFailed", mock.Anything, uint(1), `channel "webhook" is not configured`, mock.Anything).Return(nil)
+
+	_, err := newTestReminderScheduler(repo, &fakeReminderChannel{}).Fire(context.Background(), now)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestReminderScheduler_DeletedTodo(t *testing.T) {
+	repo := new(mocks.MockReminderRepository)
+	channel := &fakeReminderChannel{}
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+
+	repo.On("ClaimDue", mock.Anything, now, mock.Anything, 10).Return([]models.Reminder{
+		{ID: 1, Channel: models.ReminderChannelInApp, Attempts: 1},
+	}, nil)
+	repo.On("MarkFailed", mock.Anything, uint(1), "todo was deleted", (*time.Time)(nil)).Return(nil)
+
+	delivered, err := newTestReminderScheduler(repo, channel).Fire(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Empty(t, channel.delivered)
+	repo.AssertExpectations(t)
+}
+
+func TestReminderChannels_RefuseRemindersWithoutTodo(t *testing.T) {
+	channels := map[string]ReminderChannel{
+		"email":   NewEmailReminderChannel(mailer.NewMemoryMailer()),
+		"webhook": NewWebhookReminderChannel(http.DefaultClient, []byte("secret")),
+		"inApp":   NewInAppReminderChannel(new(mocks.MockNotificationRepository)),
+	}
+	for name, channel := range channels {
+		t.Run(name, func(t *testing.T) {
+			err := channel.Deliver(context.Background(), &models.Reminder{ID: 1, WebhookURL: "https://example.com/hook"})
+			assert.EqualError(t, err, "reminder has no todo")
+		})
+	}
+}
+
+func TestReminderScheduler_StoppedReleasesClaims(t *testing.T) {
+	repo := new(mocks.MockReminderRepository)
+	channel := &fakeReminderChannel{}
+	now := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	repo.On("ClaimDue", ctx, now, mock.Anything, 10).Return([]models.Reminder{
+		{ID: 1, Channel: models.ReminderChannelInApp, Attempts: 1, Todo: &models.Todo{}},
+		{ID: 2, Channel: models.ReminderChannelInApp, Attempts: 1, Todo: &models.Todo{}},
+	}, nil)
+	repo.On("Release", mock.Anything, []uint{1, 2}).Return(nil)
+
+	delivered, err := newTestReminderScheduler(repo, channel).Fire(ctx, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Empty(t, channel.delivered)
+	repo.AssertExpectations(t)
+}
+
+func TestReminderScheduler_StartStop(t *testing.T) {
+	repo := new(mocks.MockReminderRepository)
+	repo.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything, 10).Return([]models.Reminder{}, nil)
+	scheduler := newTestReminderScheduler(repo, &fakeReminderChannel{})
+
+	scheduler.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, scheduler.Stop(ctx))
+}
+
+func TestEmailReminderChannel_UsesOwnerTimezone(t *testing.T) {
+	mail := mailer.NewMemoryMailer()
+	dueDate := time.Date(2026, time.July, 1, 16, 0, 0, 0, time.UTC)
+	reminder := &models.Reminder{ID: 1, Todo: &models.Todo{
+		Title:   "Send invoices",
+		DueDate: &dueDate,
+		User:    &models.User{Email: "user@example.com", Preferences: models.UserPreferences{Timezone: "Europe/Paris"}},
+	}}
+
+	require.NoError(t, NewEmailReminderChannel(mail).Deliver(context.Background(), reminder))
+
+	messages := mail.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "user@example.com", messages[0].To)
+	assert.Equal(t, "Reminder: Send invoices", messages[0].Subject)
+	assert.True(t, strings.Contains(messages[0].Body, "18:00 CEST"), messages[0].Body)
+}
+
+func TestWebhookReminderChannel_RefusesNonPublicAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	channel := NewWebhookReminderChannel(NewWebhookClient(time.Second), nil)
+	reminder := &models.Reminder{ID: 1, WebhookURL: server.URL, Todo: &models.Todo{Title: "Send invoices"}}
+
+	err := channel.Deliver(context.Background(), reminder)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not public")
+	assert.Equal(t, "delivery failed", deliveryFailure(err), "network details are not shown to users")
+
+	for _, address := range []string{"10.0.0.1:443", "169.254.169.254:80", "[::1]:443", "100.64.0.1:443", "[::ffff:127.0.0.1]:443"} {
+		assert.Error(t, dialPublicOnly("tcp", address, nil), address)
+	}
+	assert.NoError(t, dialPublicOnly("tcp", "93.184.215.14:443", nil))
+}
+
+func TestWebhookReminderChannel_DoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://169.254.169.254/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	// The test server is on loopback, so only the redirect policy is used
+	client := NewWebhookClient(time.Second)
+	client.Transport = http.DefaultTransport
+	reminder := &models.Reminder{ID: 1, WebhookURL: server.URL, Todo: &models.Todo{Title: "Send invoices"}}
+
+	err := NewWebhookReminderChannel(client, nil).Deliver(context.Background(), reminder)
+	assert.EqualError(t, err, "webhook responded with status 302")
+	assert.Equal(t, "webhook responded with status 302", deliveryFailure(err))
+}
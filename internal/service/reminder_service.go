@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// ReminderService defines the interface for reminder business logic
+// operations. All operations act on a todo owned by userID.
+type ReminderService interface {
+	GetReminders(ctx context.Context, userID uint, todoID uint) ([]models.Reminder, error)
+	CreateReminder(ctx context.Context, userID uint, todoID uint, req *models.CreateReminderRequest) (*models.Reminder, error)
+	DeleteReminder(ctx context.Context, userID uint, todoID uint, id uint) error
+}
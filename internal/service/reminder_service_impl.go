@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/utils"
+)
+
+type reminderServiceImpl struct {
+	todoRepo     repository.TodoRepository
+	reminderRepo repository.ReminderRepository
+}
+
+// NewReminderService creates a new instance of ReminderService
+func NewReminderService(todoRepo repository.TodoRepository, reminderRepo repository.ReminderRepository) ReminderService {
+	return &reminderServiceImpl{
+		todoRepo:     todoRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+func (s *reminderServiceImpl) GetReminders(ctx context.Context, userID uint, todoID uint) ([]models.Reminder, error) {
+	if err := s.checkTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	return s.reminderRepo.ListByTodoID(ctx, todoID)
+}
+
+func (s *reminderServiceImpl) CreateReminder(ctx context.Context, userID uint, todoID uint, req *models.CreateReminderRequest) (*models.Reminder, error) {
+	if err := s.checkTodo(ctx, userID, todoID); err != nil {
+		return nil, err
+	}
+
+	remindAt := utils.ParseStringToDate(req.RemindAt)
+	if (remindAt == nil) == (req.OffsetMinutes == nil) {
+		return nil, errors.New("either remindAt or offsetMinutes is required")
+	}
+	if remindAt != nil {
+		*remindAt = remindAt.UTC()
+	}
+
+	reminders, err := s.reminderRepo.ListByTodoID(ctx, todoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(reminders) >= models.MaxRemindersPerTodo {
+		return nil, errors.New("too many reminders")
+	}
+
+	now := time.Now().UTC()
+	reminder := &models.Reminder{
+		TodoID:        todoID,
+		RemindAt:      remindAt,
+		OffsetMinutes: req.OffsetMinutes,
+		Channel:       req.Channel,
+		Status:        models.ReminderPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if req.Channel == models.ReminderChannelWebhook {
+		reminder.WebhookURL = req.WebhookURL
+	}
+
+	return s.reminderRepo.Create(ctx, reminder)
+}
+
+func (s *reminderServiceImpl) DeleteReminder(ctx context.Context, userID uint, todoID uint, id uint) error {
+	if err := s.checkTodo(ctx, userID, todoID); err != nil {
+		return err
+	}
+
+	return s.reminderRepo.Delete(ctx, todoID, id)
+}
+
+// checkTodo checks that the todo exists and belongs to the user
+func (s *reminderServiceImpl) checkTodo(ctx context.Context, userID uint, todoID uint) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if todoID == 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	todo, err := s.todoRepo.GetByID(ctx, userID, todoID)
+	if err != nil {
+		return err
+	}
+	if todo == nil {
+		return errors.New("todo not found")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReminderServiceTestSuite struct {
+	suite.Suite
+	mockTodoRepo     *mocks.MockTodoRepository
+	mockReminderRepo *mocks.MockReminderRepository
+	service          ReminderService
+	ctx              context.Context
+	userID           uint
+	todoID           uint
+}
+
+func (suite *ReminderServiceTestSuite) SetupTest() {
+	suite.mockTodoRepo = new(mocks.MockTodoRepository)
+	suite.mockReminderRepo = new(mocks.MockReminderRepository)
+	suite.service = NewReminderService(suite.mockTodoRepo, suite.mockReminderRepo)
+	suite.ctx = context.Background()
+	suite.userID = 1
+	suite.todoID = 10
+}
+
+// expectTodo makes the todo visible to the user
+func (suite *ReminderServiceTestSuite) expectTodo() {
+	suite.mockTodoRepo.On("GetByID", suite.ctx, suite.userID, suite.todoID).
+		Return(&models.Todo{ID: suite.todoID, UserID: uint64(suite.userID)}, nil)
+}
+
+// TestCreateReminder_Absolute tests that an absolute reminder is stored in UTC
+func (suite *ReminderServiceTestSuite) TestCreateReminder_Absolute() {
+	// Arrange
+	suite.expectTodo()
+	remindAt := "2026-05-04T09:30:00+02:00"
+	suite.mockReminderRepo.On("ListByTodoID", suite.ctx, suite.todoID).Return([]models.Reminder{}, nil)
+	suite.mockReminderRepo.On("Create", suite.ctx, mock.MatchedBy(func(reminder *models.Reminder) bool {
+		return reminder.TodoID == suite.todoID &&
+			reminder.RemindAt.Equal(time.Date(2026, time.May, 4, 7, 30, 0, 0, time.UTC)) &&
+			reminder.RemindAt.Location() == time.UTC &&
+			reminder.OffsetMinutes == nil &&
+			reminder.Status == models.ReminderPending &&
+			reminder.WebhookURL == ""
+	})).Return(&models.Reminder{ID: 1}, nil)
+
+	// Act
+	_, err := suite.service.CreateReminder(suite.ctx, suite.userID, suite.todoID, &models.CreateReminderRequest{
+		RemindAt:   &remindAt,
+		Channel:    models.ReminderChannelEmail,
+		WebhookURL: "https://example.com/hook",
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockReminderRepo.AssertExpectations(suite.T())
+}
+
+// TestCreateReminder_NeedsOneTime tests that a reminder has either a time or an offset
+func (suite *ReminderServiceTestSuite) TestCreateReminder_NeedsOneTime() {
+	// Arrange
+	suite.expectTodo()
+	remindAt := "2026-05-04T09:30:00Z"
+	offset := 30
+
+	// Act
+	_, errNeither := suite.service.CreateReminder(suite.ctx, suite.userID, suite.todoID, &models.CreateReminderRequest{Channel: models.ReminderChannelEmail})
+	_, errBoth := suite.service.CreateReminder(suite.ctx, suite.userID, suite.todoID, &models.CreateReminderRequest{
+		RemindAt: &remindAt, OffsetMinutes: &offset, Channel: models.ReminderChannelEmail,
+	})
+
+	// Assert
+	assert.EqualError(suite.T(), errNeither, "either remindAt or offsetMinutes is required")
+	assert.EqualError(suite.T(), errBoth, "either remindAt or offsetMinutes is required")
+	suite.mockReminderRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreateReminder_TooMany tests that a todo cannot have more reminders than the limit
+func (suite *ReminderServiceTestSuite) TestCreateReminder_TooMany() {
+	// Arrange
+	suite.expectTodo()
+	offset := 60
+	suite.mockReminderRepo.On("ListByTodoID", suite.ctx, suite.todoID).
+		Return(make([]models.Reminder, models.MaxRemindersPerTodo), nil)
+
+	// Act
+	_, err := suite.service.CreateReminder(suite.ctx, suite.userID, suite.todoID, &models.CreateReminderRequest{
+		OffsetMinutes: &offset, Channel: models.ReminderChannelInApp,
+	})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "too many reminders")
+}
+
+// TestDeleteReminder_TodoNotFound tests that reminders of other users' todos are not reachable
+func (suite *ReminderServiceTestSuite) TestDeleteReminder_TodoNotFound() {
+	// Arrange
+	suite.mockTodoRepo.On("GetByID", suite.ctx, suite.userID, suite.todoID).Return(nil, nil)
+
+	// Act
+	err := suite.service.DeleteReminder(suite.ctx, suite.userID, suite.todoID, 1)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "todo not found")
+	suite.mockReminderRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReminderServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReminderServiceTestSuite))
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+	"todo-list-api/internal/repository"
+	"todo-list-api/internal/utils"
+)
+
+// Cache lifetimes of the session denylist. Revocations are permanent, so a
+// revoked session is remembered for as long as any of its access tokens can
+// still be valid. Active sessions are rechecked often so that revocations made
+// by other instances take effect quickly.
+const (
+	revokedSessionCacheTTL = utils.AccessTokenTTL
+	activeSessionCacheTTL  = 30 * time.Second
+)
+
+type sessionCacheEntry struct {
+	revoked   bool
+	expiresAt time.Time
+}
+
+// SessionDenylist answers whether the session of an access token was revoked.
+// Lookups hit the database at most once per cache lifetime; revocations made
+// through this instance are applied to the cache immediately.
+type SessionDenylist struct {
+	sessionRepo repository.SessionRepository
+	mu          sync.Mutex
+	entries     map[string]sessionCacheEntry
+	lastSweep   time.Time
+}
+
+// NewSessionDenylist creates a new SessionDenylist backed by sessionRepo
+func NewSessionDenylist(sessionRepo repository.SessionRepository) *SessionDenylist {
+	return &SessionDenylist{
+		sessionRepo: sessionRepo,
+		entries:     make(map[string]sessionCacheEntry),
+		lastSweep:   time.Now(),
+	}
+}
+
+// IsSessionRevoked reports whether the session has been revoked
+func (d *SessionDenylist) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	now := time.Now()
+
+	d.mu.Lock()
+	entry, ok := d.entries[sessionID]
+	d.mu.Unlock()
+	if ok && now.Before(entry.expiresAt) {
+		return entry.revoked, nil
+	}
+
+	revoked, err := d.sessionRepo.IsRevoked(ctx, sessionID)
+	if err != nil {
+		return false, err
+	}
+
+	ttl := activeSessionCacheTTL
+	if revoked {
+		ttl = revokedSessionCacheTTL
+	}
+	d.set(sessionID, revoked, now.Add(ttl))
+
+	return revoked, nil
+}
+
+// Revoke marks a session as revoked in the local cache
+func (d *SessionDenylist) Revoke(sessionID string) {
+	d.set(sessionID, true, time.Now().Add(revokedSessionCacheTTL))
+}
+
+func (d *SessionDenylist) set(sessionID string, revoked bool, expiresAt time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.entries[sessionID] = sessionCacheEntry{revoked: revoked, expiresAt: expiresAt}
+
+	// Drop expired entries from time to time so the cache cannot grow unbounded
+	now := time.Now()
+	if now.Sub(d.lastSweep) > activeSessionCacheTTL {
+		for id, entry := range d.entries {
+			if now.After(entry.expiresAt) {
+				delete(d.entries, id)
+			}
+		}
+		d.lastSweep = now
+	}
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// TagService defines the interface for tag business logic operations.
+// All operations act on behalf of userID and only ever touch that user's tags.
+type TagService interface {
+	ListTags(ctx context.Context, userID uint) ([]models.Tag, error)
+	CreateTag(ctx context.Context, userID uint, req *models.CreateTagRequest) (*models.Tag, error)
+	// UpdateTag renames or recolors a tag. A tag cannot be renamed to the name
+	// of another tag; merge them instead.
+	UpdateTag(ctx context.Context, userID uint, id uint, req *models.UpdateTagRequest) (*models.Tag, error)
+	// DeleteTag deletes the tag and removes it from its todos
+	DeleteTag(ctx context.Context, userID uint, id uint) error
+	// MergeTag moves the todos of tag id to the target tag, deletes tag id and
+	// returns the target
+	MergeTag(ctx context.Context, userID uint, id uint, req *models.MergeTagRequest) (*models.Tag, error)
+}
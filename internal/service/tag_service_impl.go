@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+type tagServiceImpl struct {
+	tagRepo repository.TagRepository
+}
+
+// NewTagService creates a new instance of TagService
+func NewTagService(tagRepo repository.TagRepository) TagService {
+	return &tagServiceImpl{
+		tagRepo: tagRepo,
+	}
+}
+
+func (s *tagServiceImpl) ListTags(ctx context.Context, userID uint) ([]models.Tag, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	return s.tagRepo.List(ctx, userID)
+}
+
+func (s *tagServiceImpl) CreateTag(ctx context.Context, userID uint, req *models.CreateTagRequest) (*models.Tag, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("tag name is required")
+	}
+
+	if err := s.checkNameAvailable(ctx, userID, name, 0); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return s.tagRepo.Create(ctx, &models.Tag{
+		UserID:    uint64(userID),
+		Name:      name,
+		Color:     req.Color,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (s *tagServiceImpl) UpdateTag(ctx context.Context, userID uint, id uint, req *models.UpdateTagRequest) (*models.Tag, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	tag, err := s.getTag(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("tag name is required")
+		}
+		if err := s.checkNameAvailable(ctx, userID, name, tag.ID); err != nil {
+			return nil, err
+		}
+		tag.Name = name
+	}
+	if req.Color != nil {
+		tag.Color = *req.Color
+	}
+	tag.UpdatedAt = time.Now().UTC()
+
+	return s.tagRepo.Update(ctx, tag)
+}
+
+func (s *tagServiceImpl) DeleteTag(ctx context.Context, userID uint, id uint) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return errors.New("tag not found")
+	}
+
+	return s.tagRepo.Delete(ctx, userID, id)
+}
+
+func (s *tagServiceImpl) MergeTag(ctx context.Context, userID uint, id uint, req *models.MergeTagRequest) (*models.Tag, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == req.TargetID {
+		return nil, errors.New("cannot merge a tag into itself")
+	}
+
+	if err := s.tagRepo.Merge(ctx, userID, id, req.TargetID); err != nil {
+		return nil, err
+	}
+
+	return s.getTag(ctx, userID, req.TargetID)
+}
+
+// getTag returns a tag of the user or a "tag not found" error
+func (s *tagServiceImpl) getTag(ctx context.Context, userID uint, id uint) (*models.Tag, error) {
+	if id == 0 {
+		return nil, errors.New("tag not found")
+	}
+
+	tag, err := s.tagRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, errors.New("tag not found")
+	}
+	return tag, nil
+}
+
+// checkNameAvailable fails if another tag than exceptID has the name, in any case
+func (s *tagServiceImpl) checkNameAvailable(ctx context.Context, userID uint, name string, exceptID uint) error {
+	existing, err := s.tagRepo.GetByName(ctx, userID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != exceptID {
+		return errors.New("tag already exists")
+	}
+	return nil
+}
+
+// normalizeTagNames trims the names and drops duplicates, ignoring case.
+// The first spelling of a name is kept.
+func normalizeTagNames(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, errors.New("tag name is required")
+		}
+		key := strings.ToLower(name)
+		if !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, nil
+}
+
+// uniqueTagIDs drops duplicate IDs, keeping their order
+func uniqueTagIDs(ids []uint) []uint {
+	unique := make([]uint, 0, len(ids))
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TagServiceTestSuite struct {
+	suite.Suite
+	mockTagRepo *mocks.MockTagRepository
+	service     TagService
+	ctx         context.Context
+	userID      uint
+}
+
+func (suite *TagServiceTestSuite) SetupTest() {
+	suite.mockTagRepo = new(mocks.MockTagRepository)
+	suite.service = NewTagService(suite.mockTagRepo)
+	suite.ctx = context.Background()
+	suite.userID = 1
+}
+
+// TestCreateTag_Success tests that a tag is created with a trimmed name
+func (suite *TagServiceTestSuite) TestCreateTag_Success() {
+	// Arrange
+	suite.mockTagRepo.On("GetByName", suite.ctx, suite.userID, "Work").Return(nil, nil)
+	suite.mockTagRepo.On("Create", suite.ctx, mock.MatchedBy(func(tag *models.Tag) bool {
+		return tag.UserID == uint64(suite.userID) && tag.Name == "Work" && tag.Color == "#ff0000"
+	})).Return(&models.Tag{ID: 1, Name: "Work", Color: "#ff0000"}, nil)
+
+	// Act
+	tag, err := suite.service.CreateTag(suite.ctx, suite.userID, &models.CreateTagRequest{Name: " Work ", Color: "#ff0000"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint(1), tag.ID)
+	suite.mockTagRepo.AssertExpectations(suite.T())
+}
+
+// TestCreateTag_Duplicate tests that tag names are unique per user, ignoring case
+func (suite *TagServiceTestSuite) TestCreateTag_Duplicate() {
+	// Arrange
+	suite.mockTagRepo.On("GetByName", suite.ctx, suite.userID, "WORK").Return(&models.Tag{ID: 1, Name: "work"}, nil)
+
+	// Act
+	tag, err := suite.service.CreateTag(suite.ctx, suite.userID, &models.CreateTagRequest{Name: "WORK"})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "tag already exists")
+	assert.Nil(suite.T(), tag)
+	suite.mockTagRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestUpdateTag_ChangeCase tests that a tag can be renamed to another spelling of its own name
+func (suite *TagServiceTestSuite) TestUpdateTag_ChangeCase() {
+	// Arrange
+	name := "Work"
+	suite.mockTagRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(&models.Tag{ID: 1, Name: "work"}, nil)
+	suite.mockTagRepo.On("GetByName", suite.ctx, suite.userID, "Work").Return(&models.Tag{ID: 1, Name: "work"}, nil)
+	suite.mockTagRepo.On("Update", suite.ctx, mock.MatchedBy(func(tag *models.Tag) bool {
+		return tag.ID == 1 && tag.Name == "Work"
+	})).Return(&models.Tag{ID: 1, Name: "Work"}, nil)
+
+	// Act
+	tag, err := suite.service.UpdateTag(suite.ctx, suite.userID, 1, &models.UpdateTagRequest{Name: &name})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Work", tag.Name)
+	suite.mockTagRepo.AssertExpectations(suite.T())
+}
+
+// TestUpdateTag_NotFound tests that tags of other users cannot be updated
+func (suite *TagServiceTestSuite) TestUpdateTag_NotFound() {
+	// Arrange
+	suite.mockTagRepo.On("GetByID", suite.ctx, suite.userID, uint(2)).Return(nil, nil)
+
+	// Act
+	tag, err := suite.service.UpdateTag(suite.ctx, suite.userID, 2, &models.UpdateTagRequest{})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "tag not found")
+	assert.Nil(suite.T(), tag)
+}
+
+// TestMergeTag_Success tests that merging returns the target tag
+func (suite *TagServiceTestSuite) TestMergeTag_Success() {
+	// Arrange
+	suite.mockTagRepo.On("Merge", suite.ctx, suite.userID, uint(1), uint(2)).Return(nil)
+	suite.mockTagRepo.On("GetByID", suite.ctx, suite.userID, uint(2)).Return(&models.Tag{ID: 2, Name: "work"}, nil)
+
+	// Act
+	tag, err := suite.service.MergeTag(suite.ctx, suite.userID, 1, &models.MergeTagRequest{TargetID: 2})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint(2), tag.ID)
+	suite.mockTagRepo.AssertExpectations(suite.T())
+}
+
+// TestMergeTag_Itself tests that a tag cannot be merged into itself
+func (suite *TagServiceTestSuite) TestMergeTag_Itself() {
+	// Act
+	tag, err := suite.service.MergeTag(suite.ctx, suite.userID, 1, &models.MergeTagRequest{TargetID: 1})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "cannot merge a tag into itself")
+	assert.Nil(suite.T(), tag)
+	suite.mockTagRepo.AssertNotCalled(suite.T(), "Merge", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestTagServiceSuite runs the test suite
+func TestTagServiceSuite(t *testing.T) {
+	suite.Run(t, new(TagServiceTestSuite))
+}
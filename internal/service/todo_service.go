@@ -5,12 +5,40 @@ import (
 	"todo-list-api/internal/models"
 )
 
-// TodoService defines the interface for todo business logic operations
+// TodoService defines the interface for todo business logic operations.
+// All operations act on behalf of userID and only ever touch that user's todos.
 type TodoService interface {
-	CreateTodo(ctx context.Context, req *models.CreateTodoRequest) (*models.Todo, error)
-	GetTodos(ctx context.Context) ([]models.Todo, error)
-	GetTodoByID(ctx context.Context, id uint) (*models.Todo, error)
-	UpdateTodo(ctx context.Context, id uint, req *models.UpdateTodoRequest) (*models.Todo, error)
-	DeleteTodo(ctx context.Context, id uint) error
-	GetTodosByUserID(ctx context.Context, userID uint) ([]models.Todo, error)
+	CreateTodo(ctx context.Context, userID uint, req *models.CreateTodoRequest) (*models.Todo, error)
+	GetTodos(ctx context.Context, userID uint, req *models.ListTodosRequest) (*models.TodoPage, error)
+	SearchTodos(ctx context.Context, userID uint, req *models.SearchTodosRequest) (*models.TodoSearchPage, error)
+	GetTodoByID(ctx context.Context, userID uint, id uint) (*models.Todo, error)
+	// UpdateTodo replaces the todo. When completeSubtasks is set and the todo
+	// ends up completed, all of its subtasks are completed too, and recurring
+	// ones create their next occurrence.
+	UpdateTodo(ctx context.Context, userID uint, id uint, req *models.UpdateTodoRequest, completeSubtasks bool) (*models.Todo, error)
+	// PatchTodo applies a patch document of the given models.PatchType* format to
+	// the todo, validates the result and stores it. completeSubtasks is handled
+	// as in UpdateTodo.
+	PatchTodo(ctx context.Context, userID uint, id uint, patchType string, patch []byte, completeSubtasks bool) (*models.Todo, error)
+	// DeleteTodo moves the todo to the trash, from where it can be restored.
+	// subtasks is a models.Subtasks* value; empty means models.SubtasksDelete.
+	DeleteTodo(ctx context.Context, userID uint, id uint, subtasks string) error
+	// DeleteTodoPermanently removes the todo for good, including from the trash
+	DeleteTodoPermanently(ctx context.Context, userID uint, id uint, subtasks string) error
+	GetTrash(ctx context.Context, userID uint, req *models.ListTrashRequest) (*models.TrashPage, error)
+	RestoreTodo(ctx context.Context, userID uint, id uint) (*models.Todo, error)
+	GetSubtasks(ctx context.Context, userID uint, id uint) ([]models.Todo, error)
+	CreateSubtask(ctx context.Context, userID uint, parentID uint, req *models.CreateTodoRequest) (*models.Todo, error)
+	// AttachSubtask moves an existing todo under parentID
+	AttachSubtask(ctx context.Context, userID uint, parentID uint, subtaskID uint) (*models.Todo, error)
+	// DetachSubtask makes a subtask of parentID a top-level todo
+	DetachSubtask(ctx context.Context, userID uint, parentID uint, subtaskID uint) (*models.Todo, error)
+	// SnoozeTodo hides the todo from the todo listings until the time req
+	// resolves to, in the user's time zone
+	SnoozeTodo(ctx context.Context, userID uint, id uint, req *models.SnoozeTodoRequest) (*models.Todo, error)
+	// UnsnoozeTodo ends the snooze of the todo
+	UnsnoozeTodo(ctx context.Context, userID uint, id uint) (*models.Todo, error)
+	// TransitionTodo moves the todo to another status of its workflow, if the
+	// workflow allows it. Completing it can complete its subtasks too, as in UpdateTodo.
+	TransitionTodo(ctx context.Context, userID uint, id uint, req *models.TransitionTodoRequest, completeSubtasks bool) (*models.Todo, error)
 }
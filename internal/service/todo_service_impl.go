@@ -1,62 +1,208 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 	"todo-list-api/internal/models"
 	"todo-list-api/internal/repository"
 	"todo-list-api/internal/utils"
+
+	"github.com/go-playground/validator/v10"
 )
 
 type todoServiceImpl struct {
-	todoRepo repository.TodoRepository
+	todoRepo     repository.TodoRepository
+	tagRepo      repository.TagRepository
+	projectRepo  repository.ProjectRepository
+	authRepo     repository.AuthRepository
+	workflowRepo repository.WorkflowRepository
+	validator    *validator.Validate
 }
 
 // NewTodoService creates a new instance of TodoService
-func NewTodoService(todoRepo repository.TodoRepository) TodoService {
+func NewTodoService(todoRepo repository.TodoRepository, tagRepo repository.TagRepository, projectRepo repository.ProjectRepository, authRepo repository.AuthRepository, workflowRepo repository.WorkflowRepository) TodoService {
 	return &todoServiceImpl{
-		todoRepo: todoRepo,
+		todoRepo:     todoRepo,
+		tagRepo:      tagRepo,
+		projectRepo:  projectRepo,
+		authRepo:     authRepo,
+		workflowRepo: workflowRepo,
+		validator:    validator.New(),
 	}
 }
 
-func (s *todoServiceImpl) CreateTodo(ctx context.Context, req *models.CreateTodoRequest) (*models.Todo, error) {
-	// Create todo entity
-	todo := &models.Todo{
-		Title:       strings.TrimSpace(req.Title),
-		Description: strings.TrimSpace(req.Description),
-		Priority:    strings.TrimSpace(req.Priority),
-		DueDate:     utils.ParseStringToDate(req.DueDate),
-		Category:    strings.TrimSpace(req.Category),
-		Completed:   false,
-		CreatedAt:   time.Now().UTC(),
-		UpdatedAt:   time.Now().UTC(),
+func (s *todoServiceImpl) CreateTodo(ctx context.Context, userID uint, req *models.CreateTodoRequest) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	todo, err := s.newTodo(ctx, userID, req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Delegate to repository
 	return s.todoRepo.Create(ctx, todo)
 }
 
-func (s *todoServiceImpl) GetTodos(ctx context.Context) ([]models.Todo, error) {
-	return s.todoRepo.GetAll(ctx)
+func (s *todoServiceImpl) GetTodos(ctx context.Context, userID uint, req *models.ListTodosRequest) (*models.TodoPage, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if req == nil {
+		req = &models.ListTodosRequest{}
+	}
+
+	query := models.TodoQuery{
+		Filter: models.TodoFilter{
+			Completed: req.Completed,
+			Priority:  strings.TrimSpace(req.Priority),
+			Status:    strings.TrimSpace(req.Status),
+			ProjectID: req.ProjectID,
+			TagMatch:  req.TagMatch,
+			DueBefore: utils.ParseStringToDate(req.DueBefore),
+			DueAfter:  utils.ParseStringToDate(req.DueAfter),
+			Overdue:   req.Overdue,
+			// Deferred todos are left out unless asked for
+			IncludeDeferred: req.IncludeDeferred,
+		},
+		Sort:  req.Sort,
+		Order: req.Order,
+		Limit: req.Limit,
+	}
+
+	// Apply defaults: newest first, one default-sized page
+	if query.Sort == "" {
+		query.Sort = models.TodoSortCreatedAt
+	}
+	if query.Order == "" {
+		query.Order = models.SortDesc
+	}
+	if query.Limit <= 0 {
+		query.Limit = models.DefaultTodoPageSize
+	}
+	if query.Limit > models.MaxTodoPageSize {
+		query.Limit = models.MaxTodoPageSize
+	}
+
+	if req.Cursor != "" {
+		var cursor models.TodoCursor
+		if err := utils.DecodeCursor(req.Cursor, &cursor); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		// A cursor is only meaningful for the ordering it was produced with
+		if cursor.Sort != query.Sort || cursor.Order != query.Order {
+			return nil, errors.New("invalid cursor")
+		}
+		query.Cursor = &cursor
+	}
+
+	tagIDs, ok, err := s.resolveTagFilter(ctx, userID, req.Tags, req.TagIDs, req.TagMatch)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &models.TodoPage{Items: []models.Todo{}}, nil
+	}
+	query.Filter.TagIDs = tagIDs
+
+	return s.todoRepo.List(ctx, userID, query)
+}
+
+func (s *todoServiceImpl) SearchTodos(ctx context.Context, userID uint, req *models.SearchTodosRequest) (*models.TodoSearchPage, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if req == nil || strings.TrimSpace(req.Query) == "" {
+		return nil, errors.New("search query is required")
+	}
+
+	query := models.TodoSearchQuery{
+		Text: strings.TrimSpace(req.Query),
+		Filter: models.TodoFilter{
+			Completed: req.Completed,
+			Priority:  strings.TrimSpace(req.Priority),
+			ProjectID: req.ProjectID,
+			TagMatch:  req.TagMatch,
+			// Deferred todos can be found by searching
+			IncludeDeferred: true,
+		},
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = models.DefaultTodoPageSize
+	}
+	if query.Limit > models.MaxTodoPageSize {
+		query.Limit = models.MaxTodoPageSize
+	}
+	if query.Offset < 0 {
+		query.Offset = 0
+	}
+
+	tagIDs, ok, err := s.resolveTagFilter(ctx, userID, req.Tags, req.TagIDs, req.TagMatch)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return &models.TodoSearchPage{Items: []models.TodoSearchResult{}}, nil
+	}
+	query.Filter.TagIDs = tagIDs
+
+	return s.todoRepo.Search(ctx, userID, query)
+}
+
+func (s *todoServiceImpl) GetTodoByID(ctx context.Context, userID uint, id uint) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	return s.todoRepo.GetByID(ctx, userID, id)
 }
 
-func (s *todoServiceImpl) GetTodoByID(ctx context.Context, id uint) (*models.Todo, error) {
+func (s *todoServiceImpl) UpdateTodo(ctx context.Context, userID uint, id uint, req *models.UpdateTodoRequest, completeSubtasks bool) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
 	if id == 0 {
 		return nil, errors.New("invalid todo ID")
 	}
 
-	return s.todoRepo.GetByID(ctx, id)
+	// Check if todo exists and belongs to the user
+	existingTodo, err := s.todoRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if existingTodo == nil {
+		return nil, errors.New("todo not found")
+	}
+
+	return s.replaceTodo(ctx, userID, existingTodo, req, nil, completeSubtasks)
 }
 
-func (s *todoServiceImpl) UpdateTodo(ctx context.Context, id uint, req *models.UpdateTodoRequest) (*models.Todo, error) {
+func (s *todoServiceImpl) PatchTodo(ctx context.Context, userID uint, id uint, patchType string, patch []byte, completeSubtasks bool) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
 	if id == 0 {
 		return nil, errors.New("invalid todo ID")
 	}
 
-	// Check if todo exists
-	existingTodo, err := s.todoRepo.GetByID(ctx, id)
+	existingTodo, err := s.todoRepo.GetByID(ctx, userID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -64,30 +210,58 @@ func (s *todoServiceImpl) UpdateTodo(ctx context.Context, id uint, req *models.U
 		return nil, errors.New("todo not found")
 	}
 
-	// Create updated todo entity
-	updatedTodo := &models.Todo{
-		ID:          id,
-		Title:       strings.TrimSpace(req.Title),
-		Description: strings.TrimSpace(req.Description),
-		Priority:    strings.TrimSpace(req.Priority),
-		DueDate:     utils.ParseStringToDate(req.DueDate),
-		Category:    strings.TrimSpace(req.Category),
-		Completed:   req.Completed,
-		UpdatedAt:   time.Now().UTC(),
-		// Preserve original creation time
-		CreatedAt: existingTodo.CreatedAt,
+	// Patches are applied to the same representation PUT accepts
+	document, err := json.Marshal(todoToUpdateRequest(existingTodo))
+	if err != nil {
+		return nil, err
+	}
+
+	var patched []byte
+	switch patchType {
+	case models.PatchTypeMerge:
+		patched, err = utils.MergePatch(document, patch)
+	case models.PatchTypeJSON:
+		patched, err = utils.ApplyJSONPatch(document, patch)
+	default:
+		return nil, errors.New("unsupported patch type")
+	}
+	if err != nil {
+		if errors.Is(err, utils.ErrPatchTestFailed) {
+			return nil, errors.New("patch test failed")
+		}
+		return nil, errors.New("invalid patch document: " + err.Error())
+	}
+
+	var req models.UpdateTodoRequest
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return nil, errors.New("invalid patch document: " + err.Error())
+	}
+
+	if err := s.validator.Struct(&req); err != nil {
+		return nil, err
 	}
 
-	return s.todoRepo.Update(ctx, id, updatedTodo)
+	return s.replaceTodo(ctx, userID, existingTodo, &req, nil, completeSubtasks)
 }
 
-func (s *todoServiceImpl) DeleteTodo(ctx context.Context, id uint) error {
+func (s *todoServiceImpl) DeleteTodo(ctx context.Context, userID uint, id uint, subtasks string) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
 	if id == 0 {
 		return errors.New("invalid todo ID")
 	}
 
-	// Check if todo exists before deleting
-	existingTodo, err := s.todoRepo.GetByID(ctx, id)
+	subtasks, err := subtasksOption(subtasks)
+	if err != nil {
+		return err
+	}
+
+	// Check if todo exists and belongs to the user before deleting
+	existingTodo, err := s.todoRepo.GetByID(ctx, userID, id)
 	if err != nil {
 		return err
 	}
@@ -95,13 +269,588 @@ func (s *todoServiceImpl) DeleteTodo(ctx context.Context, id uint) error {
 		return errors.New("todo not found")
 	}
 
-	return s.todoRepo.Delete(ctx, id)
+	return s.todoRepo.Delete(ctx, userID, id, subtasks)
+}
+
+func (s *todoServiceImpl) DeleteTodoPermanently(ctx context.Context, userID uint, id uint, subtasks string) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return errors.New("invalid todo ID")
+	}
+
+	subtasks, err := subtasksOption(subtasks)
+	if err != nil {
+		return err
+	}
+
+	return s.todoRepo.DeletePermanently(ctx, userID, id, subtasks)
+}
+
+func (s *todoServiceImpl) GetTrash(ctx context.Context, userID uint, req *models.ListTrashRequest) (*models.TrashPage, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if req == nil {
+		req = &models.ListTrashRequest{}
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = models.DefaultTodoPageSize
+	}
+	if limit > models.MaxTodoPageSize {
+		limit = models.MaxTodoPageSize
+	}
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.todoRepo.ListTrash(ctx, userID, limit, offset)
+}
+
+func (s *todoServiceImpl) RestoreTodo(ctx context.Context, userID uint, id uint) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	if err := s.todoRepo.Restore(ctx, userID, id); err != nil {
+		return nil, err
+	}
+
+	return s.getExistingTodo(ctx, userID, id)
+}
+
+func (s *todoServiceImpl) GetSubtasks(ctx context.Context, userID uint, id uint) ([]models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	parent, err := s.todoRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, errors.New("todo not found")
+	}
+
+	return s.todoRepo.ListSubtasks(ctx, userID, id)
+}
+
+func (s *todoServiceImpl) CreateSubtask(ctx context.Context, userID uint, parentID uint, req *models.CreateTodoRequest) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if parentID == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	parent, err := s.todoRepo.GetByID(ctx, userID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, errors.New("todo not found")
+	}
+
+	// Subtasks are created in the project of their parent unless told otherwise
+	if req.ProjectID == nil {
+		req.ProjectID = parent.ProjectID
+	}
+
+	todo, err := s.newTodo(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	todo.ParentID = &parent.ID
+	return s.todoRepo.Create(ctx, todo)
+}
+
+func (s *todoServiceImpl) AttachSubtask(ctx context.Context, userID uint, parentID uint, subtaskID uint) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if parentID == 0 || subtaskID == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	if err := s.todoRepo.SetParent(ctx, userID, subtaskID, &parentID); err != nil {
+		return nil, err
+	}
+
+	return s.getExistingTodo(ctx, userID, subtaskID)
 }
 
-func (s *todoServiceImpl) GetTodosByUserID(ctx context.Context, userID uint) ([]models.Todo, error) {
+func (s *todoServiceImpl) DetachSubtask(ctx context.Context, userID uint, parentID uint, subtaskID uint) (*models.Todo, error) {
 	if userID == 0 {
 		return nil, errors.New("invalid user ID")
 	}
 
-	return s.todoRepo.GetByUserID(ctx, userID)
+	if parentID == 0 || subtaskID == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	subtask, err := s.todoRepo.GetByID(ctx, userID, subtaskID)
+	if err != nil {
+		return nil, err
+	}
+	if subtask == nil || subtask.ParentID == nil || *subtask.ParentID != parentID {
+		return nil, errors.New("todo not found")
+	}
+
+	if err := s.todoRepo.SetParent(ctx, userID, subtaskID, nil); err != nil {
+		return nil, err
+	}
+
+	return s.getExistingTodo(ctx, userID, subtaskID)
+}
+
+// getExistingTodo returns a todo that is expected to exist
+func (s *todoServiceImpl) getExistingTodo(ctx context.Context, userID uint, id uint) (*models.Todo, error) {
+	todo, err := s.todoRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		return nil, errors.New("todo not found")
+	}
+	return todo, nil
+}
+
+// subtasksOption validates a models.Subtasks* value and applies its default
+func subtasksOption(subtasks string) (string, error) {
+	switch subtasks {
+	case "":
+		return models.SubtasksDelete, nil
+	case models.SubtasksDelete, models.SubtasksPromote:
+		return subtasks, nil
+	default:
+		return "", errors.New("invalid subtasks option")
+	}
+}
+
+// newTodo creates the todo entity described by req
+func (s *todoServiceImpl) newTodo(ctx context.Context, userID uint, req *models.CreateTodoRequest) (*models.Todo, error) {
+	if err := s.checkProject(ctx, userID, req.ProjectID); err != nil {
+		return nil, err
+	}
+
+	rule, err := parseRecurrence(req.Recurrence, req.RecurrenceTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.resolveTags(ctx, userID, req.Tags, req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := resolveWorkflow(ctx, s.workflowRepo, userID, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	todo := &models.Todo{
+		UserID:      uint64(userID),
+		ProjectID:   req.ProjectID,
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		Priority:    strings.TrimSpace(req.Priority),
+		DueDate:     utils.ParseStringToDate(req.DueDate),
+		StartDate:   utils.ParseStringToDate(req.StartDate),
+		Tags:        tags,
+		Completed:   false,
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+	}
+	setStatus(todo, workflow.Statuses[0], todo.CreatedAt)
+	if rule != nil {
+		setRecurrence(todo, req.Recurrence, req.RecurrenceTimezone, req.RepeatFrom)
+		todo.RecurrenceStart = todo.DueDate
+		todo.Occurrence = 1
+	}
+	return todo, nil
+}
+
+// parseRecurrence parses the recurrence rule of a todo. A todo without a rule
+// does not recur and has a nil rule.
+func parseRecurrence(rule, timezone string) (*utils.RRule, error) {
+	if strings.TrimSpace(rule) == "" {
+		return nil, nil
+	}
+
+	loc, err := time.LoadLocation(strings.TrimSpace(timezone))
+	if err != nil {
+		return nil, errors.New("invalid recurrence timezone")
+	}
+
+	parsed, err := utils.ParseRRule(rule, loc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence rule: %v", err)
+	}
+	return parsed, nil
+}
+
+// setRecurrence stores a valid recurrence rule on a todo
+func setRecurrence(todo *models.Todo, rule, timezone, repeatFrom string) {
+	todo.Recurrence = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	todo.RecurrenceTimezone = strings.TrimSpace(timezone)
+	todo.RepeatFrom = repeatFrom
+	if todo.RepeatFrom == "" {
+		todo.RepeatFrom = models.RepeatFromDue
+	}
+}
+
+// nextOccurrence returns the todo that follows a recurring todo completed at
+// completedAt, or nil when the series has ended
+func nextOccurrence(todo *models.Todo, rule *utils.RRule, completedAt time.Time) *models.Todo {
+	if rule.Count > 0 && todo.Occurrence >= rule.Count {
+		return nil
+	}
+
+	var start, due time.Time
+	var ok bool
+	if todo.RepeatFrom == models.RepeatFromDue && todo.DueDate != nil {
+		start = *todo.DueDate
+		if todo.RecurrenceStart != nil {
+			start = *todo.RecurrenceStart
+		}
+		due, ok = rule.Next(start, *todo.DueDate)
+	} else {
+		// The series restarts on the day of completion, at the time of day
+		// of the due date
+		loc := rule.Location()
+		day := completedAt.In(loc)
+		var hour, minute, second int
+		if todo.DueDate != nil {
+			hour, minute, second = todo.DueDate.In(loc).Clock()
+		}
+		start = time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, loc)
+		due, ok = rule.Next(start, start)
+	}
+	if !ok {
+		return nil
+	}
+
+	dueDate := due.UTC()
+	recurrenceStart := start.UTC()
+
+	// The start date keeps its distance to the due date
+	var startDate *time.Time
+	if todo.StartDate != nil && todo.DueDate != nil {
+		shifted := dueDate.Add(todo.StartDate.Sub(*todo.DueDate))
+		startDate = &shifted
+	}
+
+	return &models.Todo{
+		UserID:             todo.UserID,
+		ParentID:           todo.ParentID,
+		ProjectID:          todo.ProjectID,
+		Title:              todo.Title,
+		Description:        todo.Description,
+		Priority:           todo.Priority,
+		DueDate:            &dueDate,
+		StartDate:          startDate,
+		Tags:               todo.Tags,
+		Recurrence:         todo.Recurrence,
+		RecurrenceTimezone: todo.RecurrenceTimezone,
+		RepeatFrom:         todo.RepeatFrom,
+		RecurrenceStart:    &recurrenceStart,
+		Occurrence:         todo.Occurrence + 1,
+		CreatedAt:          completedAt,
+		UpdatedAt:          completedAt,
+	}
+}
+
+// checkProject checks that a project given for a todo belongs to the user.
+// A nil projectID is the Inbox.
+func (s *todoServiceImpl) checkProject(ctx context.Context, userID uint, projectID *uint) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, userID, *projectID)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return errors.New("project not found")
+	}
+	return nil
+}
+
+// resolveTags returns the tags named by names and ids. Missing names are
+// created as tags; unknown IDs are an error.
+func (s *todoServiceImpl) resolveTags(ctx context.Context, userID uint, names []string, ids []uint) ([]models.Tag, error) {
+	names, err := normalizeTagNames(names)
+	if err != nil {
+		return nil, err
+	}
+	ids = uniqueTagIDs(ids)
+
+	tags := []models.Tag{}
+	if len(ids) > 0 {
+		if tags, err = s.tagRepo.FindByIDs(ctx, userID, ids); err != nil {
+			return nil, err
+		}
+		if len(tags) != len(ids) {
+			return nil, errors.New("tag not found")
+		}
+	}
+
+	named := []models.Tag{}
+	if len(names) > 0 {
+		if named, err = s.tagRepo.EnsureNames(ctx, userID, names); err != nil {
+			return nil, err
+		}
+	}
+
+	// A tag may be given both by name and by ID
+	seen := make(map[uint]bool, len(tags)+len(named))
+	for _, tag := range tags {
+		seen[tag.ID] = true
+	}
+	for _, tag := range named {
+		if !seen[tag.ID] {
+			seen[tag.ID] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	if len(tags) > models.MaxTagsPerTodo {
+		return nil, errors.New("too many tags")
+	}
+	return tags, nil
+}
+
+// resolveTagFilter turns the tags of a list filter into tag IDs. ok is false
+// when no todo can match because the user has none of the tags, or, when
+// every tag must match, lacks one of them.
+func (s *todoServiceImpl) resolveTagFilter(ctx context.Context, userID uint, names []string, ids []uint, match string) (tagIDs []uint, ok bool, err error) {
+	names, err = normalizeTagNames(names)
+	if err != nil {
+		return nil, false, err
+	}
+	ids = uniqueTagIDs(ids)
+	if len(names) == 0 && len(ids) == 0 {
+		return nil, true, nil
+	}
+
+	byID, err := s.tagRepo.FindByIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, false, err
+	}
+	byName, err := s.tagRepo.FindByNames(ctx, userID, names)
+	if err != nil {
+		return nil, false, err
+	}
+
+	found := make([]uint, 0, len(byID)+len(byName))
+	for _, tag := range append(byID, byName...) {
+		found = append(found, tag.ID)
+	}
+	tagIDs = uniqueTagIDs(found)
+
+	missing := len(byID) < len(ids) || len(byName) < len(names)
+	if len(tagIDs) == 0 || (missing && match == models.TagMatchAll) {
+		return nil, false, nil
+	}
+	return tagIDs, true, nil
+}
+
+// replaceTodo overwrites all editable fields of an existing todo with req and
+// moves it to the transition status when one is given
+func (s *todoServiceImpl) replaceTodo(ctx context.Context, userID uint, existingTodo *models.Todo, req *models.UpdateTodoRequest, transition *models.WorkflowStatus, completeSubtasks bool) (*models.Todo, error) {
+	if !sameProject(existingTodo.ProjectID, req.ProjectID) {
+		if err := s.checkProject(ctx, userID, req.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	rule, err := parseRecurrence(req.Recurrence, req.RecurrenceTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.resolveTags(ctx, userID, req.Tags, req.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := resolveWorkflow(ctx, s.workflowRepo, userID, req.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	priority := strings.TrimSpace(req.Priority)
+	if priority == "" {
+		priority = "low"
+	}
+
+	updatedTodo := &models.Todo{
+		ID:     existingTodo.ID,
+		UserID: existingTodo.UserID,
+		// Not editable, but carried over to the next occurrence
+		ParentID:    existingTodo.ParentID,
+		ProjectID:   req.ProjectID,
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		Priority:    priority,
+		DueDate:     utils.ParseStringToDate(req.DueDate),
+		StartDate:   utils.ParseStringToDate(req.StartDate),
+		Tags:        tags,
+		Completed:   existingTodo.Completed,
+		UpdatedAt:   time.Now().UTC(),
+		// Preserve original creation time
+		CreatedAt:  existingTodo.CreatedAt,
+		Occurrence: existingTodo.Occurrence,
+		// The status only changes by a transition or a change of completed
+		Status:      existingTodo.Status,
+		StartedAt:   existingTodo.StartedAt,
+		CompletedAt: existingTodo.CompletedAt,
+		CancelledAt: existingTodo.CancelledAt,
+	}
+
+	// Clients setting completed move the todo to the first done status or back
+	// to the first status of its workflow, whatever its transitions
+	switch {
+	case transition != nil:
+		setStatus(updatedTodo, *transition, updatedTodo.UpdatedAt)
+	case req.Completed && !existingTodo.Completed:
+		setStatus(updatedTodo, workflow.FirstStatus(models.StatusCategoryDone), updatedTodo.UpdatedAt)
+	case !req.Completed && existingTodo.Completed:
+		setStatus(updatedTodo, workflow.Statuses[0], updatedTodo.UpdatedAt)
+	}
+
+	if rule != nil {
+		setRecurrence(updatedTodo, req.Recurrence, req.RecurrenceTimezone, req.RepeatFrom)
+		updatedTodo.Occurrence = max(existingTodo.Occurrence, 1)
+
+		// The series restarts from the due date when the rule or the due date changes
+		updatedTodo.RecurrenceStart = existingTodo.RecurrenceStart
+		if updatedTodo.Recurrence != existingTodo.Recurrence ||
+			updatedTodo.RecurrenceTimezone != existingTodo.RecurrenceTimezone ||
+			!sameTime(updatedTodo.DueDate, existingTodo.DueDate) {
+			updatedTodo.RecurrenceStart = updatedTodo.DueDate
+		}
+
+		// Completing the todo creates the next occurrence, which takes over the rule
+		if updatedTodo.Completed && !existingTodo.Completed {
+			updatedTodo.NextOccurrence = nextOccurrence(updatedTodo, rule, updatedTodo.UpdatedAt)
+			if next := updatedTodo.NextOccurrence; next != nil {
+				setStatus(next, workflow.Statuses[0], next.CreatedAt)
+			}
+			updatedTodo.Recurrence = ""
+			updatedTodo.RecurrenceTimezone = ""
+			updatedTodo.RepeatFrom = ""
+			updatedTodo.RecurrenceStart = nil
+		}
+	}
+
+	// The update fails if a concurrent one changed the status this one started from
+	todo, err := s.todoRepo.Update(ctx, userID, existingTodo.ID, existingTodo.Status, updatedTodo)
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		return nil, errors.New("todo not found")
+	}
+
+	if completeSubtasks && todo.Completed {
+		recurringIDs, err := s.todoRepo.CompleteSubtasks(ctx, userID, todo.ID, todo.Status)
+		if err != nil {
+			return nil, err
+		}
+		for _, subtaskID := range recurringIDs {
+			if err := s.completeRecurringSubtask(ctx, userID, subtaskID); err != nil {
+				return nil, err
+			}
+		}
+		// Reload the todo for its progress
+		reloaded, err := s.getExistingTodo(ctx, userID, todo.ID)
+		if err != nil {
+			return nil, err
+		}
+		reloaded.NextOccurrence = todo.NextOccurrence
+		return reloaded, nil
+	}
+
+	return todo, nil
+}
+
+// completeRecurringSubtask completes a recurring subtask left open by
+// CompleteSubtasks, creating its next occurrence
+func (s *todoServiceImpl) completeRecurringSubtask(ctx context.Context, userID uint, id uint) error {
+	subtask, err := s.getExistingTodo(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if subtask.Completed {
+		return nil
+	}
+
+	req := todoToUpdateRequest(subtask)
+	req.Completed = true
+	_, err = s.replaceTodo(ctx, userID, subtask, req, nil, false)
+	return err
+}
+
+// sameProject reports whether two project references are the same project or both the Inbox
+func sameProject(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameTime reports whether two optional times are the same instant or both unset
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// todoToUpdateRequest returns the editable representation of a todo
+func todoToUpdateRequest(todo *models.Todo) *models.UpdateTodoRequest {
+	req := &models.UpdateTodoRequest{
+		Title:              todo.Title,
+		Description:        todo.Description,
+		Completed:          todo.Completed,
+		Priority:           todo.Priority,
+		ProjectID:          todo.ProjectID,
+		Recurrence:         todo.Recurrence,
+		RecurrenceTimezone: todo.RecurrenceTimezone,
+		RepeatFrom:         todo.RepeatFrom,
+		// Tags are represented by name, so patches can add or remove them by name
+		Tags: make([]string, 0, len(todo.Tags)),
+	}
+	for _, tag := range todo.Tags {
+		req.Tags = append(req.Tags, tag.Name)
+	}
+
+	if todo.DueDate != nil {
+		dueDate := todo.DueDate.UTC().Format(time.RFC3339)
+		req.DueDate = &dueDate
+	}
+	if todo.StartDate != nil {
+		startDate := todo.StartDate.UTC().Format(time.RFC3339)
+		req.StartDate = &startDate
+	}
+
+	return req
 }
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/utils"
+)
+
+// maxSnooze bounds how long a todo can be snoozed for
+const maxSnooze = 366 * 24 * time.Hour
+
+func (s *todoServiceImpl) SnoozeTodo(ctx context.Context, userID uint, id uint, req *models.SnoozeTodoRequest) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	user, err := s.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	until, err := resolveSnooze(req, time.Now().UTC(), user.Preferences)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.todoRepo.Snooze(ctx, userID, id, &until); err != nil {
+		return nil, err
+	}
+	return s.getExistingTodo(ctx, userID, id)
+}
+
+func (s *todoServiceImpl) UnsnoozeTodo(ctx context.Context, userID uint, id uint) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	if err := s.todoRepo.Snooze(ctx, userID, id, nil); err != nil {
+		return nil, err
+	}
+	return s.getExistingTodo(ctx, userID, id)
+}
+
+// resolveSnooze returns the end of the snooze req asks for at now. Presets
+// are resolved in the time zone and week of prefs.
+func resolveSnooze(req *models.SnoozeTodoRequest, now time.Time, prefs models.UserPreferences) (time.Time, error) {
+	given := 0
+	for _, set := range []bool{req.Until != nil, req.Duration != "", req.Preset != ""} {
+		if set {
+			given++
+		}
+	}
+	if given != 1 {
+		return time.Time{}, errors.New("either until, duration or preset is required")
+	}
+
+	var until time.Time
+	switch {
+	case req.Until != nil:
+		parsed := utils.ParseStringToDate(req.Until)
+		if parsed == nil {
+			return time.Time{}, errors.New("invalid snooze time")
+		}
+		until = parsed.UTC()
+	case req.Duration != "":
+		d, err := parseSnoozeDuration(req.Duration)
+		if err != nil {
+			return time.Time{}, err
+		}
+		until = now.Add(d)
+	default:
+		loc, err := time.LoadLocation(prefs.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+		year, month, day := now.In(loc).Date()
+
+		switch req.Preset {
+		case models.SnoozeTomorrow:
+			day++
+		case models.SnoozeWeekend:
+			day += daysUntil(now.In(loc).Weekday(), time.Saturday)
+		case models.SnoozeNextWeek:
+			weekStart := time.Monday
+			if prefs.WeekStart == "sunday" {
+				weekStart = time.Sunday
+			}
+			day += daysUntil(now.In(loc).Weekday(), weekStart)
+		case models.SnoozeNextMonth:
+			month, day = month+1, 1
+		default:
+			return time.Time{}, errors.New("invalid snooze preset")
+		}
+		until = time.Date(year, month, day, 0, 0, 0, 0, loc).UTC()
+	}
+
+	if !until.After(now) {
+		return time.Time{}, errors.New("snooze must end in the future")
+	}
+	if until.Sub(now) > maxSnooze {
+		return time.Time{}, errors.New("snooze must end within a year")
+	}
+	return until, nil
+}
+
+// daysUntil returns the number of days from a day to the next weekday, 1 to 7
+func daysUntil(from, weekday time.Weekday) int {
+	days := (int(weekday) - int(from) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return days
+}
+
+// parseSnoozeDuration parses a duration such as "90m" or "3h" as in
+// time.ParseDuration, or a whole number of days or weeks such as "2d" or "1w"
+func parseSnoozeDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	var d time.Duration
+	var err error
+	switch {
+	case strings.HasSuffix(value, "d"), strings.HasSuffix(value, "w"):
+		unit := 24 * time.Hour
+		if strings.HasSuffix(value, "w") {
+			unit *= 7
+		}
+		var n int
+		n, err = strconv.Atoi(value[:len(value)-1])
+		if n > 1000 {
+			n = 1000 // beyond the longest snooze, without overflowing
+		}
+		d = time.Duration(n) * unit
+	default:
+		d, err = time.ParseDuration(value)
+	}
+	if err != nil || d <= 0 {
+		return 0, errors.New("invalid snooze duration")
+	}
+	return d, nil
+}
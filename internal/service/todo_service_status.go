@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+)
+
+func (s *todoServiceImpl) TransitionTodo(ctx context.Context, userID uint, id uint, req *models.TransitionTodoRequest, completeSubtasks bool) (*models.Todo, error) {
+	if userID == 0 {
+		return nil, errors.New("invalid user ID")
+	}
+
+	if id == 0 {
+		return nil, errors.New("invalid todo ID")
+	}
+
+	existingTodo, err := s.getExistingTodo(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := resolveWorkflow(ctx, s.workflowRepo, userID, existingTodo.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, ok := workflow.Status(strings.TrimSpace(req.Status))
+	if !ok {
+		return nil, errors.New("unknown status")
+	}
+	if target.Key == existingTodo.Status {
+		return existingTodo, nil
+	}
+
+	// A todo whose status is not part of its workflow, after the workflow
+	// changed or the todo moved to another project, can move to any status
+	if current, ok := workflow.Status(existingTodo.Status); ok && !slices.Contains(current.Transitions, target.Key) {
+		return nil, errors.New("transition not allowed")
+	}
+
+	return s.replaceTodo(ctx, userID, existingTodo, todoToUpdateRequest(existingTodo), &target, completeSubtasks)
+}
+
+// setStatus moves a todo to a status at now, deriving Completed and the
+// status timestamps from the category of the status
+func setStatus(todo *models.Todo, status models.WorkflowStatus, now time.Time) {
+	todo.Status = status.Key
+	todo.Completed = status.Category == models.StatusCategoryDone
+
+	if status.Category == models.StatusCategoryInProgress && todo.StartedAt == nil {
+		todo.StartedAt = &now
+	}
+	todo.CompletedAt = statusSince(todo.CompletedAt, status.Category == models.StatusCategoryDone, now)
+	todo.CancelledAt = statusSince(todo.CancelledAt, status.Category == models.StatusCategoryCancelled, now)
+}
+
+// statusSince returns when a todo entered a category it is in, which is now
+// unless it already was, or nil when it is not in the category
+func statusSince(since *time.Time, in bool, now time.Time) *time.Time {
+	if !in {
+		return nil
+	}
+	if since == nil {
+		return &now
+	}
+	return since
+}
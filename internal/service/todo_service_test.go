@@ -7,6 +7,7 @@ import (
 	"time"
 	"todo-list-api/internal/models"
 	"todo-list-api/internal/service/mocks"
+	"todo-list-api/internal/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,15 +16,32 @@ import (
 
 type TodoServiceTestSuite struct {
 	suite.Suite
-	mockRepo *mocks.MockTodoRepository
-	service  TodoService
-	ctx      context.Context
+	mockRepo         *mocks.MockTodoRepository
+	mockTagRepo      *mocks.MockTagRepository
+	mockProjectRepo  *mocks.MockProjectRepository
+	mockAuthRepo     *mocks.MockAuthRepository
+	mockWorkflowRepo *mocks.MockWorkflowRepository
+	service          TodoService
+	ctx              context.Context
+	userID           uint
 }
 
 func (suite *TodoServiceTestSuite) SetupTest() {
 	suite.mockRepo = new(mocks.MockTodoRepository)
-	suite.service = NewTodoService(suite.mockRepo)
+	suite.mockTagRepo = new(mocks.MockTagRepository)
+	suite.mockProjectRepo = new(mocks.MockProjectRepository)
+	suite.mockAuthRepo = new(mocks.MockAuthRepository)
 	suite.ctx = context.Background()
+	suite.userID = 1
+	suite.useWorkflow(nil)
+}
+
+// useWorkflow makes the user's todos follow workflow, the default one when
+// nil, with a new workflow repository and service
+func (suite *TodoServiceTestSuite) useWorkflow(workflow *models.Workflow) {
+	suite.mockWorkflowRepo = new(mocks.MockWorkflowRepository)
+	suite.mockWorkflowRepo.On("Get", suite.ctx, suite.userID, mock.Anything).Return(workflow, nil)
+	suite.service = NewTodoService(suite.mockRepo, suite.mockTagRepo, suite.mockProjectRepo, suite.mockAuthRepo, suite.mockWorkflowRepo)
 }
 
 func (suite *TodoServiceTestSuite) TestCreateTodo_Success() {
@@ -32,35 +50,38 @@ func (suite *TodoServiceTestSuite) TestCreateTodo_Success() {
 		Title:       "Test Todo",
 		Description: "Test Description",
 		Priority:    "high",
-		Category:    "work",
+		Tags:        []string{" work ", "Work"},
 	}
 
+	workTag := models.Tag{ID: 5, Name: "work"}
 	expectedTodo := &models.Todo{
 		ID:          1,
 		Title:       "Test Todo",
 		Description: "Test Description",
 		Priority:    "high",
-		Category:    "work",
+		Tags:        []models.Tag{workTag},
 		Completed:   false,
 	}
 
+	suite.mockTagRepo.On("EnsureNames", suite.ctx, suite.userID, []string{"work"}).Return([]models.Tag{workTag}, nil)
 	suite.mockRepo.On("Create", suite.ctx, mock.MatchedBy(func(todo *models.Todo) bool {
-		return todo.Title == "Test Todo" &&
+		return todo.UserID == uint64(suite.userID) &&
+			todo.Title == "Test Todo" &&
 			todo.Description == "Test Description" &&
 			todo.Priority == "high" &&
-			todo.Category == "work" &&
+			len(todo.Tags) == 1 && todo.Tags[0].ID == workTag.ID &&
 			todo.Completed == false
 	})).Return(expectedTodo, nil)
 
 	// Act
-	result, err := suite.service.CreateTodo(suite.ctx, req)
+	result, err := suite.service.CreateTodo(suite.ctx, suite.userID, req)
 
 	// Assert
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 	assert.Equal(suite.T(), expectedTodo.Title, result.Title)
 	assert.Equal(suite.T(), expectedTodo.Priority, result.Priority)
-	assert.Equal(suite.T(), expectedTodo.Category, result.Category)
+	assert.Equal(suite.T(), expectedTodo.Tags, result.Tags)
 	assert.False(suite.T(), result.Completed)
 }
 
@@ -76,7 +97,7 @@ func (suite *TodoServiceTestSuite) TestCreateTodo_RepositoryError() {
 		Return(nil, errors.New("database error"))
 
 	// Act
-	result, err := suite.service.CreateTodo(suite.ctx, req)
+	result, err := suite.service.CreateTodo(suite.ctx, suite.userID, req)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -93,10 +114,10 @@ func (suite *TodoServiceTestSuite) TestGetTodoByID_Success() {
 		Title: "Test Todo",
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, todoID).Return(expectedTodo, nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(expectedTodo, nil)
 
 	// Act
-	result, err := suite.service.GetTodoByID(suite.ctx, todoID)
+	result, err := suite.service.GetTodoByID(suite.ctx, suite.userID, todoID)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -108,7 +129,7 @@ func (suite *TodoServiceTestSuite) TestGetTodoByID_Success() {
 // TestGetTodoByID_InvalidID tests invalid ID handling
 func (suite *TodoServiceTestSuite) TestGetTodoByID_InvalidID() {
 	// Act
-	result, err := suite.service.GetTodoByID(suite.ctx, 0)
+	result, err := suite.service.GetTodoByID(suite.ctx, suite.userID, 0)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -120,10 +141,10 @@ func (suite *TodoServiceTestSuite) TestGetTodoByID_InvalidID() {
 func (suite *TodoServiceTestSuite) TestGetTodoByID_NotFound() {
 	// Arrange
 	todoID := uint(999)
-	suite.mockRepo.On("GetByID", suite.ctx, todoID).Return(nil, errors.New("todo not found"))
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(nil, errors.New("todo not found"))
 
 	// Act
-	result, err := suite.service.GetTodoByID(suite.ctx, todoID)
+	result, err := suite.service.GetTodoByID(suite.ctx, suite.userID, todoID)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -155,8 +176,8 @@ func (suite *TodoServiceTestSuite) TestUpdateTodo_Success() {
 		Priority:    "medium",
 	}
 
-	suite.mockRepo.On("GetByID", suite.ctx, todoID).Return(existingTodo, nil)
-	suite.mockRepo.On("Update", suite.ctx, todoID, mock.MatchedBy(func(todo *models.Todo) bool {
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
 		return todo.Title == "Updated Title" &&
 			todo.Description == "Updated Description" &&
 			todo.Completed == true &&
@@ -165,7 +186,7 @@ func (suite *TodoServiceTestSuite) TestUpdateTodo_Success() {
 	})).Return(updatedTodo, nil)
 
 	// Act
-	result, err := suite.service.UpdateTodo(suite.ctx, todoID, req)
+	result, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, req, false)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -181,7 +202,7 @@ func (suite *TodoServiceTestSuite) TestUpdateTodo_InvalidID() {
 	req := &models.UpdateTodoRequest{Title: "Updated Title"}
 
 	// Act
-	result, err := suite.service.UpdateTodo(suite.ctx, 0, req)
+	result, err := suite.service.UpdateTodo(suite.ctx, suite.userID, 0, req, false)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -195,10 +216,10 @@ func (suite *TodoServiceTestSuite) TestUpdateTodo_TodoNotFound() {
 	todoID := uint(999)
 	req := &models.UpdateTodoRequest{Title: "Updated Title"}
 
-	suite.mockRepo.On("GetByID", suite.ctx, todoID).Return(nil, nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(nil, nil)
 
 	// Act
-	result, err := suite.service.UpdateTodo(suite.ctx, todoID, req)
+	result, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, req, false)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -206,17 +227,111 @@ func (suite *TodoServiceTestSuite) TestUpdateTodo_TodoNotFound() {
 	assert.Contains(suite.T(), err.Error(), "todo not found")
 }
 
+// TestPatchTodo_MergePatch tests that a merge patch clears null fields and keeps absent ones
+func (suite *TodoServiceTestSuite) TestPatchTodo_MergePatch() {
+	// Arrange
+	todoID := uint(1)
+	dueDate := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	existingTodo := &models.Todo{
+		ID:          todoID,
+		Title:       "Write report",
+		Description: "Quarterly numbers",
+		Priority:    "high",
+		Tags:        []models.Tag{{ID: 5, Name: "work"}},
+		DueDate:     &dueDate,
+		Completed:   true,
+	}
+	patch := []byte(`{"completed": false, "description": null, "dueDate": null}`)
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockTagRepo.On("EnsureNames", suite.ctx, suite.userID, []string{"work"}).Return(existingTodo.Tags, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.Title == "Write report" &&
+			todo.Description == "" &&
+			todo.Priority == "high" &&
+			len(todo.Tags) == 1 && todo.Tags[0].ID == 5 &&
+			todo.DueDate == nil &&
+			!todo.Completed
+	})).Return(&models.Todo{ID: todoID, Title: "Write report"}, nil)
+
+	// Act
+	result, err := suite.service.PatchTodo(suite.ctx, suite.userID, todoID, models.PatchTypeMerge, patch, false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NotNil(suite.T(), result)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestPatchTodo_JSONPatch tests applying an RFC 6902 patch
+func (suite *TodoServiceTestSuite) TestPatchTodo_JSONPatch() {
+	// Arrange
+	todoID := uint(1)
+	existingTodo := &models.Todo{ID: todoID, Title: "Old Title", Priority: "low"}
+	patch := []byte(`[
+		{"op": "test", "path": "/title", "value": "Old Title"},
+		{"op": "replace", "path": "/title", "value": "New Title"},
+		{"op": "replace", "path": "/priority", "value": "medium"}
+	]`)
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.Title == "New Title" && todo.Priority == "medium"
+	})).Return(&models.Todo{ID: todoID, Title: "New Title"}, nil)
+
+	// Act
+	result, err := suite.service.PatchTodo(suite.ctx, suite.userID, todoID, models.PatchTypeJSON, patch, false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "New Title", result.Title)
+}
+
+// TestPatchTodo_ValidationError tests that the patched document is validated
+func (suite *TodoServiceTestSuite) TestPatchTodo_ValidationError() {
+	// Arrange
+	todoID := uint(1)
+	existingTodo := &models.Todo{ID: todoID, Title: "Title", Priority: "low"}
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+
+	// Act
+	result, err := suite.service.PatchTodo(suite.ctx, suite.userID, todoID, models.PatchTypeMerge, []byte(`{"title": null}`), false)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestPatchTodo_UnknownField tests that patches cannot introduce unknown fields
+func (suite *TodoServiceTestSuite) TestPatchTodo_UnknownField() {
+	// Arrange
+	todoID := uint(1)
+	existingTodo := &models.Todo{ID: todoID, Title: "Title"}
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+
+	// Act
+	result, err := suite.service.PatchTodo(suite.ctx, suite.userID, todoID, models.PatchTypeMerge, []byte(`{"userId": 2}`), false)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "invalid patch document")
+}
+
 // TestDeleteTodo_Success tests successful todo deletion
 func (suite *TodoServiceTestSuite) TestDeleteTodo_Success() {
 	// Arrange
 	todoID := uint(1)
 	existingTodo := &models.Todo{ID: todoID, Title: "Test Todo"}
 
-	suite.mockRepo.On("GetByID", suite.ctx, todoID).Return(existingTodo, nil)
-	suite.mockRepo.On("Delete", suite.ctx, todoID).Return(nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockRepo.On("Delete", suite.ctx, suite.userID, todoID, models.SubtasksDelete).Return(nil)
 
 	// Act
-	err := suite.service.DeleteTodo(suite.ctx, todoID)
+	err := suite.service.DeleteTodo(suite.ctx, suite.userID, todoID, "")
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -225,37 +340,204 @@ func (suite *TodoServiceTestSuite) TestDeleteTodo_Success() {
 // TestDeleteTodo_InvalidID tests invalid ID handling
 func (suite *TodoServiceTestSuite) TestDeleteTodo_InvalidID() {
 	// Act
-	err := suite.service.DeleteTodo(suite.ctx, 0)
+	err := suite.service.DeleteTodo(suite.ctx, suite.userID, 0, "")
 
 	// Assert
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "invalid todo ID")
 }
 
-// TestGetTodosByUserID_Success tests successful user todos retrieval
-func (suite *TodoServiceTestSuite) TestGetTodosByUserID_Success() {
+// TestDeleteTodo_NotOwned tests that another user's todo is treated as not found
+func (suite *TodoServiceTestSuite) TestDeleteTodo_NotOwned() {
+	// Arrange
+	todoID := uint(1)
+	otherUserID := uint(2)
+
+	suite.mockRepo.On("GetByID", suite.ctx, otherUserID, todoID).Return(nil, nil)
+
+	// Act
+	err := suite.service.DeleteTodo(suite.ctx, otherUserID, todoID, "")
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Contains(suite.T(), err.Error(), "todo not found")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Delete", suite.ctx, otherUserID, todoID)
+}
+
+// TestGetTodos_Success tests successful user todos retrieval with default paging
+func (suite *TodoServiceTestSuite) TestGetTodos_Success() {
 	// Arrange
-	userID := uint(1)
-	expectedTodos := []models.Todo{
-		{ID: 1, Title: "Todo 1"},
-		{ID: 2, Title: "Todo 2"},
+	expectedPage := &models.TodoPage{
+		Items: []models.Todo{
+			{ID: 1, Title: "Todo 1"},
+			{ID: 2, Title: "Todo 2"},
+		},
+		TotalCount: 2,
 	}
 
-	suite.mockRepo.On("GetByUserID", suite.ctx, userID).Return(expectedTodos, nil)
+	suite.mockRepo.On("List", suite.ctx, suite.userID, mock.MatchedBy(func(query models.TodoQuery) bool {
+		return query.Sort == models.TodoSortCreatedAt &&
+			query.Order == models.SortDesc &&
+			query.Limit == models.DefaultTodoPageSize &&
+			query.Cursor == nil &&
+			!query.Filter.IncludeDeferred
+	})).Return(expectedPage, nil)
 
 	// Act
-	result, err := suite.service.GetTodosByUserID(suite.ctx, userID)
+	result, err := suite.service.GetTodos(suite.ctx, suite.userID, &models.ListTodosRequest{})
 
 	// Assert
 	assert.NoError(suite.T(), err)
-	assert.Len(suite.T(), result, 2)
-	assert.Equal(suite.T(), expectedTodos[0].Title, result[0].Title)
+	assert.Len(suite.T(), result.Items, 2)
+	assert.Equal(suite.T(), int64(2), result.TotalCount)
+	assert.Equal(suite.T(), expectedPage.Items[0].Title, result.Items[0].Title)
 }
 
-// TestGetTodosByUserID_InvalidID tests invalid user ID handling
-func (suite *TodoServiceTestSuite) TestGetTodosByUserID_InvalidID() {
+// TestGetTodos_Filters tests that request filters reach the repository query
+func (suite *TodoServiceTestSuite) TestGetTodos_Filters() {
+	// Arrange
+	completed := false
+	dueBefore := "2030-01-01T00:00:00Z"
+	req := &models.ListTodosRequest{
+		Limit:     500,
+		Completed: &completed,
+		Priority:  "high",
+		Tags:      []string{" work "},
+		TagIDs:    []uint{7, 7},
+		TagMatch:  models.TagMatchAll,
+		DueBefore: &dueBefore,
+		Sort:      models.TodoSortDueDate,
+		Order:     models.SortAsc,
+	}
+
+	suite.mockRepo.On("List", suite.ctx, suite.userID, mock.MatchedBy(func(query models.TodoQuery) bool {
+		return query.Limit == models.MaxTodoPageSize &&
+			query.Sort == models.TodoSortDueDate &&
+			query.Order == models.SortAsc &&
+			query.Filter.Completed != nil && !*query.Filter.Completed &&
+			query.Filter.Priority == "high" &&
+			assert.ObjectsAreEqual([]uint{7, 5}, query.Filter.TagIDs) &&
+			query.Filter.TagMatch == models.TagMatchAll &&
+			query.Filter.DueBefore != nil && query.Filter.DueBefore.Year() == 2030
+	})).Return(&models.TodoPage{}, nil)
+	suite.mockTagRepo.On("FindByIDs", suite.ctx, suite.userID, []uint{7}).Return([]models.Tag{{ID: 7, Name: "home"}}, nil)
+	suite.mockTagRepo.On("FindByNames", suite.ctx, suite.userID, []string{"work"}).Return([]models.Tag{{ID: 5, Name: "work"}}, nil)
+
 	// Act
-	result, err := suite.service.GetTodosByUserID(suite.ctx, 0)
+	_, err := suite.service.GetTodos(suite.ctx, suite.userID, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestCreateTodo_UnknownProject tests that todos can only be put in the user's own projects
+func (suite *TodoServiceTestSuite) TestCreateTodo_UnknownProject() {
+	// Arrange
+	projectID := uint(4)
+	suite.mockProjectRepo.On("GetByID", suite.ctx, suite.userID, projectID).Return(nil, nil)
+
+	// Act
+	result, err := suite.service.CreateTodo(suite.ctx, suite.userID, &models.CreateTodoRequest{Title: "Test", ProjectID: &projectID})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "project not found")
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestCreateSubtask_InheritsProject tests that a subtask is created in its parent's project
+func (suite *TodoServiceTestSuite) TestCreateSubtask_InheritsProject() {
+	// Arrange
+	projectID := uint(4)
+	parent := &models.Todo{ID: 10, UserID: uint64(suite.userID), ProjectID: &projectID}
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, parent.ID).Return(parent, nil)
+	suite.mockProjectRepo.On("GetByID", suite.ctx, suite.userID, projectID).Return(&models.Project{ID: projectID}, nil)
+	suite.mockRepo.On("Create", suite.ctx, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.ProjectID != nil && *todo.ProjectID == projectID && *todo.ParentID == parent.ID
+	})).Return(&models.Todo{ID: 11, ParentID: &parent.ID, ProjectID: &projectID}, nil)
+
+	// Act
+	result, err := suite.service.CreateSubtask(suite.ctx, suite.userID, parent.ID, &models.CreateTodoRequest{Title: "Step"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), projectID, *result.ProjectID)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestPatchTodo_MoveToInbox tests that a null projectId moves a todo to the Inbox
+func (suite *TodoServiceTestSuite) TestPatchTodo_MoveToInbox() {
+	// Arrange
+	projectID := uint(4)
+	existingTodo := &models.Todo{ID: 1, UserID: uint64(suite.userID), Title: "Call", Priority: "low", ProjectID: &projectID}
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, existingTodo.ID).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, existingTodo.ID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.ProjectID == nil
+	})).Return(&models.Todo{ID: 1, Title: "Call"}, nil)
+
+	// Act
+	result, err := suite.service.PatchTodo(suite.ctx, suite.userID, existingTodo.ID, models.PatchTypeMerge, []byte(`{"projectId": null}`), false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.ProjectID)
+	suite.mockProjectRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetTodos_UnknownTagMatchAll tests that no todo has every tag when the user lacks one of them
+func (suite *TodoServiceTestSuite) TestGetTodos_UnknownTagMatchAll() {
+	// Arrange
+	req := &models.ListTodosRequest{Tags: []string{"work", "typo"}, TagMatch: models.TagMatchAll}
+	suite.mockTagRepo.On("FindByIDs", suite.ctx, suite.userID, []uint{}).Return([]models.Tag{}, nil)
+	suite.mockTagRepo.On("FindByNames", suite.ctx, suite.userID, []string{"work", "typo"}).Return([]models.Tag{{ID: 5, Name: "work"}}, nil)
+
+	// Act
+	page, err := suite.service.GetTodos(suite.ctx, suite.userID, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), page.Items)
+	suite.mockRepo.AssertNotCalled(suite.T(), "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestCreateTodo_UnknownTagID tests that tags given by ID must belong to the user
+func (suite *TodoServiceTestSuite) TestCreateTodo_UnknownTagID() {
+	// Arrange
+	suite.mockTagRepo.On("FindByIDs", suite.ctx, suite.userID, []uint{9}).Return([]models.Tag{}, nil)
+
+	// Act
+	result, err := suite.service.CreateTodo(suite.ctx, suite.userID, &models.CreateTodoRequest{Title: "Test", TagIDs: []uint{9}})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "tag not found")
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestGetTodos_CursorSortMismatch tests that a cursor cannot be reused with another ordering
+func (suite *TodoServiceTestSuite) TestGetTodos_CursorSortMismatch() {
+	// Arrange
+	cursor, _ := utils.EncodeCursor(models.TodoCursor{
+		Sort:  models.TodoSortTitle,
+		Order: models.SortAsc,
+		Value: "Todo 1",
+		ID:    1,
+	})
+
+	// Act
+	result, err := suite.service.GetTodos(suite.ctx, suite.userID, &models.ListTodosRequest{Cursor: cursor})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "invalid cursor")
+}
+
+// TestGetTodos_InvalidUserID tests invalid user ID handling
+func (suite *TodoServiceTestSuite) TestGetTodos_InvalidUserID() {
+	// Act
+	result, err := suite.service.GetTodos(suite.ctx, 0, nil)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -263,6 +545,583 @@ func (suite *TodoServiceTestSuite) TestGetTodosByUserID_InvalidID() {
 	assert.Contains(suite.T(), err.Error(), "invalid user ID")
 }
 
+// TestSearchTodos_EmptyQuery tests that a blank search is rejected
+func (suite *TodoServiceTestSuite) TestSearchTodos_EmptyQuery() {
+	// Act
+	result, err := suite.service.SearchTodos(suite.ctx, suite.userID, &models.SearchTodosRequest{Query: "   "})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	assert.Contains(suite.T(), err.Error(), "search query is required")
+}
+
+// TestSearchTodos_Success tests that search text and filters reach the repository
+func (suite *TodoServiceTestSuite) TestSearchTodos_Success() {
+	// Arrange
+	req := &models.SearchTodosRequest{Query: " groceries ", Priority: "high"}
+	expectedPage := &models.TodoSearchPage{
+		Items: []models.TodoSearchResult{
+			{Todo: models.Todo{ID: 1, Title: "Buy groceries"}, TitleHighlight: "Buy <mark>groceries</mark>"},
+		},
+		TotalCount: 1,
+	}
+
+	suite.mockRepo.On("Search", suite.ctx, suite.userID, mock.MatchedBy(func(query models.TodoSearchQuery) bool {
+		return query.Text == "groceries" &&
+			query.Filter.Priority == "high" &&
+			query.Limit == models.DefaultTodoPageSize
+	})).Return(expectedPage, nil)
+
+	// Act
+	result, err := suite.service.SearchTodos(suite.ctx, suite.userID, req)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Items, 1)
+	assert.Equal(suite.T(), "Buy <mark>groceries</mark>", result.Items[0].TitleHighlight)
+}
+
+// TestDeleteTodoPermanently_Success tests that a permanent delete bypasses the trash
+func (suite *TodoServiceTestSuite) TestDeleteTodoPermanently_Success() {
+	// Arrange
+	suite.mockRepo.On("DeletePermanently", suite.ctx, suite.userID, uint(1), models.SubtasksDelete).Return(nil)
+
+	// Act
+	err := suite.service.DeleteTodoPermanently(suite.ctx, suite.userID, 1, "")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetTrash_Defaults tests that the trash is listed with the default page size
+func (suite *TodoServiceTestSuite) TestGetTrash_Defaults() {
+	// Arrange
+	deletedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	expectedPage := &models.TrashPage{
+		Items:      []models.TrashedTodo{{Todo: models.Todo{ID: 1, Title: "Deleted"}, DeletedAt: deletedAt}},
+		TotalCount: 1,
+	}
+	suite.mockRepo.On("ListTrash", suite.ctx, suite.userID, models.DefaultTodoPageSize, 0).Return(expectedPage, nil)
+
+	// Act
+	result, err := suite.service.GetTrash(suite.ctx, suite.userID, nil)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedPage, result)
+}
+
+// TestRestoreTodo_Success tests that a restored todo is returned
+func (suite *TodoServiceTestSuite) TestRestoreTodo_Success() {
+	// Arrange
+	restored := &models.Todo{ID: 1, UserID: uint64(suite.userID), Title: "Restored"}
+	suite.mockRepo.On("Restore", suite.ctx, suite.userID, uint(1)).Return(nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(restored, nil)
+
+	// Act
+	result, err := suite.service.RestoreTodo(suite.ctx, suite.userID, 1)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), restored, result)
+}
+
+// TestRestoreTodo_NotInTrash tests that only trashed todos can be restored
+func (suite *TodoServiceTestSuite) TestRestoreTodo_NotInTrash() {
+	// Arrange
+	suite.mockRepo.On("Restore", suite.ctx, suite.userID, uint(1)).Return(errors.New("todo not found"))
+
+	// Act
+	result, err := suite.service.RestoreTodo(suite.ctx, suite.userID, 1)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "todo not found")
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUpdateTodo_CompleteSubtasks tests that completing a todo can complete its subtasks too
+func (suite *TodoServiceTestSuite) TestUpdateTodo_CompleteSubtasks() {
+	// Arrange
+	todoID := uint(1)
+	existingTodo := &models.Todo{ID: todoID, Title: "Parent", Status: models.StatusTodo}
+	completedTodo := &models.Todo{ID: todoID, Title: "Parent", Completed: true, Status: models.StatusDone}
+	reloadedTodo := &models.Todo{ID: todoID, Title: "Parent", Completed: true, Progress: models.TodoProgress{Subtasks: 2, CompletedSubtasks: 2, Percent: 100}}
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil).Once()
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.Anything).Return(completedTodo, nil)
+	suite.mockRepo.On("CompleteSubtasks", suite.ctx, suite.userID, todoID, models.StatusDone).Return([]uint{}, nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(reloadedTodo, nil).Once()
+
+	// Act
+	result, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, &models.UpdateTodoRequest{Title: "Parent", Completed: true}, true)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 100, result.Progress.Percent)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestUpdateTodo_CompleteSubtasksNotCompleted tests that subtasks are left alone while the todo stays open
+func (suite *TodoServiceTestSuite) TestUpdateTodo_CompleteSubtasksNotCompleted() {
+	// Arrange
+	todoID := uint(1)
+	existingTodo := &models.Todo{ID: todoID, Title: "Parent"}
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.Anything).Return(existingTodo, nil)
+
+	// Act
+	_, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, &models.UpdateTodoRequest{Title: "Parent"}, true)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertNotCalled(suite.T(), "CompleteSubtasks", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUpdateTodo_CompleteRecurringSubtasks tests that recurring subtasks completed with their
+// parent create their next occurrence
+func (suite *TodoServiceTestSuite) TestUpdateTodo_CompleteRecurringSubtasks() {
+	// Arrange
+	todoID, subtaskID := uint(1), uint(2)
+	dueDate := "2026-01-05T09:00:00Z"
+	start := utils.ParseStringToDate(&dueDate)
+	existingTodo := &models.Todo{ID: todoID, Title: "Parent", Status: models.StatusTodo}
+	completedTodo := &models.Todo{ID: todoID, Title: "Parent", Completed: true, Status: models.StatusDone}
+	subtask := &models.Todo{
+		ID: subtaskID, UserID: uint64(suite.userID), ParentID: &todoID, Title: "Weekly report", DueDate: start,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO", RepeatFrom: models.RepeatFromDue, RecurrenceStart: start, Occurrence: 1,
+	}
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil).Once()
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.Anything).Return(completedTodo, nil)
+	suite.mockRepo.On("CompleteSubtasks", suite.ctx, suite.userID, todoID, models.StatusDone).Return([]uint{subtaskID}, nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, subtaskID).Return(subtask, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, subtaskID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		next := todo.NextOccurrence
+		return todo.Completed && todo.Recurrence == "" &&
+			next != nil && !next.Completed &&
+			next.ParentID != nil && *next.ParentID == todoID &&
+			next.Occurrence == 2 &&
+			next.DueDate.Equal(time.Date(2026, time.January, 12, 9, 0, 0, 0, time.UTC))
+	})).Return(&models.Todo{ID: subtaskID, Completed: true, NextOccurrence: &models.Todo{ID: 3}}, nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(completedTodo, nil).Once()
+
+	// Act
+	result, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, &models.UpdateTodoRequest{Title: "Parent", Completed: true}, true)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Completed)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestDeleteTodo_PromoteSubtasks tests that the subtasks option reaches the repository
+func (suite *TodoServiceTestSuite) TestDeleteTodo_PromoteSubtasks() {
+	// Arrange
+	todoID := uint(1)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(&models.Todo{ID: todoID}, nil)
+	suite.mockRepo.On("Delete", suite.ctx, suite.userID, todoID, models.SubtasksPromote).Return(nil)
+
+	// Act
+	err := suite.service.DeleteTodo(suite.ctx, suite.userID, todoID, models.SubtasksPromote)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestDeleteTodo_InvalidSubtasksOption tests that unknown subtasks options are rejected
+func (suite *TodoServiceTestSuite) TestDeleteTodo_InvalidSubtasksOption() {
+	// Act
+	err := suite.service.DeleteTodo(suite.ctx, suite.userID, 1, "orphan")
+
+	// Assert
+	assert.EqualError(suite.T(), err, "invalid subtasks option")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestCreateSubtask_Success tests that a subtask is created under its parent
+func (suite *TodoServiceTestSuite) TestCreateSubtask_Success() {
+	// Arrange
+	parentID := uint(1)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, parentID).Return(&models.Todo{ID: parentID}, nil)
+	suite.mockRepo.On("Create", suite.ctx, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.ParentID != nil && *todo.ParentID == parentID && todo.Title == "Step"
+	})).Return(&models.Todo{ID: 2, ParentID: &parentID, Title: "Step"}, nil)
+
+	// Act
+	result, err := suite.service.CreateSubtask(suite.ctx, suite.userID, parentID, &models.CreateTodoRequest{Title: " Step "})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), parentID, *result.ParentID)
+}
+
+// TestCreateSubtask_ParentNotFound tests that subtasks need an existing parent
+func (suite *TodoServiceTestSuite) TestCreateSubtask_ParentNotFound() {
+	// Arrange
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(nil, nil)
+
+	// Act
+	result, err := suite.service.CreateSubtask(suite.ctx, suite.userID, 1, &models.CreateTodoRequest{Title: "Step"})
+
+	// Assert
+	assert.EqualError(suite.T(), err, "todo not found")
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestAttachSubtask_Cycle tests that a move creating a cycle is rejected
+func (suite *TodoServiceTestSuite) TestAttachSubtask_Cycle() {
+	// Arrange
+	parentID := uint(2)
+	suite.mockRepo.On("SetParent", suite.ctx, suite.userID, uint(1), &parentID).Return(errors.New("subtask would create a cycle"))
+
+	// Act
+	result, err := suite.service.AttachSubtask(suite.ctx, suite.userID, parentID, 1)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "subtask would create a cycle")
+	assert.Nil(suite.T(), result)
+}
+
+// TestDetachSubtask_OtherParent tests that only a subtask of the given todo can be detached
+func (suite *TodoServiceTestSuite) TestDetachSubtask_OtherParent() {
+	// Arrange
+	otherParentID := uint(3)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(2)).Return(&models.Todo{ID: 2, ParentID: &otherParentID}, nil)
+
+	// Act
+	result, err := suite.service.DetachSubtask(suite.ctx, suite.userID, 1, 2)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "todo not found")
+	assert.Nil(suite.T(), result)
+	suite.mockRepo.AssertNotCalled(suite.T(), "SetParent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDetachSubtask_Success tests that a detached subtask becomes a top-level todo
+func (suite *TodoServiceTestSuite) TestDetachSubtask_Success() {
+	// Arrange
+	parentID := uint(1)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(2)).Return(&models.Todo{ID: 2, ParentID: &parentID}, nil).Once()
+	suite.mockRepo.On("SetParent", suite.ctx, suite.userID, uint(2), (*uint)(nil)).Return(nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(2)).Return(&models.Todo{ID: 2}, nil).Once()
+
+	// Act
+	result, err := suite.service.DetachSubtask(suite.ctx, suite.userID, parentID, 2)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result.ParentID)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestUpdateTodo_CompleteRecurringTodo tests that completing a recurring todo creates its next occurrence
+func (suite *TodoServiceTestSuite) TestUpdateTodo_CompleteRecurringTodo() {
+	// Arrange
+	todoID := uint(1)
+	dueDate := "2026-01-05T09:00:00Z"
+	start := utils.ParseStringToDate(&dueDate)
+	existingTodo := &models.Todo{
+		ID: todoID, UserID: uint64(suite.userID), Title: "Weekly report", DueDate: start,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO", RepeatFrom: models.RepeatFromDue, RecurrenceStart: start, Occurrence: 1,
+	}
+	req := todoToUpdateRequest(existingTodo)
+	req.Completed = true
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		next := todo.NextOccurrence
+		return todo.Completed && todo.Recurrence == "" &&
+			next != nil && !next.Completed &&
+			next.Title == "Weekly report" &&
+			next.Recurrence == "FREQ=WEEKLY;BYDAY=MO" &&
+			next.Occurrence == 2 &&
+			next.DueDate.Equal(time.Date(2026, time.January, 12, 9, 0, 0, 0, time.UTC))
+	})).Return(&models.Todo{ID: todoID, Completed: true, NextOccurrence: &models.Todo{ID: 2}}, nil)
+
+	// Act
+	result, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, req, false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint(2), result.NextOccurrence.ID)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestUpdateTodo_RepeatFromCompletion tests that the next occurrence follows the completion day
+func (suite *TodoServiceTestSuite) TestUpdateTodo_RepeatFromCompletion() {
+	// Arrange
+	todoID := uint(1)
+	dueDate := "2020-01-01T18:00:00Z"
+	existingTodo := &models.Todo{ID: todoID, UserID: uint64(suite.userID), Title: "Water the plants"}
+	req := &models.UpdateTodoRequest{
+		Title: "Water the plants", DueDate: &dueDate, Completed: true,
+		Recurrence: "FREQ=DAILY;INTERVAL=3", RepeatFrom: models.RepeatFromCompletion,
+	}
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		// Three days after the completion day, at the time of day of the due date
+		completed := todo.UpdatedAt
+		expected := time.Date(completed.Year(), completed.Month(), completed.Day()+3, 18, 0, 0, 0, time.UTC)
+		return todo.NextOccurrence != nil && todo.NextOccurrence.DueDate.Equal(expected)
+	})).Return(&models.Todo{ID: todoID, Completed: true}, nil)
+
+	// Act
+	_, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, req, false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestUpdateTodo_RecurrenceCountReached tests that the last occurrence of a series has no successor
+func (suite *TodoServiceTestSuite) TestUpdateTodo_RecurrenceCountReached() {
+	// Arrange
+	todoID := uint(1)
+	dueDate := "2026-03-31T09:00:00Z"
+	existingTodo := &models.Todo{ID: todoID, UserID: uint64(suite.userID), Title: "Invoice", Occurrence: 3}
+	req := &models.UpdateTodoRequest{Title: "Invoice", DueDate: &dueDate, Completed: true, Recurrence: "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3"}
+
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, todoID).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, todoID, mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.Completed && todo.NextOccurrence == nil && todo.Occurrence == 3
+	})).Return(&models.Todo{ID: todoID, Completed: true}, nil)
+
+	// Act
+	_, err := suite.service.UpdateTodo(suite.ctx, suite.userID, todoID, req, false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestCreateTodo_InvalidRecurrence tests that invalid rules are rejected before reaching the repository
+func (suite *TodoServiceTestSuite) TestCreateTodo_InvalidRecurrence() {
+	// Act
+	_, err := suite.service.CreateTodo(suite.ctx, suite.userID, &models.CreateTodoRequest{Title: "Test", Recurrence: "FREQ=HOURLY"})
+
+	// Assert
+	assert.ErrorContains(suite.T(), err, "invalid recurrence rule")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+// TestSnoozeTodo_Preset tests that presets are resolved in the user's time zone
+func (suite *TodoServiceTestSuite) TestSnoozeTodo_Preset() {
+	// Arrange
+	loc, _ := time.LoadLocation("America/New_York")
+	user := &models.User{ID: uint64(suite.userID), Preferences: models.UserPreferences{Timezone: "America/New_York"}}
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, suite.userID).Return(user, nil)
+	suite.mockRepo.On("Snooze", suite.ctx, suite.userID, uint(1), mock.MatchedBy(func(until *time.Time) bool {
+		local := until.In(loc)
+		return until.Location() == time.UTC &&
+			until.After(time.Now()) && until.Before(time.Now().Add(26*time.Hour)) &&
+			local.Hour() == 0 && local.Minute() == 0
+	})).Return(nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(&models.Todo{ID: 1}, nil)
+
+	// Act
+	_, err := suite.service.SnoozeTodo(suite.ctx, suite.userID, 1, &models.SnoozeTodoRequest{Preset: models.SnoozeTomorrow})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestSnoozeTodo_OneEnd tests that a snooze end is given in exactly one way
+func (suite *TodoServiceTestSuite) TestSnoozeTodo_OneEnd() {
+	// Arrange
+	suite.mockAuthRepo.On("GetUserByID", suite.ctx, suite.userID).Return(&models.User{ID: uint64(suite.userID)}, nil)
+
+	// Act
+	_, errNone := suite.service.SnoozeTodo(suite.ctx, suite.userID, 1, &models.SnoozeTodoRequest{})
+	_, errBoth := suite.service.SnoozeTodo(suite.ctx, suite.userID, 1, &models.SnoozeTodoRequest{Duration: "2h", Preset: models.SnoozeTomorrow})
+
+	// Assert
+	assert.EqualError(suite.T(), errNone, "either until, duration or preset is required")
+	assert.EqualError(suite.T(), errBoth, "either until, duration or preset is required")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Snooze", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUnsnoozeTodo_Success tests that unsnoozing clears the snooze
+func (suite *TodoServiceTestSuite) TestUnsnoozeTodo_Success() {
+	// Arrange
+	suite.mockRepo.On("Snooze", suite.ctx, suite.userID, uint(1), (*time.Time)(nil)).Return(nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(&models.Todo{ID: 1}, nil)
+
+	// Act
+	_, err := suite.service.UnsnoozeTodo(suite.ctx, suite.userID, 1)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+func TestResolveSnooze(t *testing.T) {
+	// Friday 2026-03-06 15:00 in Berlin
+	now := time.Date(2026, time.March, 6, 14, 0, 0, 0, time.UTC)
+	berlin := models.UserPreferences{Timezone: "Europe/Berlin", WeekStart: "monday"}
+	until := "2026-03-10T08:00:00+01:00"
+	past := "2026-03-01T08:00:00Z"
+
+	tests := []struct {
+		name  string
+		req   models.SnoozeTodoRequest
+		prefs models.UserPreferences
+		want  time.Time
+		err   string
+	}{
+		{"until", models.SnoozeTodoRequest{Until: &until}, berlin, time.Date(2026, time.March, 10, 7, 0, 0, 0, time.UTC), ""},
+		{"hours", models.SnoozeTodoRequest{Duration: "3h"}, berlin, now.Add(3 * time.Hour), ""},
+		{"days", models.SnoozeTodoRequest{Duration: "2d"}, berlin, now.Add(48 * time.Hour), ""},
+		{"tomorrow", models.SnoozeTodoRequest{Preset: models.SnoozeTomorrow}, berlin, time.Date(2026, time.March, 6, 23, 0, 0, 0, time.UTC), ""},
+		{"weekend", models.SnoozeTodoRequest{Preset: models.SnoozeWeekend}, berlin, time.Date(2026, time.March, 6, 23, 0, 0, 0, time.UTC), ""},
+		{"next week", models.SnoozeTodoRequest{Preset: models.SnoozeNextWeek}, berlin, time.Date(2026, time.March, 8, 23, 0, 0, 0, time.UTC), ""},
+		{"next week from sunday", models.SnoozeTodoRequest{Preset: models.SnoozeNextWeek}, models.UserPreferences{WeekStart: "sunday"}, time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC), ""},
+		{"next month", models.SnoozeTodoRequest{Preset: models.SnoozeNextMonth}, berlin, time.Date(2026, time.March, 31, 22, 0, 0, 0, time.UTC), ""},
+		{"past", models.SnoozeTodoRequest{Until: &past}, berlin, time.Time{}, "snooze must end in the future"},
+		{"too long", models.SnoozeTodoRequest{Duration: "60w"}, berlin, time.Time{}, "snooze must end within a year"},
+		{"bad duration", models.SnoozeTodoRequest{Duration: "-2h"}, berlin, time.Time{}, "invalid snooze duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSnooze(&tt.req, now, tt.prefs)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+}
+
+// TestCreateTodo_InitialStatus tests that new todos get the first status of their workflow
+func (suite *TodoServiceTestSuite) TestCreateTodo_InitialStatus() {
+	// Arrange
+	suite.useWorkflow(&models.Workflow{Statuses: []models.WorkflowStatus{
+		{Key: "doing", Name: "Doing", Category: models.StatusCategoryInProgress, Transitions: []string{"shipped"}},
+		{Key: "shipped", Name: "Shipped", Category: models.StatusCategoryDone},
+	}})
+	suite.mockRepo.On("Create", suite.ctx, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.Status == "doing" && todo.StartedAt != nil && !todo.Completed
+	})).Return(&models.Todo{ID: 1}, nil)
+
+	// Act
+	_, err := suite.service.CreateTodo(suite.ctx, suite.userID, &models.CreateTodoRequest{Title: "Test"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestUpdateTodo_CompletedMovesStatus tests that setting completed moves the todo to a done status and back
+func (suite *TodoServiceTestSuite) TestUpdateTodo_CompletedMovesStatus() {
+	// Arrange
+	completedAt := time.Now().Add(-time.Hour)
+	openTodo := &models.Todo{ID: 1, Title: "Open", Status: models.StatusWaiting}
+	doneTodo := &models.Todo{ID: 2, Title: "Done", Status: models.StatusDone, Completed: true, CompletedAt: &completedAt}
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(openTodo, nil)
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(2)).Return(doneTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, uint(1), models.StatusWaiting, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.Status == models.StatusDone && todo.Completed && todo.CompletedAt != nil
+	})).Return(&models.Todo{ID: 1}, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, uint(2), models.StatusDone, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.Status == models.StatusTodo && !todo.Completed && todo.CompletedAt == nil
+	})).Return(&models.Todo{ID: 2}, nil)
+
+	// Act
+	_, errComplete := suite.service.UpdateTodo(suite.ctx, suite.userID, 1, &models.UpdateTodoRequest{Title: "Open", Completed: true}, false)
+	_, errReopen := suite.service.UpdateTodo(suite.ctx, suite.userID, 2, &models.UpdateTodoRequest{Title: "Done"}, false)
+
+	// Assert
+	assert.NoError(suite.T(), errComplete)
+	assert.NoError(suite.T(), errReopen)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestTransitionTodo_Success tests that a transition sets the status and its timestamps
+func (suite *TodoServiceTestSuite) TestTransitionTodo_Success() {
+	// Arrange
+	existingTodo := &models.Todo{ID: 1, Title: "Write docs", Status: models.StatusTodo}
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, uint(1), models.StatusTodo, mock.MatchedBy(func(todo *models.Todo) bool {
+		return todo.Title == "Write docs" &&
+			todo.Status == models.StatusBlocked &&
+			todo.StartedAt != nil && !todo.Completed &&
+			todo.CompletedAt == nil && todo.CancelledAt == nil
+	})).Return(&models.Todo{ID: 1, Status: models.StatusBlocked}, nil)
+
+	// Act
+	result, err := suite.service.TransitionTodo(suite.ctx, suite.userID, 1, &models.TransitionTodoRequest{Status: models.StatusBlocked}, false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), models.StatusBlocked, result.Status)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
+// TestTransitionTodo_StatusChanged tests that a transition fails when a concurrent update changed the status first
+func (suite *TodoServiceTestSuite) TestTransitionTodo_StatusChanged() {
+	// Arrange
+	existingTodo := &models.Todo{ID: 1, Title: "Write docs", Status: models.StatusTodo}
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, uint(1), models.StatusTodo, mock.Anything).
+		Return(nil, errors.New("todo status changed"))
+
+	// Act
+	_, err := suite.service.TransitionTodo(suite.ctx, suite.userID, 1, &models.TransitionTodoRequest{Status: models.StatusDone}, false)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "todo status changed")
+}
+
+// TestTransitionTodo_NotAllowed tests that moves the workflow does not list are rejected
+func (suite *TodoServiceTestSuite) TestTransitionTodo_NotAllowed() {
+	// Arrange
+	cancelledAt := time.Now()
+	existingTodo := &models.Todo{ID: 1, Title: "Dropped", Status: models.StatusCancelled, CancelledAt: &cancelledAt}
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(existingTodo, nil)
+
+	// Act
+	_, errMove := suite.service.TransitionTodo(suite.ctx, suite.userID, 1, &models.TransitionTodoRequest{Status: models.StatusDone}, false)
+	_, errUnknown := suite.service.TransitionTodo(suite.ctx, suite.userID, 1, &models.TransitionTodoRequest{Status: "archived"}, false)
+
+	// Assert
+	assert.EqualError(suite.T(), errMove, "transition not allowed")
+	assert.EqualError(suite.T(), errUnknown, "unknown status")
+	suite.mockRepo.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestTransitionTodo_CompleteRecurringTodo tests that the next occurrence starts in the first status
+func (suite *TodoServiceTestSuite) TestTransitionTodo_CompleteRecurringTodo() {
+	// Arrange
+	dueDate := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+	existingTodo := &models.Todo{
+		ID: 1, UserID: uint64(suite.userID), Title: "Weekly report", DueDate: &dueDate, Status: models.StatusInProgress,
+		Recurrence: "FREQ=WEEKLY;BYDAY=MO", RepeatFrom: models.RepeatFromDue, RecurrenceStart: &dueDate, Occurrence: 1,
+	}
+	suite.mockRepo.On("GetByID", suite.ctx, suite.userID, uint(1)).Return(existingTodo, nil)
+	suite.mockRepo.On("Update", suite.ctx, suite.userID, uint(1), mock.Anything, mock.MatchedBy(func(todo *models.Todo) bool {
+		next := todo.NextOccurrence
+		return todo.Completed && todo.Status == models.StatusDone &&
+			next != nil && next.Status == models.StatusTodo && !next.Completed && next.StartedAt == nil
+	})).Return(&models.Todo{ID: 1, Completed: true}, nil)
+
+	// Act
+	_, err := suite.service.TransitionTodo(suite.ctx, suite.userID, 1, &models.TransitionTodoRequest{Status: models.StatusDone}, false)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRepo.AssertExpectations(suite.T())
+}
+
 // TestTodoServiceSuite runs the test suite
 func TestTodoServiceSuite(t *testing.T) {
 	suite.Run(t, new(TodoServiceTestSuite))
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"todo-list-api/internal/models"
+)
+
+// WorkflowService defines the interface for workflow business logic operations.
+// A nil projectID is the workflow of the user, otherwise that of one of their
+// projects.
+type WorkflowService interface {
+	// GetWorkflow returns the workflow the todos of the user or project follow:
+	// the project's own, else the user's, else the default workflow
+	GetWorkflow(ctx context.Context, userID uint, projectID *uint) (*models.Workflow, error)
+	SetWorkflow(ctx context.Context, userID uint, projectID *uint, req *models.SetWorkflowRequest) (*models.Workflow, error)
+	// DeleteWorkflow makes the user or project fall back to the workflow it inherits
+	DeleteWorkflow(ctx context.Context, userID uint, projectID *uint) error
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/repository"
+)
+
+// statusKeyPattern is the format of workflow status keys
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type workflowServiceImpl struct {
+	workflowRepo repository.WorkflowRepository
+	projectRepo  repository.ProjectRepository
+}
+
+// NewWorkflowService creates a new instance of WorkflowService
+func NewWorkflowService(workflowRepo repository.WorkflowRepository, projectRepo repository.ProjectRepository) WorkflowService {
+	return &workflowServiceImpl{
+		workflowRepo: workflowRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+func (s *workflowServiceImpl) GetWorkflow(ctx context.Context, userID uint, projectID *uint) (*models.Workflow, error) {
+	if err := s.checkOwner(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	return resolveWorkflow(ctx, s.workflowRepo, userID, projectID)
+}
+
+func (s *workflowServiceImpl) SetWorkflow(ctx context.Context, userID uint, projectID *uint, req *models.SetWorkflowRequest) (*models.Workflow, error) {
+	if err := s.checkOwner(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	statuses, err := normalizeWorkflowStatuses(req.Statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return s.workflowRepo.Save(ctx, &models.Workflow{
+		UserID:    uint64(userID),
+		ProjectID: projectID,
+		Statuses:  statuses,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+func (s *workflowServiceImpl) DeleteWorkflow(ctx context.Context, userID uint, projectID *uint) error {
+	if err := s.checkOwner(ctx, userID, projectID); err != nil {
+		return err
+	}
+
+	return s.workflowRepo.Delete(ctx, userID, projectID)
+}
+
+// checkOwner checks the user ID and that a project given belongs to the user
+func (s *workflowServiceImpl) checkOwner(ctx context.Context, userID uint, projectID *uint) error {
+	if userID == 0 {
+		return errors.New("invalid user ID")
+	}
+
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, userID, *projectID)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return errors.New("project not found")
+	}
+	return nil
+}
+
+// resolveWorkflow returns the workflow the todos of a project, or of the
+// Inbox when projectID is nil, follow
+func resolveWorkflow(ctx context.Context, workflowRepo repository.WorkflowRepository, userID uint, projectID *uint) (*models.Workflow, error) {
+	if projectID != nil {
+		workflow, err := workflowRepo.Get(ctx, userID, projectID)
+		if err != nil || workflow != nil {
+			return workflow, err
+		}
+	}
+
+	workflow, err := workflowRepo.Get(ctx, userID, nil)
+	if err != nil || workflow != nil {
+		return workflow, err
+	}
+	return models.DefaultWorkflow(), nil
+}
+
+// normalizeWorkflowStatuses trims the statuses of a workflow and checks that
+// they form a usable workflow: unique keys, transitions to known statuses, an
+// open first status for new todos and a done status for completing todos
+func normalizeWorkflowStatuses(statuses []models.WorkflowStatus) ([]models.WorkflowStatus, error) {
+	if len(statuses) == 0 || len(statuses) > models.MaxWorkflowStatuses {
+		return nil, fmt.Errorf("invalid workflow: between 1 and %d statuses are required", models.MaxWorkflowStatuses)
+	}
+
+	normalized := make([]models.WorkflowStatus, 0, len(statuses))
+	keys := make(map[string]bool, len(statuses))
+	hasDone := false
+	for _, status := range statuses {
+		status.Key = strings.TrimSpace(status.Key)
+		status.Name = strings.TrimSpace(status.Name)
+		if !statusKeyPattern.MatchString(status.Key) {
+			return nil, fmt.Errorf("invalid workflow: status key %q must be lowercase letters, digits and underscores", status.Key)
+		}
+		if keys[status.Key] {
+			return nil, fmt.Errorf("invalid workflow: duplicate status %q", status.Key)
+		}
+		keys[status.Key] = true
+		if status.Name == "" {
+			return nil, fmt.Errorf("invalid workflow: status %q needs a name", status.Key)
+		}
+		switch status.Category {
+		case models.StatusCategoryTodo, models.StatusCategoryInProgress, models.StatusCategoryDone, models.StatusCategoryCancelled:
+		default:
+			return nil, fmt.Errorf("invalid workflow: status %q has an unknown category", status.Key)
+		}
+		hasDone = hasDone || status.Category == models.StatusCategoryDone
+		normalized = append(normalized, status)
+	}
+
+	switch normalized[0].Category {
+	case models.StatusCategoryDone, models.StatusCategoryCancelled:
+		return nil, errors.New("invalid workflow: the first status must not be done or cancelled")
+	}
+	if !hasDone {
+		return nil, errors.New("invalid workflow: a status in the done category is required")
+	}
+
+	for i, status := range normalized {
+		transitions := make([]string, 0, len(status.Transitions))
+		for _, key := range status.Transitions {
+			key = strings.TrimSpace(key)
+			if !keys[key] {
+				return nil, fmt.Errorf("invalid workflow: status %q has a transition to unknown status %q", status.Key, key)
+			}
+			if key != status.Key && !slices.Contains(transitions, key) {
+				transitions = append(transitions, key)
+			}
+		}
+		normalized[i].Transitions = transitions
+	}
+	return normalized, nil
+}
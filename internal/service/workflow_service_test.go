@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"todo-list-api/internal/models"
+	"todo-list-api/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkflowServiceTestSuite struct {
+	suite.Suite
+	mockWorkflowRepo *mocks.MockWorkflowRepository
+	mockProjectRepo  *mocks.MockProjectRepository
+	service          WorkflowService
+	ctx              context.Context
+	userID           uint
+}
+
+func (suite *WorkflowServiceTestSuite) SetupTest() {
+	suite.mockWorkflowRepo = new(mocks.MockWorkflowRepository)
+	suite.mockProjectRepo = new(mocks.MockProjectRepository)
+	suite.service = NewWorkflowService(suite.mockWorkflowRepo, suite.mockProjectRepo)
+	suite.ctx = context.Background()
+	suite.userID = 1
+}
+
+// TestGetWorkflow_FallsBack tests that a project without a workflow follows the user's, then the default one
+func (suite *WorkflowServiceTestSuite) TestGetWorkflow_FallsBack() {
+	// Arrange
+	projectID := uint(3)
+	userWorkflow := &models.Workflow{ID: 7, Statuses: []models.WorkflowStatus{{Key: "open", Category: models.StatusCategoryTodo}}}
+	suite.mockProjectRepo.On("GetByID", suite.ctx, suite.userID, projectID).Return(&models.Project{ID: projectID}, nil)
+	suite.mockWorkflowRepo.On("Get", suite.ctx, suite.userID, &projectID).Return(nil, nil)
+	suite.mockWorkflowRepo.On("Get", suite.ctx, suite.userID, (*uint)(nil)).Return(userWorkflow, nil).Once()
+	suite.mockWorkflowRepo.On("Get", suite.ctx, suite.userID, (*uint)(nil)).Return(nil, nil).Once()
+
+	// Act
+	inherited, errInherited := suite.service.GetWorkflow(suite.ctx, suite.userID, &projectID)
+	fallback, errFallback := suite.service.GetWorkflow(suite.ctx, suite.userID, nil)
+
+	// Assert
+	assert.NoError(suite.T(), errInherited)
+	assert.Equal(suite.T(), userWorkflow, inherited)
+	assert.NoError(suite.T(), errFallback)
+	assert.Equal(suite.T(), models.DefaultWorkflow(), fallback)
+}
+
+// TestGetWorkflow_ProjectNotFound tests that workflows of other users' projects are not reachable
+func (suite *WorkflowServiceTestSuite) TestGetWorkflow_ProjectNotFound() {
+	// Arrange
+	projectID := uint(3)
+	suite.mockProjectRepo.On("GetByID", suite.ctx, suite.userID, projectID).Return(nil, nil)
+
+	// Act
+	_, err := suite.service.GetWorkflow(suite.ctx, suite.userID, &projectID)
+
+	// Assert
+	assert.EqualError(suite.T(), err, "project not found")
+	suite.mockWorkflowRepo.AssertNotCalled(suite.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestSetWorkflow_Normalizes tests that statuses are trimmed and their transitions deduplicated
+func (suite *WorkflowServiceTestSuite) TestSetWorkflow_Normalizes() {
+	// Arrange
+	suite.mockWorkflowRepo.On("Save", suite.ctx, mock.MatchedBy(func(workflow *models.Workflow) bool {
+		return workflow.UserID == uint64(suite.userID) && workflow.ProjectID == nil &&
+			len(workflow.Statuses) == 2 &&
+			workflow.Statuses[0].Key == "open" && workflow.Statuses[0].Name == "Open" &&
+			assert.ObjectsAreEqual([]string{"closed"}, workflow.Statuses[0].Transitions) &&
+			assert.ObjectsAreEqual([]string{"open"}, workflow.Statuses[1].Transitions)
+	})).Return(&models.Workflow{ID: 1}, nil)
+
+	// Act
+	_, err := suite.service.SetWorkflow(suite.ctx, suite.userID, nil, &models.SetWorkflowRequest{Statuses: []models.WorkflowStatus{
+		{Key: " open ", Name: " Open ", Category: models.StatusCategoryTodo, Transitions: []string{"closed", " closed", "open"}},
+		{Key: "closed", Name: "Closed", Category: models.StatusCategoryDone, Transitions: []string{"open"}},
+	}})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockWorkflowRepo.AssertExpectations(suite.T())
+}
+
+// TestSetWorkflow_Invalid tests that unusable workflows are rejected
+func (suite *WorkflowServiceTestSuite) TestSetWorkflow_Invalid() {
+	open := models.WorkflowStatus{Key: "open", Name: "Open", Category: models.StatusCategoryTodo}
+	done := models.WorkflowStatus{Key: "done", Name: "Done", Category: models.StatusCategoryDone}
+	tests := []struct {
+		name     string
+		statuses []models.WorkflowStatus
+		err      string
+	}{
+		{"no statuses", nil, "invalid workflow: between 1 and 20 statuses are required"},
+		{"bad key", []models.WorkflowStatus{{Key: "In Progress", Name: "In progress", Category: models.StatusCategoryInProgress}, done},
+			`invalid workflow: status key "In Progress" must be lowercase letters, digits and underscores`},
+		{"duplicate key", []models.WorkflowStatus{open, open, done}, `invalid workflow: duplicate status "open"`},
+		{"first status closed", []models.WorkflowStatus{done, open}, "invalid workflow: the first status must not be done or cancelled"},
+		{"no done status", []models.WorkflowStatus{open}, "invalid workflow: a status in the done category is required"},
+		{"unknown transition", []models.WorkflowStatus{{Key: "open", Name: "Open", Category: models.StatusCategoryTodo, Transitions: []string{"review"}}, done},
+			`invalid workflow: status "open" has a transition to unknown status "review"`},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			_, err := suite.service.SetWorkflow(suite.ctx, suite.userID, nil, &models.SetWorkflowRequest{Statuses: tt.statuses})
+			assert.EqualError(suite.T(), err, tt.err)
+		})
+	}
+	suite.mockWorkflowRepo.AssertNotCalled(suite.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func TestWorkflowServiceSuite(t *testing.T) {
+	suite.Run(t, new(WorkflowServiceTestSuite))
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeCursor serializes a pagination cursor into an opaque URL-safe string
+func EncodeCursor(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor into v
+func DecodeCursor(cursor string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
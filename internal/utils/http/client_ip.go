@@ -0,0 +1,110 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type clientIPKey struct{}
+
+// TrustedProxies are the networks of the reverse proxies in front of the API.
+// Only they are trusted to report the client address in X-Forwarded-For.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a comma-separated list of IP addresses and CIDR
+// ranges such as "10.0.0.0/8,192.168.1.10"
+func ParseTrustedProxies(value string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q", entry)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// Contains reports whether ip is the address of a trusted proxy
+func (p TrustedProxies) Contains(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP address of the client that sent the request. It is
+// the peer address, unless the peer is a trusted proxy: then it is the last
+// X-Forwarded-For hop that was not added by a trusted proxy, since the hops
+// before it are whatever the client sent.
+func (p TrustedProxies) ClientIP(r *http.Request) string {
+	peer := remoteIP(r)
+	ip := net.ParseIP(peer)
+	if ip == nil || !p.Contains(ip) {
+		return peer
+	}
+
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// Nothing left of a malformed hop can be trusted
+			break
+		}
+		ip = hop
+		if !p.Contains(hop) {
+			break
+		}
+	}
+	return ip.String()
+}
+
+// WithClientIP returns r with the client IP address the rest of the request
+// handling sees
+func WithClientIP(r *http.Request, ip string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip))
+}
+
+// ClientIP returns the IP address of the client that sent the request, as
+// resolved by WithClientIP, or the peer address
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+// remoteIP returns the host of the peer address of the request
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
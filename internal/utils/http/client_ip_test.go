@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedProxies_ClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.0/8, 192.168.1.10")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{"untrusted peer", "203.0.113.7:4000", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"trusted peer", "10.0.0.2:4000", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"spoofed hops", "10.0.0.2:4000", []string{"1.2.3.4, 198.51.100.1"}, "198.51.100.1"},
+		{"proxy chain", "10.0.0.2:4000", []string{"1.2.3.4, 198.51.100.1, 192.168.1.10", "10.1.1.1"}, "198.51.100.1"},
+		{"malformed hop", "10.0.0.2:4000", []string{"198.51.100.1, not-an-ip, 10.1.1.1"}, "10.1.1.1"},
+		{"no header", "10.0.0.2:4000", nil, "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.peer
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			r.Header.Set("X-Real-IP", "1.1.1.1")
+
+			assert.Equal(t, tt.want, proxies.ClientIP(r))
+		})
+	}
+}
+
+func TestClientIP_IgnoresHeadersWithoutProxies(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.RemoteAddr = "203.0.113.7:4000"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4")
+
+	assert.Equal(t, "203.0.113.7", ClientIP(r))
+	assert.Equal(t, "1.2.3.4", ClientIP(WithClientIP(r, "1.2.3.4")))
+}
+
+func TestParseTrustedProxies_Invalid(t *testing.T) {
+	_, err := ParseTrustedProxies("10.0.0.0/8,proxy.internal")
+	assert.EqualError(t, err, `invalid trusted proxy "proxy.internal"`)
+}
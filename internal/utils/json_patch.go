@@ -0,0 +1,354 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrPatchTestFailed is returned when a JSON Patch "test" operation does not match
+var ErrPatchTestFailed = errors.New("patch test failed")
+
+// MergePatch applies an RFC 7396 JSON Merge Patch to a JSON document.
+// Members set to null in the patch are removed from the document, objects are
+// merged recursively and any other value replaces the target.
+func MergePatch(document, patch []byte) ([]byte, error) {
+	var doc, p any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	return json.Marshal(mergeValue(doc, p))
+}
+
+func mergeValue(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// jsonPatchOperation is a single RFC 6902 operation. Value is kept raw so a
+// missing value can be told apart from an explicit null.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies an RFC 6902 JSON Patch to a JSON document.
+// Operations are applied in order and the whole patch fails if any one does.
+func ApplyJSONPatch(document, patch []byte) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	var operations []jsonPatchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("invalid json patch: %w", err)
+	}
+
+	for i, operation := range operations {
+		var err error
+		doc, err = applyOperation(doc, operation)
+		if err != nil {
+			if errors.Is(err, ErrPatchTestFailed) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("operation %d (%s): %w", i, operation.Op, err)
+		}
+	}
+
+	return json.Marshal(doc)
+}
+
+func applyOperation(doc any, operation jsonPatchOperation) (any, error) {
+	if operation.Path == nil {
+		return nil, errors.New("missing path")
+	}
+	path, err := parseJSONPointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add":
+		value, err := operationValue(operation)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+
+	case "remove":
+		doc, _, err := removeValue(doc, path)
+		return doc, err
+
+	case "replace":
+		value, err := operationValue(operation)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 {
+			return value, nil
+		}
+		doc, _, err = removeValue(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+
+	case "move", "copy":
+		if operation.From == nil {
+			return nil, errors.New("missing from")
+		}
+		from, err := parseJSONPointer(*operation.From)
+		if err != nil {
+			return nil, err
+		}
+
+		if operation.Op == "copy" {
+			value, err := getValue(doc, from)
+			if err != nil {
+				return nil, err
+			}
+			return addValue(doc, path, deepCopy(value))
+		}
+
+		if isPointerPrefix(from, path) && len(from) < len(path) {
+			return nil, errors.New("cannot move a value into one of its children")
+		}
+		doc, value, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+
+	case "test":
+		expected, err := operationValue(operation)
+		if err != nil {
+			return nil, err
+		}
+		actual, err := getValue(doc, path)
+		if err != nil || !reflect.DeepEqual(actual, expected) {
+			return nil, ErrPatchTestFailed
+		}
+		return doc, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", operation.Op)
+	}
+}
+
+func operationValue(operation jsonPatchOperation) (any, error) {
+	if operation.Value == nil {
+		return nil, errors.New("missing value")
+	}
+	var value any
+	if err := json.Unmarshal(operation.Value, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// parseJSONPointer splits an RFC 6901 JSON Pointer into unescaped reference tokens
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPointerPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex parses an array reference token, accepting indexes up to max
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return index, nil
+}
+
+func getValue(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("path %q not found", token)
+			}
+			node = child
+		case []any:
+			index, err := arrayIndex(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[index]
+		default:
+			return nil, fmt.Errorf("path %q not found", token)
+		}
+	}
+	return node, nil
+}
+
+// addValue returns node with value added at path. Containers are updated in
+// place where possible, but callers must use the returned node since slices
+// may be reallocated.
+func addValue(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token := path[0]
+	switch n := node.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			n[token] = value
+			return n, nil
+		}
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("path %q not found", token)
+		}
+		updated, err := addValue(child, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = updated
+		return n, nil
+
+	case []any:
+		if len(path) == 1 {
+			index := len(n)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(n)); err != nil {
+					return nil, err
+				}
+			}
+			n = append(n, nil)
+			copy(n[index+1:], n[index:])
+			n[index] = value
+			return n, nil
+		}
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := addValue(n[index], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = updated
+		return n, nil
+
+	default:
+		return nil, fmt.Errorf("path %q not found", token)
+	}
+}
+
+// removeValue returns node without the value at path, and the removed value
+func removeValue(node any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the document root")
+	}
+
+	token := path[0]
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("path %q not found", token)
+		}
+		if len(path) == 1 {
+			delete(n, token)
+			return n, child, nil
+		}
+		updated, removed, err := removeValue(child, path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		n[token] = updated
+		return n, removed, nil
+
+	case []any:
+		index, err := arrayIndex(token, len(n)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(path) == 1 {
+			removed := n[index]
+			return append(n[:index], n[index+1:]...), removed, nil
+		}
+		updated, removed, err := removeValue(n[index], path[1:])
+		if err != nil {
+			return nil, nil, err
+		}
+		n[index] = updated
+		return n, removed, nil
+
+	default:
+		return nil, nil, fmt.Errorf("path %q not found", token)
+	}
+}
+
+func deepCopy(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(v))
+		for key, child := range v {
+			copied[key] = deepCopy(child)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(v))
+		for i, child := range v {
+			copied[i] = deepCopy(child)
+		}
+		return copied
+	default:
+		return v
+	}
+}
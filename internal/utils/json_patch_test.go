@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null removes member", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"absent member untouched", `{"a":"b","c":true}`, `{}`, `{"a":"b","c":true}`},
+		{"false is kept", `{"completed":true}`, `{"completed":false}`, `{"completed":false}`},
+		{"nested merge", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":null,"f":"g"}}`, `{"a":{"d":"e","f":"g"}}`},
+		{"array replaced", `{"a":[1,2]}`, `{"a":[3]}`, `{"a":[3]}`},
+		{"non-object patch replaces", `{"a":"b"}`, `["c"]`, `["c"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := MergePatch([]byte(tt.document), []byte(tt.patch))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(result))
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{"add member", `{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+		{"add array element", `{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{"append array element", `{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":"qux"}]`, `{"foo":["bar","qux"]}`},
+		{"remove member", `{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{"replace with null", `{"dueDate":"2030-01-01T00:00:00Z"}`, `[{"op":"replace","path":"/dueDate","value":null}]`, `{"dueDate":null}`},
+		{"move member", `{"foo":{"bar":"baz"},"qux":{}}`, `[{"op":"move","from":"/foo/bar","path":"/qux/thud"}]`, `{"foo":{},"qux":{"thud":"baz"}}`},
+		{"copy member", `{"foo":{"bar":1}}`, `[{"op":"copy","from":"/foo","path":"/baz"}]`, `{"foo":{"bar":1},"baz":{"bar":1}}`},
+		{"escaped pointer", `{"a/b":1,"m~n":2}`, `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/m~0n"}]`, `{}`},
+		{"test then replace", `{"completed":true}`, `[{"op":"test","path":"/completed","value":true},{"op":"replace","path":"/completed","value":false}]`, `{"completed":false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyJSONPatch([]byte(tt.document), []byte(tt.patch))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(result))
+		})
+	}
+}
+
+func TestApplyJSONPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{"missing path", `[{"op":"add","value":1}]`},
+		{"missing value", `[{"op":"add","path":"/a"}]`},
+		{"remove missing member", `[{"op":"remove","path":"/missing"}]`},
+		{"replace missing member", `[{"op":"replace","path":"/missing","value":1}]`},
+		{"unknown operation", `[{"op":"frobnicate","path":"/a"}]`},
+		{"invalid pointer", `[{"op":"add","path":"a","value":1}]`},
+		{"not an array", `{"op":"add","path":"/a","value":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ApplyJSONPatch([]byte(`{"a":"b"}`), []byte(tt.patch))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestApplyJSONPatch_TestFailure(t *testing.T) {
+	_, err := ApplyJSONPatch([]byte(`{"a":"b"}`), []byte(`[{"op":"test","path":"/a","value":"c"}]`))
+	assert.True(t, errors.Is(err, ErrPatchTestFailed))
+}
@@ -9,8 +9,27 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Token lifetimes
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+	// MFATokenTTL is how long a user has to enter their second factor after the password
+	MFATokenTTL = 5 * time.Minute
+)
+
+// Authentication methods, as used in the amr claim (RFC 8176)
+const (
+	AuthMethodPassword = "pwd"
+	AuthMethodOTP      = "otp"
+)
+
+// JWT issues and validates tokens. When Keys is set, tokens are signed with
+// its signing key and carry the key ID in the kid header; otherwise they are
+// signed with Secret (HS256). Tokens without kid are accepted as long as
+// Secret is set, so HS256 tokens keep working while migrating to Keys.
 type JWT struct {
 	Secret string
+	Keys   *KeySet
 }
 
 // Custom Claims structure for better JWT handling
@@ -18,20 +37,63 @@ type Claims struct {
 	UserID    uint64 `json:"user_id"`
 	UserEmail string `json:"user_email"`
 	TokenType string `json:"token_type"` // "access" or "refresh"
+	SessionID string `json:"sid,omitempty"`
+	// EmailVerified tells whether the user had verified their email when the token was issued
+	EmailVerified bool `json:"email_verified"`
+	// AuthMethods lists how the user authenticated when the session started,
+	// and AuthTime is when that happened. Refreshing the token keeps both.
+	AuthMethods []string         `json:"amr,omitempty"`
+	AuthTime    *jwt.NumericDate `json:"auth_time,omitempty"`
+	// Role and Permissions are the user's when the token was issued. Changes
+	// apply from the next refresh.
+	Role        string   `json:"role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// HasRole reports whether the token's user has one of roles
+func (c *Claims) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if c.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission reports whether the token's user was granted permission
+func (c *Claims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAuthMethod reports whether the user authenticated with method
+func (c *Claims) HasAuthMethod(method string) bool {
+	for _, m := range c.AuthMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
 // TokenPair represents both access and refresh tokens
 type TokenPair struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int64  `json:"expires_in"` // seconds until access token expires
+
+	RefreshExpiresAt time.Time `json:"-"`
 }
 
-// CreateToken creates a token pair (access + refresh) for the user
-func (j *JWT) CreateToken(user *models.User) (*models.Token, error) {
-	tokenPair, err := j.CreateTokenPair(user)
+// CreateToken creates a token pair (access + refresh) for the user's session
+func (j *JWT) CreateToken(user *models.User, session *models.Session) (*models.Token, error) {
+	tokenPair, err := j.CreateTokenPair(user, session)
 	if err != nil {
 		return nil, err
 	}
@@ -45,17 +107,28 @@ func (j *JWT) CreateToken(user *models.User) (*models.Token, error) {
 	return token, nil
 }
 
-// CreateTokenPair creates both access and refresh tokens
-func (j *JWT) CreateTokenPair(user *models.User) (*TokenPair, error) {
+// CreateTokenPair creates both access and refresh tokens bound to a session
+func (j *JWT) CreateTokenPair(user *models.User, session *models.Session) (*TokenPair, error) {
 	now := time.Now()
-	accessTokenExpiry := now.Add(15 * time.Minute)
-	refreshTokenExpiry := now.Add(7 * 24 * time.Hour) // 7 days
+	accessTokenExpiry := now.Add(AccessTokenTTL)
+	refreshTokenExpiry := now.Add(RefreshTokenTTL)
+
+	var authTime *jwt.NumericDate
+	if !session.AuthenticatedAt.IsZero() {
+		authTime = jwt.NewNumericDate(session.AuthenticatedAt)
+	}
 
 	// Create Access Token
 	accessClaims := &Claims{
-		UserID:    user.ID,
-		UserEmail: user.Email,
-		TokenType: "access",
+		UserID:        user.ID,
+		UserEmail:     user.Email,
+		TokenType:     "access",
+		SessionID:     session.ID,
+		EmailVerified: user.EmailVerified,
+		AuthMethods:   session.AuthMethodList(),
+		AuthTime:      authTime,
+		Role:          user.Role,
+		Permissions:   models.PermissionsForRole(user.Role),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(accessTokenExpiry),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -65,49 +138,75 @@ func (j *JWT) CreateTokenPair(user *models.User) (*TokenPair, error) {
 		},
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString([]byte(j.Secret))
+	accessTokenString, err := j.sign(accessClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign access token: %w", err)
 	}
 
-	// Create Refresh Token
+	// Create Refresh Token. The random ID makes every refresh token unique, so
+	// each one can be tracked and revoked individually.
+	refreshTokenID, err := GenerateRandomToken(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token ID: %w", err)
+	}
+
 	refreshClaims := &Claims{
 		UserID:    user.ID,
 		UserEmail: user.Email,
 		TokenType: "refresh",
+		SessionID: session.ID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(refreshTokenExpiry),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "todo-list-api",
 			Subject:   fmt.Sprintf("user:%d", user.ID),
+			ID:        refreshTokenID,
 		},
 	}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString([]byte(j.Secret))
+	refreshTokenString, err := j.sign(refreshClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
 
 	return &TokenPair{
-		AccessToken:  accessTokenString,
-		RefreshToken: refreshTokenString,
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(accessTokenExpiry.Sub(now).Seconds()),
+		AccessToken:      accessTokenString,
+		RefreshToken:     refreshTokenString,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(accessTokenExpiry.Sub(now).Seconds()),
+		RefreshExpiresAt: refreshTokenExpiry,
 	}, nil
 }
 
+// CreateMFAToken creates the short-lived token proving that the user passed
+// the password step of a login that still requires a second factor
+func (j *JWT) CreateMFAToken(user *models.User) (string, error) {
+	now := time.Now()
+	claims := &Claims{
+		UserID:      user.ID,
+		UserEmail:   user.Email,
+		TokenType:   "mfa",
+		AuthMethods: []string{AuthMethodPassword},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(MFATokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "todo-list-api",
+			Subject:   fmt.Sprintf("user:%d", user.ID),
+		},
+	}
+
+	token, err := j.sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign MFA token: %w", err)
+	}
+	return token, nil
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func (j *JWT) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(j.Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, j.verificationKey)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -160,48 +259,73 @@ func (j *JWT) ValidateRefreshToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
-// RefreshAccessToken creates a new access token using a valid refresh token
-func (j *JWT) RefreshAccessToken(refreshTokenString string) (*TokenPair, error) {
-	claims, err := j.ValidateRefreshToken(refreshTokenString)
+// ValidateMFAToken specifically validates MFA pending tokens
+func (j *JWT) ValidateMFAToken(tokenString string) (*Claims, error) {
+	claims, err := j.ValidateToken(tokenString)
 	if err != nil {
-		return nil, fmt.Errorf("invalid refresh token: %w", err)
+		return nil, err
 	}
 
-	// Create a new access token
-	now := time.Now()
-	accessTokenExpiry := now.Add(15 * time.Minute)
+	if claims.TokenType != "mfa" {
+		return nil, errors.New("token is not an MFA token")
+	}
 
-	newAccessClaims := &Claims{
-		UserID:    claims.UserID,
-		UserEmail: claims.UserEmail,
-		TokenType: "access",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(accessTokenExpiry),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "todo-list-api",
-			Subject:   fmt.Sprintf("user:%d", claims.UserID),
-		},
+	return claims, nil
+}
+
+// JWKS returns the public keys that verify tokens. It is empty when tokens are
+// only signed with Secret, which must never be published.
+func (j *JWT) JWKS() JWKSet {
+	if j.Keys == nil {
+		return JWKSet{Keys: []JWK{}}
 	}
+	return j.Keys.JWKS(time.Now())
+}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, newAccessClaims)
-	accessTokenString, err := accessToken.SignedString([]byte(j.Secret))
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign new access token: %w", err)
+// sign signs claims with the signing key, or with Secret when there is none
+func (j *JWT) sign(claims *Claims) (string, error) {
+	if j.Keys == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.Secret))
 	}
 
-	return &TokenPair{
-		AccessToken:  accessTokenString,
-		RefreshToken: refreshTokenString, // Keep the same refresh token
-		TokenType:    "Bearer",
-		ExpiresIn:    int64(accessTokenExpiry.Sub(now).Seconds()),
-	}, nil
+	key := j.Keys.Signing()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// verificationKey selects the key of a token from its kid header. The token's
+// algorithm must be the one of the key, so a public key can never be used as
+// an HMAC secret.
+func (j *JWT) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, hasKid := token.Header["kid"].(string)
+	if !hasKid {
+		if j.Secret == "" {
+			return nil, errors.New("token has no key ID")
+		}
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(j.Secret), nil
+	}
+
+	if j.Keys == nil {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	key, ok := j.Keys.Lookup(kid, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Public, nil
 }
 
 // validateClaims performs additional validation on claims
 func (j *JWT) validateClaims(claims *Claims) error {
 	// Check if token type is valid
-	if claims.TokenType != "access" && claims.TokenType != "refresh" {
+	if claims.TokenType != "access" && claims.TokenType != "refresh" && claims.TokenType != "mfa" {
 		return errors.New("invalid token type")
 	}
 
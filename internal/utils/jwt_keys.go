@@ -0,0 +1,287 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is an asymmetric key used to sign or verify tokens. Its ID is the
+// RFC 7638 thumbprint of the public key and is sent in the kid token header.
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Private is nil for keys that only verify tokens
+	Private crypto.Signer
+	Public  crypto.PublicKey
+	// RetiredUntil is set for keys that are being phased out: they verify
+	// tokens until then, giving tokens they signed time to expire
+	RetiredUntil *time.Time
+}
+
+// KeySet holds the key that signs new tokens and every key tokens are verified with
+type KeySet struct {
+	signing *SigningKey
+	keys    map[string]*SigningKey
+}
+
+// NewKeySet creates a KeySet. The signing key must hold a private key; the
+// other keys are only used for verification.
+func NewKeySet(signing *SigningKey, others ...*SigningKey) (*KeySet, error) {
+	if signing == nil || signing.Private == nil {
+		return nil, errors.New("signing key must have a private key")
+	}
+
+	set := &KeySet{signing: signing, keys: map[string]*SigningKey{signing.ID: signing}}
+	for _, key := range others {
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key %s", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+	return set, nil
+}
+
+// LoadKeySetFromEnv loads the keys configured by:
+//   - JWT_SIGNING_KEY_FILE: PEM private key that signs new tokens
+//   - JWT_VERIFICATION_KEY_FILES: comma-separated PEM keys (public or private)
+//     that are also accepted, e.g. the keys of other instances during a rollout
+//   - JWT_RETIRED_KEY_FILES: comma-separated "path@RFC3339 time" entries of keys
+//     accepted until the given time
+//
+// It returns nil when no signing key is configured.
+func LoadKeySetFromEnv() (*KeySet, error) {
+	signingFile := os.Getenv("JWT_SIGNING_KEY_FILE")
+	if signingFile == "" {
+		return nil, nil
+	}
+
+	signing, err := LoadSigningKey(signingFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var others []*SigningKey
+	for _, path := range splitList(os.Getenv("JWT_VERIFICATION_KEY_FILES")) {
+		key, err := LoadSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+		others = append(others, key)
+	}
+
+	for _, entry := range splitList(os.Getenv("JWT_RETIRED_KEY_FILES")) {
+		path, until, found := strings.Cut(entry, "@")
+		if !found {
+			return nil, fmt.Errorf("retired key %q must be written as path@time", entry)
+		}
+		retiredUntil, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retirement time of %s: %w", path, err)
+		}
+
+		key, err := LoadSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+		key.RetiredUntil = &retiredUntil
+		others = append(others, key)
+	}
+
+	return NewKeySet(signing, others...)
+}
+
+// LoadSigningKey reads a PEM encoded key. Private keys may be PKCS#8, PKCS#1
+// (RSA) or SEC 1 (EC); public keys must be PKIX.
+func LoadSigningKey(path string) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key, err := ParseSigningKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// ParseSigningKey parses a PEM encoded key, see LoadSigningKey
+func ParseSigningKey(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key: %w", err)
+	}
+
+	key := &SigningKey{}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.Private = signer
+		key.Public = signer.Public()
+	} else {
+		key.Public = parsed
+	}
+
+	switch public := key.Public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < 2048 {
+			return nil, errors.New("RSA keys must have at least 2048 bits")
+		}
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 EC keys are supported")
+		}
+		key.Method = jwt.SigningMethodES256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key.Public)
+	}
+
+	jwk := publicJWK(key.Public)
+	key.ID = jwkThumbprint(jwk)
+	return key, nil
+}
+
+// Signing returns the key that signs new tokens
+func (s *KeySet) Signing() *SigningKey {
+	return s.signing
+}
+
+// Lookup returns the key with the given ID if it may verify tokens at now
+func (s *KeySet) Lookup(kid string, now time.Time) (*SigningKey, bool) {
+	key, ok := s.keys[kid]
+	if !ok || key.expired(now) {
+		return nil, false
+	}
+	return key, true
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that may verify tokens at now, signing key first
+func (s *KeySet) JWKS(now time.Time) JWKSet {
+	set := JWKSet{Keys: []JWK{s.signing.jwk()}}
+	for id, key := range s.keys {
+		if id == s.signing.ID || key.expired(now) {
+			continue
+		}
+		set.Keys = append(set.Keys, key.jwk())
+	}
+	return set
+}
+
+func (k *SigningKey) expired(now time.Time) bool {
+	return k.RetiredUntil != nil && now.After(*k.RetiredUntil)
+}
+
+func (k *SigningKey) jwk() JWK {
+	members := publicJWK(k.Public)
+	return JWK{
+		Kty: members["kty"],
+		Kid: k.ID,
+		Use: "sig",
+		Alg: k.Method.Alg(),
+		Crv: members["crv"],
+		N:   members["n"],
+		E:   members["e"],
+		X:   members["x"],
+		Y:   members["y"],
+	}
+}
+
+// publicJWK returns the required JWK members of a public key
+func publicJWK(public crypto.PublicKey) map[string]string {
+	encode := base64.RawURLEncoding.EncodeToString
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   encode(key.N.Bytes()),
+			"e":   encode(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return map[string]string{
+			"kty": "EC",
+			"crv": key.Curve.Params().Name,
+			"x":   encode(key.X.FillBytes(make([]byte, size))),
+			"y":   encode(key.Y.FillBytes(make([]byte, size))),
+		}
+	case ed25519.PublicKey:
+		return map[string]string{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   encode(key),
+		}
+	}
+	return nil
+}
+
+// jwkThumbprint computes the RFC 7638 thumbprint of the required JWK members.
+// encoding/json sorts map keys and adds no whitespace, as the RFC requires.
+func jwkThumbprint(members map[string]string) string {
+	canonical, _ := json.Marshal(members)
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
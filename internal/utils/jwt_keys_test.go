@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"todo-list-api/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKey writes key as a PKCS#8 PEM file and returns its path
+func writeKey(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	return path
+}
+
+// writePublicKey writes the public half of key as a PKIX PEM file and returns its path
+func writePublicKey(t *testing.T, key crypto.Signer) string {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.pub.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
+
+func newKeyJWT(t *testing.T, signer crypto.Signer, others ...*SigningKey) *JWT {
+	signing, err := LoadSigningKey(writeKey(t, signer))
+	require.NoError(t, err)
+
+	keys, err := NewKeySet(signing, others...)
+	require.NoError(t, err)
+	return &JWT{Keys: keys}
+}
+
+var testUser = &models.User{ID: 1, Email: "user@example.com"}
+
+func TestJWT_AsymmetricAlgorithms(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name string
+		key  crypto.Signer
+		alg  string
+	}{
+		{"RSA", rsaKey, "RS256"},
+		{"ECDSA", ecKey, "ES256"},
+		{"Ed25519", edKey, "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newKeyJWT(t, tt.key)
+
+			pair, err := j.CreateTokenPair(testUser, &models.Session{ID: "session-1"})
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(pair.AccessToken, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.alg, parsed.Header["alg"])
+			assert.Equal(t, j.Keys.Signing().ID, parsed.Header["kid"])
+
+			claims, err := j.ValidateAccessToken(pair.AccessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, testUser.ID, claims.UserID)
+
+			jwks := j.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, tt.alg, jwks.Keys[0].Alg)
+			assert.Equal(t, j.Keys.Signing().ID, jwks.Keys[0].Kid)
+		})
+	}
+}
+
+func TestJWT_RetiredKeyGracePeriod(t *testing.T) {
+	oldKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	newKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	// Tokens signed before the rotation
+	oldJWT := newKeyJWT(t, oldKey)
+	pair, err := oldJWT.CreateTokenPair(testUser, &models.Session{ID: "session-1"})
+	require.NoError(t, err)
+
+	retired, err := LoadSigningKey(writePublicKey(t, oldKey))
+	require.NoError(t, err)
+	assert.Equal(t, oldJWT.Keys.Signing().ID, retired.ID, "the key ID does not depend on the PEM format")
+
+	// Within the grace period the old key still verifies and is published
+	until := time.Now().Add(time.Hour)
+	retired.RetiredUntil = &until
+	rotated := newKeyJWT(t, newKey, retired)
+
+	_, err = rotated.ValidateAccessToken(pair.AccessToken)
+	assert.NoError(t, err)
+	assert.Len(t, rotated.JWKS().Keys, 2)
+
+	// Afterwards it is dropped
+	expired := time.Now().Add(-time.Minute)
+	retired.RetiredUntil = &expired
+
+	_, err = rotated.ValidateAccessToken(pair.AccessToken)
+	assert.Error(t, err)
+	assert.Len(t, rotated.JWKS().Keys, 1)
+}
+
+func TestJWT_HS256Compatibility(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	legacy := &JWT{Secret: "test-secret"}
+	pair, err := legacy.CreateTokenPair(testUser, &models.Session{ID: "session-1"})
+	require.NoError(t, err)
+
+	// HS256 tokens stay valid while the secret is configured next to the keys
+	migrating := newKeyJWT(t, ecKey)
+	migrating.Secret = "test-secret"
+	_, err = migrating.ValidateAccessToken(pair.AccessToken)
+	assert.NoError(t, err)
+
+	// and are rejected once it is removed
+	migrating.Secret = ""
+	_, err = migrating.ValidateAccessToken(pair.AccessToken)
+	assert.Error(t, err)
+
+	// The secret is never published
+	assert.Empty(t, legacy.JWKS().Keys)
+}
+
+func TestJWT_RejectsAlgorithmMismatch(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	j := newKeyJWT(t, ecKey)
+	j.Secret = "test-secret"
+
+	// An HS256 token claiming the kid of the EC key must not be verified with it
+	claims := &Claims{UserID: 1, UserEmail: "user@example.com", TokenType: "access",
+		RegisteredClaims: jwt.RegisteredClaims{Issuer: "todo-list-api", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = j.Keys.Signing().ID
+	forged, err := token.SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	_, err = j.ValidateAccessToken(forged)
+	assert.Error(t, err)
+}
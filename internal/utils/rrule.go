@@ -0,0 +1,569 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence frequencies supported by RRule
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// Recurrence limits. Next gives up on finding an occurrence after checking
+// MaxRRuleDays candidate days, so a series whose periods no longer match any
+// day has no next occurrence.
+const (
+	MaxRRuleCount    = 1000
+	MaxRRuleInterval = 1000
+	MaxRRuleDays     = 50000
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// RRule is an RFC 5545 recurrence rule evaluated in a time zone. It supports
+// FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL, BYDAY,
+// BYMONTHDAY, BYMONTH, BYSETPOS and WKST. Occurrences keep the local time of
+// day of the series start across daylight saving time changes; those falling
+// in a gap are shifted forward by its length, as RFC 5545 requires.
+type RRule struct {
+	freq     string
+	interval int
+	// Count is the number of occurrences of the series, 0 when unbounded.
+	// Next does not count occurrences, so callers enforce it.
+	Count      int
+	until      *time.Time
+	byDay      []rruleWeekday
+	byMonthDay []int
+	byMonth    []int
+	bySetPos   []int
+	weekStart  time.Weekday
+	loc        *time.Location
+}
+
+// rruleWeekday is a BYDAY entry such as "MO", "2TU" (second Tuesday) or "-1FR"
+// (last Friday). n is 0 for every such weekday.
+type rruleWeekday struct {
+	n   int
+	day time.Weekday
+}
+
+// ParseRRule parses a rule such as "FREQ=MONTHLY;BYDAY=2TU" evaluated in loc.
+// The "RRULE:" prefix is optional.
+func ParseRRule(rule string, loc *time.Location) (*RRule, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	r := &RRule{interval: 1, weekStart: time.Monday, loc: loc}
+
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if rule == "" {
+		return nil, errors.New("rule is empty")
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed part %q", part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%s is repeated", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			switch value {
+			case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+				r.freq = value
+			default:
+				err = fmt.Errorf("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			r.interval, err = parseRRuleInt(value, 1, MaxRRuleInterval)
+		case "COUNT":
+			r.Count, err = parseRRuleInt(value, 1, MaxRRuleCount)
+		case "UNTIL":
+			r.until, err = parseRRuleUntil(value, loc)
+		case "BYDAY":
+			r.byDay, err = parseRRuleWeekdays(value)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRRuleInts(value, 1, 31, true)
+		case "BYMONTH":
+			r.byMonth, err = parseRRuleInts(value, 1, 12, false)
+		case "BYSETPOS":
+			r.bySetPos, err = parseRRuleInts(value, 1, 366, true)
+		case "WKST":
+			day, ok := rruleWeekdays[value]
+			if !ok {
+				err = fmt.Errorf("invalid WKST %s", value)
+			}
+			r.weekStart = day
+		default:
+			err = fmt.Errorf("unsupported part %s", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// validate rejects the combinations RFC 5545 leaves undefined
+func (r *RRule) validate() error {
+	if r.freq == "" {
+		return errors.New("FREQ is required")
+	}
+	if r.Count > 0 && r.until != nil {
+		return errors.New("COUNT and UNTIL cannot both be set")
+	}
+	if r.freq == FreqWeekly && len(r.byMonthDay) > 0 {
+		return errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	for _, wd := range r.byDay {
+		if wd.n == 0 {
+			continue
+		}
+		if r.freq != FreqMonthly && r.freq != FreqYearly {
+			return errors.New("numbered BYDAY needs FREQ=MONTHLY or FREQ=YEARLY")
+		}
+		limit := 53
+		if r.freq == FreqMonthly || len(r.byMonth) > 0 {
+			limit = 5
+		}
+		if wd.n > limit || wd.n < -limit {
+			return fmt.Errorf("BYDAY position %d is out of range", wd.n)
+		}
+	}
+	if len(r.bySetPos) > 0 && len(r.byDay)+len(r.byMonthDay)+len(r.byMonth) == 0 {
+		return errors.New("BYSETPOS needs another BY part")
+	}
+	// A position no period reaches would have Next search for it in vain
+	size := r.maxSetSize()
+	for _, pos := range r.bySetPos {
+		if pos > size || pos < -size {
+			return fmt.Errorf("BYSETPOS %d is beyond the %d days of a period", pos, size)
+		}
+	}
+	if !r.matchesAnyDay() {
+		return errors.New("rule matches no date")
+	}
+	return nil
+}
+
+// matchesAnyDay reports whether the BY parts match a day at all, which rules
+// such as February 30th or the 20th Monday of the year on the 1st never do.
+// Weekdays and leap years repeat every 28 years from 1901 to 2099, so it is
+// enough to look at 28 of them.
+func (r *RRule) matchesAnyDay() bool {
+	if len(r.byDay)+len(r.byMonthDay)+len(r.byMonth) == 0 || r.freq == FreqWeekly {
+		return true
+	}
+
+	first := time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+	periods := 28
+	switch r.freq {
+	case FreqDaily:
+		periods = rruleDays(first, first.AddDate(28, 0, 0))
+	case FreqMonthly:
+		periods = 28 * 12
+	}
+	for offset := 0; offset < periods; offset++ {
+		if days, _ := r.periodDays(first, offset); len(days) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// maxSetSize returns an upper bound of the number of days of a period
+func (r *RRule) maxSetSize() int {
+	switch r.freq {
+	case FreqWeekly:
+		if len(r.byDay) > 0 {
+			return min(len(r.byDay), 7)
+		}
+		return 1
+	case FreqMonthly:
+		return r.maxMonthDays()
+	case FreqYearly:
+		switch {
+		case len(r.byMonth) > 0:
+			return min(len(r.byMonth)*r.maxMonthDays(), 366)
+		case len(r.byMonthDay) > 0:
+			return min(12*len(r.byMonthDay), 366)
+		case len(r.byDay) > 0:
+			size := 0
+			for _, wd := range r.byDay {
+				if wd.n == 0 {
+					size += 53
+				} else {
+					size++
+				}
+			}
+			return min(size, 366)
+		}
+	}
+	return 1
+}
+
+// maxMonthDays returns an upper bound of the number of days of a month
+// matching BYMONTHDAY and BYDAY
+func (r *RRule) maxMonthDays() int {
+	switch {
+	case len(r.byMonthDay) > 0:
+		return min(len(r.byMonthDay), 31)
+	case len(r.byDay) > 0:
+		size := 0
+		for _, wd := range r.byDay {
+			if wd.n == 0 {
+				size += 5
+			} else {
+				size++
+			}
+		}
+		return min(size, 31)
+	}
+	return 1
+}
+
+// Location returns the time zone the rule is evaluated in
+func (r *RRule) Location() *time.Location {
+	return r.loc
+}
+
+// Next returns the first occurrence after after of the series that begins at
+// dtstart, in the rule's time zone. Occurrences are at the local time of day
+// of dtstart. It reports false when the series ends before another occurrence.
+func (r *RRule) Next(dtstart, after time.Time) (time.Time, bool) {
+	dtstart = dtstart.In(r.loc)
+	hour, minute, second := dtstart.Clock()
+	first := rruleDate(dtstart)
+
+	// Start from the period containing after rather than walking from dtstart
+	skip := 0
+	if after.After(dtstart) {
+		last := rruleDate(after.In(r.loc))
+		switch r.freq {
+		case FreqDaily:
+			skip = rruleDays(first, last) / r.interval
+		case FreqWeekly:
+			skip = rruleDays(r.weekOf(first), r.weekOf(last)) / 7 / r.interval
+		case FreqMonthly:
+			skip = ((last.Year()-first.Year())*12 + int(last.Month()-first.Month())) / r.interval
+		case FreqYearly:
+			skip = (last.Year() - first.Year()) / r.interval
+		}
+	}
+
+	for period, checked := skip, 0; checked < MaxRRuleDays; period++ {
+		days, n := r.periodDays(first, period*r.interval)
+		checked += n
+		for _, day := range days {
+			t := r.localTime(day, hour, minute, second)
+			if t.Before(dtstart) || !t.After(after) {
+				continue
+			}
+			if r.until != nil && t.After(*r.until) {
+				return time.Time{}, false
+			}
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// localTime returns the time of day on day in the rule's time zone. A time
+// that does not exist, as it falls in a daylight saving time gap, is taken
+// with the UTC offset before the gap, which shifts it forward by the gap.
+func (r *RRule) localTime(day time.Time, hour, minute, second int) time.Time {
+	t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, r.loc)
+	if h, m, s := t.Clock(); h == hour && m == minute && s == second {
+		return t
+	}
+
+	// The day before at that time of day has the offset before the gap
+	_, offset := time.Date(day.Year(), day.Month(), day.Day()-1, hour, minute, second, 0, r.loc).Zone()
+	wall := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, time.UTC)
+	return wall.Add(-time.Duration(offset) * time.Second).In(r.loc)
+}
+
+// rruleDate returns the calendar day of t as a UTC midnight, so that date
+// arithmetic is not affected by daylight saving time
+func rruleDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// rruleDays returns the number of days from one UTC midnight to another
+func rruleDays(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// weekOf returns the first day of the week of day, which starts on WKST
+func (r *RRule) weekOf(day time.Time) time.Time {
+	return day.AddDate(0, 0, -int((7+day.Weekday()-r.weekStart)%7))
+}
+
+// periodDays returns the dates of the period offset periods after the one
+// containing first, in order, and how many candidate days were checked. Dates
+// are UTC midnights; only their calendar day is used.
+func (r *RRule) periodDays(first time.Time, offset int) ([]time.Time, int) {
+	var days []time.Time
+	checked := 1
+
+	switch r.freq {
+	case FreqDaily:
+		day := first.AddDate(0, 0, offset)
+		if r.monthAllowed(day.Month()) && r.monthDayAllowed(day) && r.weekdayAllowed(day) {
+			days = append(days, day)
+		}
+	case FreqWeekly:
+		weekStart := r.weekOf(first).AddDate(0, 0, 7*offset)
+		checked = 7
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			matches := day.Weekday() == first.Weekday()
+			if len(r.byDay) > 0 {
+				matches = r.weekdayAllowed(day)
+			}
+			if matches && r.monthAllowed(day.Month()) {
+				days = append(days, day)
+			}
+		}
+	case FreqMonthly:
+		month := time.Date(first.Year(), first.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		if r.monthAllowed(month.Month()) {
+			days, checked = r.monthDays(month, first.Day())
+		}
+	case FreqYearly:
+		year := first.Year() + offset
+		switch {
+		case len(r.byMonth) > 0:
+			checked = 0
+			for _, m := range r.byMonth {
+				monthDays, n := r.monthDays(time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC), first.Day())
+				days = append(days, monthDays...)
+				checked += n
+			}
+		case len(r.byMonthDay) > 0 || len(r.byDay) > 0:
+			// Without BYMONTH, numbered BYDAY counts weekdays within the year
+			start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+			checked = 0
+			for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+				checked++
+				if r.monthDayAllowed(day) && (len(r.byDay) == 0 || r.byDayMatches(day, start, end)) {
+					days = append(days, day)
+				}
+			}
+		default:
+			day := time.Date(year, first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+			// February 29th only occurs in leap years
+			if day.Month() == first.Month() {
+				days = append(days, day)
+			}
+		}
+	}
+
+	return r.applySetPos(days), checked
+}
+
+// monthDays returns the days of the month starting at month that match
+// BYMONTHDAY and BYDAY, or its day dayOfMonth when neither is set, and how
+// many candidate days were checked
+func (r *RRule) monthDays(month time.Time, dayOfMonth int) ([]time.Time, int) {
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		day := month.AddDate(0, 0, dayOfMonth-1)
+		// Months without that day, such as the 31st, are skipped
+		if day.Month() != month.Month() {
+			return nil, 1
+		}
+		return []time.Time{day}, 1
+	}
+
+	end := month.AddDate(0, 1, -1)
+	var days []time.Time
+	for day := month; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if !r.monthDayAllowed(day) {
+			continue
+		}
+		if len(r.byDay) > 0 && !r.byDayMatches(day, month, end) {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days, end.Day()
+}
+
+func (r *RRule) monthAllowed(month time.Month) bool {
+	if len(r.byMonth) == 0 {
+		return true
+	}
+	for _, m := range r.byMonth {
+		if time.Month(m) == month {
+			return true
+		}
+	}
+	return false
+}
+
+// monthDayAllowed matches BYMONTHDAY, where negative days count from the end of the month
+func (r *RRule) monthDayAllowed(day time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, md := range r.byMonthDay {
+		if md == day.Day() || (md < 0 && daysInMonth+md+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayAllowed matches the weekdays of BYDAY, ignoring positions
+func (r *RRule) weekdayAllowed(day time.Time) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, wd := range r.byDay {
+		if wd.day == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// byDayMatches matches BYDAY within the range start..end, where "2TU" is the
+// second Tuesday of the range and "-1FR" its last Friday
+func (r *RRule) byDayMatches(day, start, end time.Time) bool {
+	for _, wd := range r.byDay {
+		if wd.day != day.Weekday() {
+			continue
+		}
+		switch {
+		case wd.n == 0:
+			return true
+		case wd.n > 0 && rruleDays(start, day)/7+1 == wd.n:
+			return true
+		case wd.n < 0 && rruleDays(day, end)/7+1 == -wd.n:
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos keeps the BYSETPOS positions of the period's days
+func (r *RRule) applySetPos(days []time.Time) []time.Time {
+	if len(r.bySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+
+	selected := make(map[int]bool)
+	for _, pos := range r.bySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			selected[i] = true
+		}
+	}
+
+	kept := make([]time.Time, 0, len(selected))
+	for i, day := range days {
+		if selected[i] {
+			kept = append(kept, day)
+		}
+	}
+	return kept
+}
+
+func parseRRuleInt(value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%s is not between %d and %d", value, min, max)
+	}
+	return n, nil
+}
+
+// parseRRuleInts parses a sorted list of values between min and max, or their
+// negatives when negative is set
+func parseRRuleInts(value string, min, max int, negative bool) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err == nil && negative && n < 0 {
+			n = -n
+			if n >= min && n <= max {
+				values = append(values, -n)
+				continue
+			}
+		}
+		if err != nil || n < min || n > max {
+			return nil, fmt.Errorf("%s is out of range", item)
+		}
+		values = append(values, n)
+	}
+	sort.Ints(values)
+	return values, nil
+}
+
+func parseRRuleWeekdays(value string) ([]rruleWeekday, error) {
+	var weekdays []rruleWeekday
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY %s", item)
+		}
+		day, ok := rruleWeekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("invalid BYDAY %s", item)
+		}
+		wd := rruleWeekday{day: day}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 {
+				return nil, fmt.Errorf("invalid BYDAY %s", item)
+			}
+			wd.n = n
+		}
+		weekdays = append(weekdays, wd)
+	}
+	return weekdays, nil
+}
+
+// parseRRuleUntil parses UNTIL as a UTC time ("20261231T170000Z"), a local
+// time ("20261231T170000") or a date ("20261231"), which includes that whole day
+func parseRRuleUntil(value string, loc *time.Location) (*time.Time, error) {
+	var until time.Time
+	var err error
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		until, err = time.Parse("20060102T150405Z", value)
+	case strings.Contains(value, "T"):
+		until, err = time.ParseInLocation("20060102T150405", value, loc)
+	default:
+		until, err = time.ParseInLocation("20060102", value, loc)
+		until = until.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid UNTIL %s", value)
+	}
+	return &until, nil
+}
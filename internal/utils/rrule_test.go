@@ -0,0 +1,252 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// occurrences returns up to n occurrences of rule after dtstart, formatted in
+// the rule's time zone
+func occurrences(t *testing.T, rule string, loc *time.Location, dtstart time.Time, n int) []string {
+	t.Helper()
+	r := mustParseRRule(t, rule, loc)
+
+	var result []string
+	after := dtstart
+	for i := 0; i < n; i++ {
+		next, ok := r.Next(dtstart, after)
+		if !ok {
+			break
+		}
+		result = append(result, next.Format("2006-01-02 15:04 MST"))
+		after = next
+	}
+	return result
+}
+
+func TestRRule_Next(t *testing.T) {
+	// Monday, January 5th 2026, 09:00 UTC
+	start := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rule     string
+		start    time.Time
+		expected []string
+	}{
+		{
+			name:     "daily",
+			rule:     "FREQ=DAILY",
+			start:    start,
+			expected: []string{"2026-01-06 09:00 UTC", "2026-01-07 09:00 UTC", "2026-01-08 09:00 UTC"},
+		},
+		{
+			name:     "every third day",
+			rule:     "RRULE:FREQ=DAILY;INTERVAL=3",
+			start:    start,
+			expected: []string{"2026-01-08 09:00 UTC", "2026-01-11 09:00 UTC", "2026-01-14 09:00 UTC"},
+		},
+		{
+			name:     "weekdays",
+			rule:     "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			start:    time.Date(2026, time.January, 8, 9, 0, 0, 0, time.UTC),
+			expected: []string{"2026-01-09 09:00 UTC", "2026-01-12 09:00 UTC", "2026-01-13 09:00 UTC"},
+		},
+		{
+			name:     "weekly on the start's weekday",
+			rule:     "FREQ=WEEKLY",
+			start:    start,
+			expected: []string{"2026-01-12 09:00 UTC", "2026-01-19 09:00 UTC"},
+		},
+		{
+			name:     "every other Tuesday",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			start:    start,
+			expected: []string{"2026-01-06 09:00 UTC", "2026-01-20 09:00 UTC", "2026-02-03 09:00 UTC"},
+		},
+		{
+			name:     "second Tuesday of the month",
+			rule:     "FREQ=MONTHLY;BYDAY=2TU",
+			start:    start,
+			expected: []string{"2026-01-13 09:00 UTC", "2026-02-10 09:00 UTC", "2026-03-10 09:00 UTC"},
+		},
+		{
+			name:     "last day of the month",
+			rule:     "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start:    start,
+			expected: []string{"2026-01-31 09:00 UTC", "2026-02-28 09:00 UTC", "2026-03-31 09:00 UTC", "2026-04-30 09:00 UTC"},
+		},
+		{
+			name:     "last weekday of the month",
+			rule:     "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			start:    start,
+			expected: []string{"2026-01-30 09:00 UTC", "2026-02-27 09:00 UTC", "2026-03-31 09:00 UTC"},
+		},
+		{
+			name:     "monthly on the 31st skips shorter months",
+			rule:     "FREQ=MONTHLY",
+			start:    time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC),
+			expected: []string{"2026-03-31 09:00 UTC", "2026-05-31 09:00 UTC"},
+		},
+		{
+			name:     "quarterly on the 1st and 15th",
+			rule:     "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1,15",
+			start:    start,
+			expected: []string{"2026-01-15 09:00 UTC", "2026-04-01 09:00 UTC", "2026-04-15 09:00 UTC"},
+		},
+		{
+			name:     "yearly on February 29th",
+			rule:     "FREQ=YEARLY",
+			start:    time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC),
+			expected: []string{"2028-02-29 09:00 UTC", "2032-02-29 09:00 UTC"},
+		},
+		{
+			name:     "last Friday of November",
+			rule:     "FREQ=YEARLY;BYMONTH=11;BYDAY=-1FR",
+			start:    start,
+			expected: []string{"2026-11-27 09:00 UTC", "2027-11-26 09:00 UTC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, occurrences(t, tt.rule, time.UTC, tt.start, len(tt.expected)))
+		})
+	}
+}
+
+func TestRRule_NextEndsSeries(t *testing.T) {
+	start := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []string{"2026-01-06 09:00 UTC"},
+		occurrences(t, "FREQ=DAILY;UNTIL=20260106T090000Z", time.UTC, start, 5),
+		"occurrences after UNTIL are not returned")
+	assert.Equal(t, []string{"2026-01-12 09:00 UTC", "2026-01-19 09:00 UTC"},
+		occurrences(t, "FREQ=WEEKLY;UNTIL=20260119", time.UTC, start, 5),
+		"a date UNTIL includes that day")
+
+	// February 29th every 4 years from 2026 never falls in a leap year
+	begin := time.Now()
+	_, ok := mustParseRRule(t, "FREQ=YEARLY;INTERVAL=4;BYMONTH=2;BYMONTHDAY=29", time.UTC).Next(start, start)
+	assert.False(t, ok, "a series without dates has no next occurrence")
+	assert.Less(t, time.Since(begin), 100*time.Millisecond, "the search is bounded by candidate days")
+}
+
+func TestRRule_NumberedWeekdayOfYear(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	// Without BYMONTH, the 20th Monday is counted within the year, not the month
+	assert.Equal(t, []string{"2026-05-18 09:00 UTC", "2027-05-17 09:00 UTC"},
+		occurrences(t, "FREQ=YEARLY;BYDAY=20MO", time.UTC, start, 2))
+
+	// The 20th Monday of the year falls on the 18th in 2026 and 2037
+	assert.Equal(t, []string{"2026-05-18 09:00 UTC", "2037-05-18 09:00 UTC"},
+		occurrences(t, "FREQ=YEARLY;BYDAY=20MO;BYMONTHDAY=18", time.UTC, start, 2))
+
+	// Only years with 53 Mondays have a 53rd last Monday, their first one
+	assert.Equal(t, []string{"2029-01-01 09:00 UTC"},
+		occurrences(t, "FREQ=YEARLY;BYDAY=-53MO;BYMONTHDAY=1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,29,30,31", time.UTC, start, 1))
+}
+
+func TestRRule_NextLongAfterStart(t *testing.T) {
+	start := time.Date(2026, time.January, 5, 9, 0, 0, 0, time.UTC)
+	after := time.Date(2070, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	next, ok := mustParseRRule(t, "FREQ=DAILY;INTERVAL=2", time.UTC).Next(start, after)
+	assert.True(t, ok, "series are not limited to MaxRRulePeriods periods from their start")
+	assert.Equal(t, time.Date(2070, time.June, 3, 9, 0, 0, 0, time.UTC), next, "the interval is counted from the start")
+
+	next, ok = mustParseRRule(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", time.UTC).Next(start, time.Date(2026, time.January, 13, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.January, 19, 9, 0, 0, 0, time.UTC), next)
+}
+
+func TestRRule_DaylightSavingTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Clocks in Paris go forward on March 29th 2026; the local time of day is kept
+	assert.Equal(t,
+		[]string{"2026-03-28 09:00 CET", "2026-03-29 09:00 CEST", "2026-03-30 09:00 CEST"},
+		occurrences(t, "FREQ=DAILY", paris, time.Date(2026, time.March, 27, 9, 0, 0, 0, paris), 3))
+
+	// Clocks in New York go back on November 1st 2026
+	assert.Equal(t,
+		[]string{"2026-11-02 08:30 EST", "2026-11-09 08:30 EST"},
+		occurrences(t, "FREQ=WEEKLY", newYork, time.Date(2026, time.October, 26, 8, 30, 0, 0, newYork), 2))
+
+	// A start given in UTC is evaluated in the rule's time zone
+	start := time.Date(2026, time.October, 24, 7, 0, 0, 0, time.UTC)
+	next, ok := mustParseRRule(t, "FREQ=DAILY", paris).Next(start, start)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2026, time.October, 25, 8, 0, 0, 0, time.UTC), next.UTC(), "09:00 CET after the change")
+
+	// 02:30 does not exist in Paris on March 29th: that occurrence is shifted
+	// forward by the one hour gap, and the following ones are at 02:30 again
+	assert.Equal(t,
+		[]string{"2026-03-29 03:30 CEST", "2026-03-30 02:30 CEST"},
+		occurrences(t, "FREQ=DAILY", paris, time.Date(2026, time.March, 28, 2, 30, 0, 0, paris), 2))
+
+	// Likewise in New York on March 8th, where clocks go from 02:00 EST to 03:00 EDT
+	assert.Equal(t,
+		[]string{"2026-03-08 03:30 EDT", "2026-03-09 02:30 EDT"},
+		occurrences(t, "FREQ=DAILY", newYork, time.Date(2026, time.March, 7, 2, 30, 0, 0, newYork), 2))
+}
+
+func TestRRule_DaysInTimeZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// Sunday 23:00 UTC is already Monday in Tokyo
+	start := time.Date(2026, time.January, 4, 23, 0, 0, 0, time.UTC)
+	next, ok := mustParseRRule(t, "FREQ=WEEKLY;BYDAY=MO,WE", tokyo).Next(start, start)
+	assert.True(t, ok)
+	assert.Equal(t, "2026-01-07 08:00 JST", next.Format("2006-01-02 15:04 MST"))
+}
+
+func TestParseRRule_Invalid(t *testing.T) {
+	rules := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20261231",
+		"FREQ=DAILY;COUNT=100000",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=WEEKLY;BYDAY=2TU",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;BYDAY=MO;BYSETPOS=2",
+		"FREQ=WEEKLY;BYDAY=MO,FR;BYSETPOS=-3",
+		"FREQ=MONTHLY;BYDAY=MO;BYSETPOS=6",
+		"FREQ=YEARLY;BYDAY=MO;BYSETPOS=366",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+		"FREQ=YEARLY;BYDAY=20MO;BYMONTHDAY=1",
+		"FREQ=DAILY;BYMONTH=4;BYMONTHDAY=31",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ",
+	}
+
+	for _, rule := range rules {
+		_, err := ParseRRule(rule, time.UTC)
+		assert.Error(t, err, "rule %q", rule)
+	}
+}
+
+func mustParseRRule(t *testing.T, rule string, loc *time.Location) *RRule {
+	t.Helper()
+	r, err := ParseRRule(rule, loc)
+	require.NoError(t, err)
+	return r
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestHOTP_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tt := range tests {
+		code, err := hotp(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)), 8)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, code, "time %d", tt.unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := TOTPStep(now)
+
+	current, _ := TOTPCode(rfc6238Secret, step)
+	previous, _ := TOTPCode(rfc6238Secret, step-1)
+	stale, _ := TOTPCode(rfc6238Secret, step-2)
+
+	matched, ok := ValidateTOTP(rfc6238Secret, current, now)
+	assert.True(t, ok)
+	assert.Equal(t, step, matched)
+
+	matched, ok = ValidateTOTP(rfc6238Secret, previous, now)
+	assert.True(t, ok, "codes of the previous period are accepted")
+	assert.Equal(t, step-1, matched)
+
+	_, ok = ValidateTOTP(rfc6238Secret, stale, now)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(rfc6238Secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri, err := url.Parse(TOTPURI("Todo List", "user@example.com", "JBSWY3DPEHPK3PXP"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Todo List:user@example.com", uri.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", uri.Query().Get("secret"))
+	assert.Equal(t, "Todo List", uri.Query().Get("issuer"))
+}